@@ -1,14 +1,19 @@
 package onvif
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/beevik/etree"
 	"github.com/ritj/onvif/device"
@@ -20,6 +25,7 @@ import (
 // Xlmns XML Schema
 var Xlmns = map[string]string{
 	"onvif":   "http://www.onvif.org/ver10/schema",
+	"tt":      "http://www.onvif.org/ver10/schema",
 	"tds":     "http://www.onvif.org/ver10/device/wsdl",
 	"trt":     "http://www.onvif.org/ver10/media/wsdl",
 	"tev":     "http://www.onvif.org/ver10/events/wsdl",
@@ -34,6 +40,23 @@ var Xlmns = map[string]string{
 	"wsntw":   "http://docs.oasis-open.org/wsn/bw-2",
 	"wsrf-rw": "http://docs.oasis-open.org/wsrf/rw-2",
 	"wsaw":    "http://www.w3.org/2006/05/addressing/wsdl",
+	"tr2":     "http://www.onvif.org/ver20/media/wsdl",
+	"tmd":     "http://www.onvif.org/ver10/deviceIO/wsdl",
+	"trc":     "http://www.onvif.org/ver10/recording/wsdl",
+	"tse":     "http://www.onvif.org/ver10/search/wsdl",
+	"trp":     "http://www.onvif.org/ver10/replay/wsdl",
+	"trv":     "http://www.onvif.org/ver10/receiver/wsdl",
+	"tad":     "http://www.onvif.org/ver10/analyticsdevice/wsdl",
+	"tth":     "http://www.onvif.org/ver10/thermal/wsdl",
+	"tls":     "http://www.onvif.org/ver10/display/wsdl",
+	"tas":     "http://www.onvif.org/ver10/advancedsecurity/wsdl",
+	"tae":     "http://www.onvif.org/ver10/actionengine/wsdl",
+	"tpv":     "http://www.onvif.org/ver10/provisioning/wsdl",
+	"tsc":     "http://www.onvif.org/ver10/schedule/wsdl",
+	"tac":     "http://www.onvif.org/ver10/accesscontrol/wsdl",
+	"tar":     "http://www.onvif.org/ver10/accessrules/wsdl",
+	"tcr":     "http://www.onvif.org/ver10/credential/wsdl",
+	"tdc":     "http://www.onvif.org/ver10/doorcontrol/wsdl",
 }
 
 // DeviceType alias for int
@@ -79,6 +102,9 @@ type Device struct {
 	params    DeviceParams
 	endpoints map[string]string
 	info      DeviceInfo
+	// location is shared by the copies of a device identified by its
+	// endpoint reference, it changes when the device is resolved again.
+	location *location
 }
 
 type DeviceParams struct {
@@ -86,11 +112,41 @@ type DeviceParams struct {
 	Username   string
 	Password   string
 	HttpClient *http.Client
+	// EndpointReference identifies the device whatever its address, see
+	// device.GetEndpointReference. When set, a device that does not answer,
+	// e.g. given another address by DHCP, is found again with a WS-Discovery
+	// Resolve, and Xaddr may be empty.
+	EndpointReference string
+}
+
+// location is where a device identified by its endpoint reference answers.
+type location struct {
+	mu        sync.RWMutex
+	xaddr     string
+	endpoints map[string]string
+	// moved maps the former addresses of the device to the current one.
+	moved map[string]string
+	// resolving serializes the resolutions of the device.
+	resolving sync.Mutex
+}
+
+// resolveTimeout bounds the wait for the reply to a Resolve.
+const resolveTimeout = 2 * time.Second
+
+// where returns the address and the endpoints of the device.
+func (dev Device) where() (string, map[string]string) {
+	if dev.location == nil {
+		return dev.params.Xaddr, dev.endpoints
+	}
+	dev.location.mu.RLock()
+	defer dev.location.mu.RUnlock()
+	return dev.location.xaddr, dev.location.endpoints
 }
 
 // GetServices return available endpoints
 func (dev *Device) GetServices() map[string]string {
-	return dev.endpoints
+	_, endpoints := dev.where()
+	return endpoints
 }
 
 // GetServices return available endpoints
@@ -100,7 +156,9 @@ func (dev *Device) GetDeviceInfo() DeviceInfo {
 
 // GetDeviceParams return available endpoints
 func (dev *Device) GetDeviceParams() DeviceParams {
-	return dev.params
+	params := dev.params
+	params.Xaddr, _ = dev.where()
+	return params
 }
 
 func readResponse(resp *http.Response) string {
@@ -119,26 +177,22 @@ func GetAvailableDevicesAtSpecificEthernetInterface(interfaceName string) ([]Dev
 		return nil, err
 	}
 
-	nvtDevicesSeen := make(map[string]bool)
 	nvtDevices := make([]Device, 0)
 
-	for _, j := range devices {
-		doc := etree.NewDocument()
-		if err := doc.ReadFromString(j); err != nil {
-			return nil, err
-		}
-
-		for _, xaddr := range doc.Root().FindElements("./Body/ProbeMatches/ProbeMatch/XAddrs") {
-			xaddr := strings.Split(strings.Split(xaddr.Text(), " ")[0], "/")[2]
-			if !nvtDevicesSeen[xaddr] {
-				dev, err := NewDevice(DeviceParams{Xaddr: strings.Split(xaddr, " ")[0]})
-				if err != nil {
-					// TODO(jfsmig) print a warning
-				} else {
-					nvtDevicesSeen[xaddr] = true
-					nvtDevices = append(nvtDevices, *dev)
-				}
+	// The replies are deduplicated by endpoint reference, a device is made
+	// from the first of its addresses that answers.
+	for _, match := range devices {
+		for _, xaddr := range match.XAddrs {
+			// The device was just found at xaddr, a Resolve would find
+			// nothing more.
+			dev, err := newDevice(DeviceParams{Xaddr: xaddr.Host, EndpointReference: wsdiscovery.EndpointAddress(match.Address)})
+			if err != nil {
+				// TODO(jfsmig) print a warning
+				continue
 			}
+			dev.locate()
+			nvtDevices = append(nvtDevices, *dev)
+			break
 		}
 	}
 
@@ -174,7 +228,93 @@ func (dev *Device) getSupportedServices(resp *http.Response) error {
 }
 
 // NewDevice function construct a ONVIF Device entity
+// A device identified by its endpoint reference that cannot be connected to
+// at Xaddr is resolved, any other failure is returned as is.
 func NewDevice(params DeviceParams) (*Device, error) {
+	if params.EndpointReference == "" {
+		return newDevice(params)
+	}
+	params.EndpointReference = wsdiscovery.EndpointAddress(params.EndpointReference)
+	var dev *Device
+	var err error
+	if params.Xaddr != "" {
+		dev, err = newDevice(params)
+		if err != nil && !undelivered(err) {
+			return nil, err
+		}
+	}
+	if dev == nil {
+		resolved, resolveErr := resolveDevice(params)
+		if resolveErr != nil {
+			// The failure at Xaddr tells more than the missing reply.
+			if err == nil {
+				err = resolveErr
+			}
+			return nil, err
+		}
+		dev = resolved
+	}
+	dev.locate()
+	return dev, nil
+}
+
+// locate makes the device, identified by its endpoint reference, resolved
+// again when it does not answer anymore.
+func (dev *Device) locate() {
+	dev.location = &location{xaddr: dev.params.Xaddr, endpoints: dev.endpoints, moved: map[string]string{}}
+}
+
+// resolveDevice finds a device by its endpoint reference and connects to the
+// first of its addresses that answers.
+func resolveDevice(params DeviceParams) (*Device, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+	endpoint, err := wsdiscovery.Resolve(ctx, params.EndpointReference, wsdiscovery.DiscoverOptions{Timeout: resolveTimeout})
+	if err != nil {
+		return nil, err
+	}
+	for _, xaddr := range endpoint.XAddrs {
+		params.Xaddr = xaddr.Host
+		var dev *Device
+		if dev, err = newDevice(params); err == nil {
+			return dev, nil
+		}
+	}
+	return nil, err
+}
+
+// relocate resolves the device again after it did not answer at xaddr, and
+// moves its endpoints to its new address. It fails when the device is not
+// identified by its endpoint reference, or did not move.
+func (dev Device) relocate(xaddr string) error {
+	l := dev.location
+	if l == nil {
+		return errors.New("device not identified by its endpoint reference")
+	}
+	l.resolving.Lock()
+	defer l.resolving.Unlock()
+	if current, _ := dev.where(); current != xaddr {
+		// Moved by another call meanwhile.
+		return nil
+	}
+	moved, err := resolveDevice(dev.params)
+	if err != nil {
+		return err
+	}
+	if moved.params.Xaddr == xaddr {
+		return errors.New("device still at " + xaddr)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for former := range l.moved {
+		l.moved[former] = moved.params.Xaddr
+	}
+	l.moved[xaddr] = moved.params.Xaddr
+	l.xaddr, l.endpoints = moved.params.Xaddr, moved.endpoints
+	return nil
+}
+
+func newDevice(params DeviceParams) (*Device, error) {
 	dev := new(Device)
 	dev.params = params
 	dev.endpoints = make(map[string]string)
@@ -188,7 +328,11 @@ func NewDevice(params DeviceParams) (*Device, error) {
 
 	resp, err := dev.CallMethod(getCapabilities)
 
-	if err != nil || resp.StatusCode != http.StatusOK {
+	if err != nil {
+		return nil, fmt.Errorf("camera is not available at %s or it does not support ONVIF services: %w", dev.params.Xaddr, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
 		return nil, errors.New("camera is not available at " + dev.params.Xaddr + " or it does not support ONVIF services")
 	}
 
@@ -232,22 +376,34 @@ func isLocalhostOrEmpty(host string) bool {
 // FixEndpointAddress replaces the host in a URL with the device's actual address
 // only if the host is localhost, 127.0.0.1, or empty.
 // This is used to fix localhost addresses that cameras sometimes return.
+// The former addresses of a device that moved are replaced too.
 func (dev *Device) FixEndpointAddress(address string) string {
 	if address == "" {
 		return address
 	}
 	if u, err := url.Parse(address); err == nil {
+		xaddr, _ := dev.where()
 		if isLocalhostOrEmpty(u.Host) {
-			u.Host = dev.params.Xaddr
+			u.Host = xaddr
 			return u.String()
 		}
+		if dev.location != nil {
+			dev.location.mu.RLock()
+			current, ok := dev.location.moved[u.Host]
+			dev.location.mu.RUnlock()
+			if ok {
+				u.Host = current
+				return u.String()
+			}
+		}
 	}
 	return address
 }
 
 // GetEndpoint returns specific ONVIF service endpoint address
 func (dev *Device) GetEndpoint(name string) string {
-	return dev.endpoints[name]
+	_, endpoints := dev.where()
+	return endpoints[name]
 }
 
 func (dev Device) buildMethodSOAP(msg string) (gosoap.SoapMessage, error) {
@@ -267,9 +423,10 @@ func (dev Device) buildMethodSOAP(msg string) (gosoap.SoapMessage, error) {
 
 // getEndpoint functions get the target service endpoint in a better way
 func (dev Device) getEndpoint(endpoint string) (string, error) {
+	_, endpoints := dev.where()
 
 	// common condition, endpointMark in map we use this.
-	if endpointURL, bFound := dev.endpoints[endpoint]; bFound {
+	if endpointURL, bFound := endpoints[endpoint]; bFound {
 		return endpointURL, nil
 	}
 
@@ -277,9 +434,9 @@ func (dev Device) getEndpoint(endpoint string) (string, error) {
 	//and sametime the Targetkey like : events、analytics
 	//we use fuzzy way to find the best match url
 	var endpointURL string
-	for targetKey := range dev.endpoints {
+	for targetKey := range endpoints {
 		if strings.Contains(targetKey, endpoint) {
-			endpointURL = dev.endpoints[targetKey]
+			endpointURL = endpoints[targetKey]
 			return endpointURL, nil
 		}
 	}
@@ -288,19 +445,88 @@ func (dev Device) getEndpoint(endpoint string) (string, error) {
 
 // CallMethod functions call an method, defined <method> struct.
 // You should use Authenticate method to call authorized requests.
+// A device identified by its endpoint reference that cannot be connected to
+// is resolved again, and the method sent to its new address.
 func (dev Device) CallMethod(method interface{}) (*http.Response, error) {
-	pkgPath := strings.Split(reflect.TypeOf(method).PkgPath(), "/")
-	pkg := strings.ToLower(pkgPath[len(pkgPath)-1])
+	return dev.CallMethodContext(context.Background(), method)
+}
 
-	endpoint, err := dev.getEndpoint(pkg)
+// CallMethodContext is CallMethod, the request being canceled when ctx is done.
+func (dev Device) CallMethodContext(ctx context.Context, method interface{}) (*http.Response, error) {
+	xaddr, _ := dev.where()
+	endpoint, err := dev.getEndpoint(serviceOf(method))
 	if err != nil {
 		return nil, err
 	}
-	return dev.callMethodDo(endpoint, method)
+	resp, err := dev.callMethodDo(ctx, endpoint, method)
+	if undelivered(err) && dev.relocate(xaddr) == nil {
+		if endpoint, err = dev.getEndpoint(serviceOf(method)); err != nil {
+			return nil, err
+		}
+		return dev.callMethodDo(ctx, endpoint, method)
+	}
+	return resp, err
+}
+
+// CallMethodAt sends method to endpoint rather than to the endpoint of its
+// service, e.g. to the subscription manager of an event subscription. Every
+// header is the XML of an element added to the SOAP header.
+func (dev Device) CallMethodAt(endpoint string, method interface{}, headers ...string) (*http.Response, error) {
+	return dev.CallMethodAtContext(context.Background(), endpoint, method, headers...)
+}
+
+// CallMethodAtContext is CallMethodAt, the request being canceled when ctx is
+// done.
+func (dev Device) CallMethodAtContext(ctx context.Context, endpoint string, method interface{}, headers ...string) (*http.Response, error) {
+	xaddr, _ := dev.where()
+	resp, err := dev.callMethodDo(ctx, dev.FixEndpointAddress(endpoint), method, headers...)
+	if undelivered(err) && dev.relocate(xaddr) == nil {
+		return dev.callMethodDo(ctx, dev.FixEndpointAddress(endpoint), method, headers...)
+	}
+	return resp, err
+}
+
+// undelivered tells if a request failed to connect, e.g. refused, without a
+// route or after the timeout of the dial, so that it was never delivered and
+// may be sent again elsewhere. A request that may have been executed is not.
+func undelivered(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// services maps the namespace prefix of the requests to the endpoint of their service.
+var services = map[string]string{
+	"tds":  "device",
+	"trt":  "media",
+	"tptz": "ptz",
+	"timg": "imaging",
+	"tan":  "analytics",
+	"tev":  "event",
+}
+
+// serviceOf returns the endpoint a request is sent to, known from the prefix
+// of its XMLName tag, or else from the name of the directory of its package.
+func serviceOf(method interface{}) string {
+	t := reflect.TypeOf(method)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		if f, ok := t.FieldByName("XMLName"); ok {
+			tag := strings.Split(f.Tag.Get("xml"), ",")[0]
+			if i := strings.IndexByte(tag, ':'); i > 0 {
+				if service, ok := services[tag[:i]]; ok {
+					return service
+				}
+			}
+		}
+	}
+	pkgPath := strings.Split(t.PkgPath(), "/")
+	return strings.ToLower(pkgPath[len(pkgPath)-1])
 }
 
 // CallMethod functions call an method, defined <method> struct with authentication data
-func (dev Device) callMethodDo(endpoint string, method interface{}) (*http.Response, error) {
+func (dev Device) callMethodDo(ctx context.Context, endpoint string, method interface{}, headers ...string) (*http.Response, error) {
 	output, err := xml.MarshalIndent(method, "  ", "    ")
 	if err != nil {
 		return nil, err
@@ -313,11 +539,16 @@ func (dev Device) callMethodDo(endpoint string, method interface{}) (*http.Respo
 
 	soap.AddRootNamespaces(Xlmns)
 	soap.AddAction()
+	for _, header := range headers {
+		if err := soap.AddStringHeaderContent(header); err != nil {
+			return nil, err
+		}
+	}
 
 	//Auth Handling
 	if dev.params.Username != "" && dev.params.Password != "" {
 		soap.AddWSSecurity(dev.params.Username, dev.params.Password)
 	}
 
-	return networking.SendSoap(dev.params.HttpClient, endpoint, soap.String())
+	return networking.SendSoapContext(ctx, dev.params.HttpClient, endpoint, soap.String())
 }
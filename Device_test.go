@@ -1,7 +1,21 @@
 package onvif
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/ritj/onvif/device"
+	"github.com/ritj/onvif/imaging"
+	"github.com/ritj/onvif/networking"
+	"github.com/ritj/onvif/ptz"
+	wsdiscovery "github.com/ritj/onvif/ws-discovery"
 
 	"github.com/ritj/onvif/xsd"
 	"github.com/ritj/onvif/xsd/onvif"
@@ -120,3 +134,106 @@ func TestDevice_FixEndpointAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceOf(t *testing.T) {
+	for _, tc := range []struct {
+		method interface{}
+		want   string
+	}{
+		{device.GetCapabilities{}, "device"},
+		{&imaging.GetOptions{}, "imaging"},
+		{ptz.Stop{}, "ptz"},
+		{struct{ XMLName string }{}, ""},
+	} {
+		if got := serviceOf(tc.method); got != tc.want {
+			t.Errorf("serviceOf(%T) = %q, want %q", tc.method, got, tc.want)
+		}
+	}
+}
+
+func TestDevice_Relocate(t *testing.T) {
+	var calls []string
+	newServer := func(name string) *httptest.Server {
+		var srv *httptest.Server
+		srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			calls = append(calls, name)
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:tt="http://www.onvif.org/ver10/schema" xmlns:tds="http://www.onvif.org/ver10/device/wsdl">
+<SOAP-ENV:Body>%s</SOAP-ENV:Body></SOAP-ENV:Envelope>`, map[bool]string{
+				true:  `<tds:GetCapabilitiesResponse><tds:Capabilities><tt:Device><tt:XAddr>` + srv.URL + `/onvif/device_service</tt:XAddr></tt:Device></tds:Capabilities></tds:GetCapabilitiesResponse>`,
+				false: `<tds:GetSystemDateAndTimeResponse/>`,
+			}[strings.Contains(string(body), "GetCapabilities")])
+		}))
+		return srv
+	}
+	before, after := newServer("before"), newServer("after")
+	defer after.Close()
+
+	// The device, at its new address, answers the Resolve of its endpoint
+	// reference, and no Probe of the other tests.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	xaddr, _ := url.Parse(after.URL + "/onvif/device_service")
+	responder := wsdiscovery.NewResponder("", []url.URL{*xaddr})
+	responder.Types = []string{"test:Relocated"}
+	go responder.Run(ctx)
+	time.Sleep(100 * time.Millisecond)
+	if _, err := wsdiscovery.Resolve(ctx, responder.Address, wsdiscovery.DiscoverOptions{}); err != nil {
+		t.Skip("multicast not looped back: ", err)
+	}
+
+	dev, err := NewDevice(DeviceParams{Xaddr: strings.TrimPrefix(before.URL, "http://"), EndpointReference: responder.Address})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before.Close()
+	if _, err := dev.CallMethod(device.GetSystemDateAndTime{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := dev.GetDeviceParams().Xaddr; got != strings.TrimPrefix(after.URL, "http://") {
+		t.Errorf("unexpected Xaddr %s", got)
+	}
+	if got := dev.FixEndpointAddress(before.URL + "/onvif/events"); got != after.URL+"/onvif/events" {
+		t.Errorf("unexpected endpoint %s", got)
+	}
+	if len(calls) != 3 || calls[0] != "before" || calls[2] != "after" {
+		t.Errorf("unexpected calls %v", calls)
+	}
+}
+
+func TestUndelivered(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	_, err := networking.SendSoap(new(http.Client), closed.URL, "<Envelope/>")
+	if !undelivered(err) {
+		t.Errorf("refused connection taken as delivered: %v", err)
+	}
+
+	// The request was received, the connection is closed without a reply.
+	hangup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}))
+	defer hangup.Close()
+	_, err = networking.SendSoap(new(http.Client), hangup.URL, "<Envelope/>")
+	if err == nil || undelivered(err) {
+		t.Errorf("unanswered request taken as undelivered: %v", err)
+	}
+}
+
+func TestNewDevice_NoResolve(t *testing.T) {
+	// A device that answers but refuses the request is not resolved.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	start := time.Now()
+	_, err := NewDevice(DeviceParams{Xaddr: strings.TrimPrefix(srv.URL, "http://"), EndpointReference: "urn:uuid:00000000-0000-4000-8000-000000000001"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed >= resolveTimeout {
+		t.Errorf("resolved after a refused request, in %s", elapsed)
+	}
+}
@@ -5,21 +5,73 @@ import (
 	"github.com/ritj/onvif/xsd/onvif"
 )
 
+type Capabilities struct {
+	RuleSupport                        bool `xml:"RuleSupport,attr"`
+	AnalyticsModuleSupport             bool `xml:"AnalyticsModuleSupport,attr"`
+	CellBasedSceneDescriptionSupported bool `xml:"CellBasedSceneDescriptionSupported,attr"`
+	RuleOptionsSupported               bool `xml:"RuleOptionsSupported,attr"`
+	AnalyticsModuleOptionsSupported    bool `xml:"AnalyticsModuleOptionsSupported,attr"`
+	SupportedMetadata                  bool `xml:"SupportedMetadata,attr"`
+}
+
+// ConfigOptions lists the values accepted by a parameter of a rule. The
+// options are kept as the raw XML of the content, their schema is open.
+type ConfigOptions struct {
+	RuleType        xsd.QName `xml:"RuleType,attr"`
+	Name            string    `xml:"Name,attr"`
+	Type            xsd.QName `xml:"Type,attr"`
+	AnalyticsModule xsd.QName `xml:"AnalyticsModule,attr"`
+	MinOccurs       *xsd.Int  `xml:"minOccurs,attr"`
+	MaxOccurs       *xsd.Int  `xml:"maxOccurs,attr"`
+	Content         string    `xml:",innerxml"`
+}
+
+// AnalyticsModuleConfigOptions lists the values accepted by the parameters of
+// an analytics module, as the raw XML of the content.
+type AnalyticsModuleConfigOptions struct {
+	Type    xsd.QName `xml:"Type,attr"`
+	Content string    `xml:",innerxml"`
+}
+
+// MetadataInfo is a sample of the metadata produced by an analytics module.
+type MetadataInfo struct {
+	Type        xsd.QName   `xml:"Type,attr"`
+	SampleFrame onvif.Frame `xml:"SampleFrame"`
+}
+
+type GetServiceCapabilities struct {
+	XMLName string `xml:"tan:GetServiceCapabilities"`
+}
+
+type GetServiceCapabilitiesResponse struct {
+	Capabilities Capabilities
+}
+
 type GetSupportedRules struct {
 	XMLName            string               `xml:"tan:GetSupportedRules"`
 	ConfigurationToken onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
 }
 
+type GetSupportedRulesResponse struct {
+	SupportedRules onvif.SupportedRules
+}
+
 type CreateRules struct {
 	XMLName            string               `xml:"tan:CreateRules"`
 	ConfigurationToken onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
-	Rule               onvif.Config         `xml:"tan:Rule"`
+	Rule               []onvif.Config       `xml:"tan:Rule"`
+}
+
+type CreateRulesResponse struct {
 }
 
 type DeleteRules struct {
 	XMLName            string               `xml:"tan:DeleteRules"`
 	ConfigurationToken onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
-	RuleName           xsd.String           `xml:"tan:RuleName"`
+	RuleName           []xsd.String         `xml:"tan:RuleName"`
+}
+
+type DeleteRulesResponse struct {
 }
 
 type GetRules struct {
@@ -27,20 +79,27 @@ type GetRules struct {
 	ConfigurationToken onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
 }
 
+type GetRulesResponse struct {
+	Rule []onvif.Config
+}
+
 type GetRuleOptions struct {
 	XMLName            string               `xml:"tan:GetRuleOptions"`
-	RuleType           xsd.QName            `xml:"tan:RuleType"`
+	RuleType           xsd.QName            `xml:"tan:RuleType,omitempty"`
 	ConfigurationToken onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
 }
 
+type GetRuleOptionsResponse struct {
+	RuleOptions []ConfigOptions
+}
+
 type ModifyRules struct {
 	XMLName            string               `xml:"tan:ModifyRules"`
 	ConfigurationToken onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
-	Rule               onvif.Config         `xml:"tan:Rule"`
+	Rule               []onvif.Config       `xml:"tan:Rule"`
 }
 
-type GetServiceCapabilities struct {
-	XMLName string `xml:"tan:GetServiceCapabilities"`
+type ModifyRulesResponse struct {
 }
 
 type GetSupportedAnalyticsModules struct {
@@ -48,22 +107,36 @@ type GetSupportedAnalyticsModules struct {
 	ConfigurationToken onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
 }
 
+type GetSupportedAnalyticsModulesResponse struct {
+	SupportedAnalyticsModules onvif.SupportedAnalyticsModules
+}
+
 type GetAnalyticsModuleOptions struct {
 	XMLName            string               `xml:"tan:GetAnalyticsModuleOptions"`
 	Type               xsd.QName            `xml:"tan:Type"`
 	ConfigurationToken onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
 }
 
+type GetAnalyticsModuleOptionsResponse struct {
+	Options []AnalyticsModuleConfigOptions
+}
+
 type CreateAnalyticsModules struct {
-	XMLName            string               `xml:"tev:CreateAnalyticsModules"`
+	XMLName            string               `xml:"tan:CreateAnalyticsModules"`
 	ConfigurationToken onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
-	AnalyticsModule    onvif.Config         `xml:"tan:AnalyticsModule"`
+	AnalyticsModule    []onvif.Config       `xml:"tan:AnalyticsModule"`
+}
+
+type CreateAnalyticsModulesResponse struct {
 }
 
 type DeleteAnalyticsModules struct {
 	XMLName             string               `xml:"tan:DeleteAnalyticsModules"`
 	ConfigurationToken  onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
-	AnalyticsModuleName xsd.String           `xml:"tan:AnalyticsModuleName"`
+	AnalyticsModuleName []xsd.String         `xml:"tan:AnalyticsModuleName"`
+}
+
+type DeleteAnalyticsModulesResponse struct {
 }
 
 type GetAnalyticsModules struct {
@@ -71,8 +144,24 @@ type GetAnalyticsModules struct {
 	ConfigurationToken onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
 }
 
+type GetAnalyticsModulesResponse struct {
+	AnalyticsModule []onvif.Config
+}
+
 type ModifyAnalyticsModules struct {
 	XMLName            string               `xml:"tan:ModifyAnalyticsModules"`
 	ConfigurationToken onvif.ReferenceToken `xml:"tan:ConfigurationToken"`
-	AnalyticsModule    onvif.Config         `xml:"tan:AnalyticsModule"`
+	AnalyticsModule    []onvif.Config       `xml:"tan:AnalyticsModule"`
+}
+
+type ModifyAnalyticsModulesResponse struct {
+}
+
+type GetSupportedMetadata struct {
+	XMLName string    `xml:"tan:GetSupportedMetadata"`
+	Type    xsd.QName `xml:"tan:Type,omitempty"`
+}
+
+type GetSupportedMetadataResponse struct {
+	AnalyticsModule []MetadataInfo
 }
@@ -0,0 +1,122 @@
+package analytics
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+const supportedRules = `<tan:GetSupportedRulesResponse xmlns:tan="http://www.onvif.org/ver20/analytics/wsdl" xmlns:tt="http://www.onvif.org/ver10/schema">
+	<tan:SupportedRules Limit="4">
+		<tt:RuleDescription Name="tt:LineDetector" maxInstances="2">
+			<tt:Parameters>
+				<tt:SimpleItemDescription Name="Direction" Type="tt:Direction"/>
+				<tt:ElementItemDescription Name="Segments" Type="tt:Polyline"/>
+			</tt:Parameters>
+			<tt:Messages IsProperty="false">
+				<tt:Source>
+					<tt:SimpleItemDescription Name="VideoSourceConfigurationToken" Type="tt:ReferenceToken"/>
+					<tt:SimpleItemDescription Name="Rule" Type="xs:string"/>
+				</tt:Source>
+				<tt:Data>
+					<tt:SimpleItemDescription Name="ObjectId" Type="tt:ObjectRefType"/>
+				</tt:Data>
+				<tt:ParentTopic>tns1:RuleEngine/LineDetector/Crossed</tt:ParentTopic>
+			</tt:Messages>
+		</tt:RuleDescription>
+	</tan:SupportedRules>
+</tan:GetSupportedRulesResponse>`
+
+func TestSupportedRules(t *testing.T) {
+	var reply GetSupportedRulesResponse
+	if err := xml.Unmarshal([]byte(supportedRules), &reply); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reply.SupportedRules.Description("axis:LineDetector"); ok {
+		t.Error("unexpected description of a vendor type")
+	}
+	d, ok := reply.SupportedRules.Description("tt:LineDetector")
+	if !ok {
+		t.Fatalf("missing LineDetector in %+v", reply.SupportedRules)
+	}
+	if *d.MaxInstances != 2 || len(d.Parameters.SimpleItemDescription) != 1 || d.Parameters.ElementItemDescription[0].Type != "tt:Polyline" {
+		t.Errorf("unexpected parameters %+v", d.Parameters)
+	}
+	if len(d.Messages) != 1 || d.Messages[0].ParentTopic != "tns1:RuleEngine/LineDetector/Crossed" || len(d.Messages[0].Source.SimpleItemDescription) != 2 {
+		t.Errorf("unexpected messages %+v", d.Messages)
+	}
+}
+
+const rules = `<tan:GetRulesResponse xmlns:tan="http://www.onvif.org/ver20/analytics/wsdl" xmlns:tt="http://www.onvif.org/ver10/schema">
+	<tan:Rule Name="Line1" Type="tt:LineDetector">
+		<tt:Parameters>
+			<tt:SimpleItem Name="Direction" Value="Any"/>
+			<tt:ElementItem Name="Segments"><tt:Polyline><tt:Point x="0" y="0"/><tt:Point x="1" y="1"/></tt:Polyline></tt:ElementItem>
+		</tt:Parameters>
+	</tan:Rule>
+</tan:GetRulesResponse>`
+
+func TestRulesRoundTrip(t *testing.T) {
+	var reply GetRulesResponse
+	if err := xml.Unmarshal([]byte(rules), &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Rule) != 1 {
+		t.Fatalf("unexpected rules %+v", reply.Rule)
+	}
+	rule := reply.Rule[0]
+	if v, _ := rule.Parameters.Simple("Direction"); v != "Any" {
+		t.Errorf("unexpected Direction %q", v)
+	}
+	rule.Parameters.SetSimple("Direction", "ToLeft")
+
+	out, err := xml.Marshal(ModifyRules{ConfigurationToken: "va", Rule: reply.Rule[:1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<tan:Rule Name="Line1" Type="tt:LineDetector"><tt:Parameters>`,
+		`<tt:SimpleItem Name="Direction" Value="ToLeft"></tt:SimpleItem>`,
+		`<tt:ElementItem Name="Segments"><tt:Polyline><tt:Point x="0" y="0"/><tt:Point x="1" y="1"/></tt:Polyline></tt:ElementItem>`,
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("missing %s in %s", want, out)
+		}
+	}
+}
+
+const vendorRules = `<tan:GetRulesResponse xmlns:tan="http://www.onvif.org/ver20/analytics/wsdl" xmlns:tt="http://www.onvif.org/ver10/schema">
+	<tan:Rule Name="Line2" Type="axis:LineDetector" xmlns:axis="http://www.axis.com/vapix/ws/analytics">
+		<tt:Parameters>
+			<tt:ElementItem Name="Segments"><axis:Polyline><tt:Point x="0" y="0"/></axis:Polyline></tt:ElementItem>
+		</tt:Parameters>
+	</tan:Rule>
+</tan:GetRulesResponse>`
+
+func TestVendorRulesRoundTrip(t *testing.T) {
+	var reply GetRulesResponse
+	if err := xml.Unmarshal([]byte(vendorRules), &reply); err != nil {
+		t.Fatal(err)
+	}
+	out, err := xml.Marshal(ModifyRules{ConfigurationToken: "va", Rule: reply.Rule})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The request declares tan and tt, the axis prefix is declared on the rule.
+	doc := `<r xmlns:tan="http://www.onvif.org/ver20/analytics/wsdl" xmlns:tt="http://www.onvif.org/ver10/schema">` + string(out) + `</r>`
+	var back struct {
+		Rule []struct {
+			Type     string `xml:"Type,attr"`
+			Polyline struct {
+				XMLName xml.Name
+			} `xml:"Parameters>ElementItem>Polyline"`
+		} `xml:"ModifyRules>Rule"`
+	}
+	if err := xml.Unmarshal([]byte(doc), &back); err != nil {
+		t.Fatalf("invalid request %s: %v", out, err)
+	}
+	if len(back.Rule) != 1 || back.Rule[0].Type != "axis:LineDetector" ||
+		back.Rule[0].Polyline.XMLName.Space != "http://www.axis.com/vapix/ws/analytics" {
+		t.Errorf("unexpected request %s", out)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"path"
 	"reflect"
 	"regexp"
 	"strings"
@@ -67,44 +66,25 @@ func RunApi() {
 		devices, err := wsdiscovery.SendProbe(interfaceName, nil, []string{"dn:NetworkVideoTransmitter"}, map[string]string{"dn": "http://www.onvif.org/ver10/network/wsdl"})
 		if err != nil {
 			context.String(http.StatusInternalServerError, "error")
-		} else {
-			response := "["
-
-			for _, j := range devices {
-				doc := etree.NewDocument()
-				if err := doc.ReadFromString(j); err != nil {
-					context.XML(http.StatusBadRequest, err.Error())
-				} else {
-
-					endpoints := doc.Root().FindElements("./Body/ProbeMatches/ProbeMatch/XAddrs")
-					scopes := doc.Root().FindElements("./Body/ProbeMatches/ProbeMatch/Scopes")
-
-					flag := false
+			return
+		}
 
-					for _, xaddr := range endpoints {
-						xaddr := strings.Split(strings.Split(xaddr.Text(), " ")[0], "/")[2]
-						if strings.Contains(response, xaddr) {
-							flag = true
-							break
-						}
-						response += "{"
-						response += `"url":"` + xaddr + `",`
-					}
-					if flag {
-						break
-					}
-					for _, scope := range scopes {
-						re := regexp.MustCompile(`onvif:\/\/www\.onvif\.org\/name\/[A-Za-z0-9-]+`)
-						match := re.FindStringSubmatch(scope.Text())
-						response += `"name":"` + path.Base(match[0]) + `"`
-					}
-					response += "},"
-				}
+		type host struct {
+			URL  string `json:"url"`
+			Name string `json:"name,omitempty"`
+		}
+		response := make([]host, 0, len(devices))
+		for _, device := range devices {
+			if len(device.XAddrs) == 0 {
+				continue
+			}
+			h := host{URL: device.XAddrs[0].Host}
+			if names := device.ScopeValues(wsdiscovery.ScopeName); len(names) > 0 {
+				h.Name = names[0]
 			}
-			response = strings.TrimRight(response, ",")
-			response += "]"
-			context.String(http.StatusOK, response)
+			response = append(response, h)
 		}
+		context.JSON(http.StatusOK, response)
 	})
 
 	if err := router.Run(); err != nil {
@@ -123,6 +103,8 @@ func callNecessaryMethod(serviceName, methodName, acceptedData, username, passwo
 		methodStruct, err = getPTZStructByName(methodName)
 	case "media":
 		methodStruct, err = getMediaStructByName(methodName)
+	case "imaging":
+		methodStruct, err = getImagingStructByName(methodName)
 	default:
 		return "", errors.New("there is no such service")
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/ritj/onvif/device"
+	"github.com/ritj/onvif/imaging"
 	"github.com/ritj/onvif/media"
 	"github.com/ritj/onvif/ptz"
 )
@@ -423,3 +424,32 @@ func getMediaStructByName(name string) (interface{}, error) {
 	}
 
 }
+
+func getImagingStructByName(name string) (interface{}, error) {
+	switch name {
+	case "GetServiceCapabilities":
+		return &imaging.GetServiceCapabilities{}, nil
+	case "GetImagingSettings":
+		return &imaging.GetImagingSettings{}, nil
+	case "SetImagingSettings":
+		return &imaging.SetImagingSettings{}, nil
+	case "GetOptions":
+		return &imaging.GetOptions{}, nil
+	case "Move":
+		return &imaging.Move{}, nil
+	case "GetMoveOptions":
+		return &imaging.GetMoveOptions{}, nil
+	case "Stop":
+		return &imaging.Stop{}, nil
+	case "GetStatus":
+		return &imaging.GetStatus{}, nil
+	case "GetPresets":
+		return &imaging.GetPresets{}, nil
+	case "GetCurrentPreset":
+		return &imaging.GetCurrentPreset{}, nil
+	case "SetCurrentPreset":
+		return &imaging.SetCurrentPreset{}, nil
+	default:
+		return nil, errors.New("there is no such method in the Imaging service")
+	}
+}
@@ -214,35 +214,32 @@ type GetScopes struct {
 }
 
 type GetScopesResponse struct {
-	Scopes onvif.Scope
+	Scopes []onvif.Scope
 }
 
-//TODO: one or more scopes
 type SetScopes struct {
-	XMLName string     `xml:"tds:SetScopes"`
-	Scopes  xsd.AnyURI `xml:"tds:Scopes"`
+	XMLName string       `xml:"tds:SetScopes"`
+	Scopes  []xsd.AnyURI `xml:"tds:Scopes"`
 }
 
 type SetScopesResponse struct {
 }
 
-//TODO: list of scopes
 type AddScopes struct {
-	XMLName   string     `xml:"tds:AddScopes"`
-	ScopeItem xsd.AnyURI `xml:"tds:ScopeItem"`
+	XMLName   string       `xml:"tds:AddScopes"`
+	ScopeItem []xsd.AnyURI `xml:"tds:ScopeItem"`
 }
 
 type AddScopesResponse struct {
 }
 
-//TODO: One or more repetitions
 type RemoveScopes struct {
-	XMLName   string     `xml:"tds:RemoveScopes"`
-	ScopeItem xsd.AnyURI `xml:"onvif:ScopeItem"`
+	XMLName   string       `xml:"tds:RemoveScopes"`
+	ScopeItem []xsd.AnyURI `xml:"tds:ScopeItem"`
 }
 
 type RemoveScopesResponse struct {
-	ScopeItem xsd.AnyURI
+	ScopeItem []xsd.AnyURI
 }
 
 type GetDiscoveryMode struct {
@@ -0,0 +1,371 @@
+package event
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/ritj/onvif/xsd"
+	"github.com/ritj/onvif/xsd/onvif"
+)
+
+// TopicMessage is the part of a notification common to every topic, embedded
+// in the types the notifications are decoded into.
+type TopicMessage struct {
+	// Topic is the topic of the notification, with the prefixes of the
+	// segments in the namespace of their parent dropped, see TopicNode.Path.
+	Topic             string
+	UtcTime           time.Time
+	PropertyOperation PropertyOperation
+}
+
+// MotionAlarm is a tns1:VideoSource/MotionAlarm notification.
+type MotionAlarm struct {
+	TopicMessage
+	Source onvif.ReferenceToken
+	State  bool
+}
+
+// RuleSource is the source of the notifications of the analytics rules.
+type RuleSource struct {
+	VideoSourceConfigurationToken    onvif.ReferenceToken
+	VideoAnalyticsConfigurationToken onvif.ReferenceToken
+	Rule                             string
+}
+
+// CellMotion is a tns1:RuleEngine/CellMotionDetector/Motion notification.
+type CellMotion struct {
+	TopicMessage
+	RuleSource
+	IsMotion bool
+}
+
+// LineCrossed is a tns1:RuleEngine/LineDetector/Crossed notification.
+type LineCrossed struct {
+	TopicMessage
+	RuleSource
+	ObjectID int
+}
+
+// ObjectsInside is a tns1:RuleEngine/FieldDetector/ObjectsInside notification.
+type ObjectsInside struct {
+	TopicMessage
+	RuleSource
+	ObjectID int
+	IsInside bool
+}
+
+// GlobalSceneChange is a tns1:VideoSource/GlobalSceneChange/* notification,
+// e.g. .../ImagingService when the camera was moved or covered.
+type GlobalSceneChange struct {
+	TopicMessage
+	Source onvif.ReferenceToken
+	State  bool
+}
+
+// DigitalInput is a tns1:Device/Trigger/DigitalInput notification.
+type DigitalInput struct {
+	TopicMessage
+	InputToken   onvif.ReferenceToken
+	LogicalState bool
+}
+
+// Relay is a tns1:Device/Trigger/Relay notification.
+type Relay struct {
+	TopicMessage
+	RelayToken   onvif.ReferenceToken
+	LogicalState onvif.RelayLogicalState
+}
+
+// ProcessorUsage is a tns1:Monitoring/ProcessorUsage notification.
+type ProcessorUsage struct {
+	TopicMessage
+	Token onvif.ReferenceToken
+	// Value is the usage, in percents.
+	Value float64
+}
+
+// MonitoringTime is a tns1:Monitoring notification of a date, e.g.
+// OperatingTime/LastReboot, OperatingTime/LastReset or Backup/Last.
+type MonitoringTime struct {
+	TopicMessage
+	Status time.Time
+}
+
+// Monitoring is any other tns1:Monitoring/* notification.
+type Monitoring struct {
+	TopicMessage
+	Source onvif.ItemList
+	Data   onvif.ItemList
+}
+
+// TopicDecoder decodes the message of a notification, header holding its
+// topic and time.
+type TopicDecoder func(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error)
+
+// TopicDecoders maps topics to their decoders. A pattern is a topic, or the
+// parent of the topics it decodes followed by /*. The most specific pattern
+// decodes a topic.
+type TopicDecoders struct {
+	mu       sync.RWMutex
+	decoders map[string]TopicDecoder
+}
+
+// DefaultTopicDecoders decodes the standard ONVIF topics, and the topics
+// registered with RegisterTopic.
+var DefaultTopicDecoders = NewTopicDecoders()
+
+// NewTopicDecoders returns the decoders of the standard ONVIF topics.
+func NewTopicDecoders() *TopicDecoders {
+	r := &TopicDecoders{decoders: map[string]TopicDecoder{}}
+	r.Register("tns1:VideoSource/MotionAlarm", decodeMotionAlarm)
+	r.Register("tns1:RuleEngine/CellMotionDetector/Motion", decodeCellMotion)
+	r.Register("tns1:RuleEngine/LineDetector/Crossed", decodeLineCrossed)
+	r.Register("tns1:RuleEngine/FieldDetector/ObjectsInside", decodeObjectsInside)
+	r.Register("tns1:VideoSource/GlobalSceneChange/*", decodeGlobalSceneChange)
+	r.Register("tns1:Device/Trigger/DigitalInput", decodeDigitalInput)
+	r.Register("tns1:Device/Trigger/Relay", decodeRelay)
+	r.Register("tns1:Monitoring/*", decodeMonitoring)
+	r.Register("tns1:Monitoring/ProcessorUsage", decodeProcessorUsage)
+	r.Register("tns1:Monitoring/OperatingTime/*", decodeMonitoringTime)
+	r.Register("tns1:Monitoring/Backup/Last", decodeMonitoringTime)
+	return r
+}
+
+// Register sets the decoder of the topics of pattern, e.g.
+// tnsaxis:CameraApplicationPlatform/VMD/Camera1Profile1 or tnsaxis:Storage/*.
+func (r *TopicDecoders) Register(pattern string, decoder TopicDecoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decoders[normalizeTopic(pattern)] = decoder
+}
+
+// Decode returns the notification as the type of its topic, e.g. a
+// *CellMotion. It returns nil without an error when no decoder matches.
+func (r *TopicDecoders) Decode(msg NotificationMessage) (interface{}, error) {
+	topic := messageTopic(msg)
+	decoder := r.lookup(topic)
+	if decoder == nil {
+		return nil, nil
+	}
+	holder := msg.Message.Message
+	header := TopicMessage{Topic: topic, UtcTime: holder.UtcTime, PropertyOperation: holder.PropertyOperation}
+	v, err := decoder(header, holder)
+	if err != nil {
+		return nil, errors.Annotate(err, topic)
+	}
+	return v, nil
+}
+
+func (r *TopicDecoders) lookup(topic string) TopicDecoder {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if decoder, ok := r.decoders[topic]; ok {
+		return decoder
+	}
+	var patterns []string
+	for pattern := range r.decoders {
+		if parent := strings.TrimSuffix(pattern, "/*"); parent != pattern && strings.HasPrefix(topic, parent+"/") {
+			patterns = append(patterns, pattern)
+		}
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	sort.Slice(patterns, func(i, j int) bool { return len(patterns[i]) > len(patterns[j]) })
+	return r.decoders[patterns[0]]
+}
+
+// RegisterTopic registers a decoder in DefaultTopicDecoders.
+func RegisterTopic(pattern string, decoder TopicDecoder) {
+	DefaultTopicDecoders.Register(pattern, decoder)
+}
+
+// Decode decodes a notification with DefaultTopicDecoders.
+func Decode(msg NotificationMessage) (interface{}, error) {
+	return DefaultTopicDecoders.Decode(msg)
+}
+
+// messageTopic returns the topic of a notification, normalized, its
+// prefixes bound to a well-known namespace being replaced by the prefix of
+// topicPrefixes, e.g. tns1 for the ONVIF topics whatever the prefix the device
+// declared.
+func messageTopic(msg NotificationMessage) string {
+	segments := strings.Split(strings.TrimSpace(string(msg.Topic.TopicKinds)), "/")
+	for i, segment := range segments {
+		colon := strings.IndexByte(segment, ':')
+		if colon < 0 {
+			continue
+		}
+		ns, ok := msg.Topic.namespace(segment[:colon])
+		if !ok {
+			continue
+		}
+		for prefix, uri := range topicPrefixes {
+			if uri == ns {
+				segments[i] = prefix + segment[colon:]
+				break
+			}
+		}
+	}
+	return normalizeTopic(strings.Join(segments, "/"))
+}
+
+// normalizeTopic drops the spaces and the prefixes of the segments in the
+// namespace of their parent, as some devices write every prefix.
+func normalizeTopic(topic string) string {
+	segments := strings.Split(strings.TrimSpace(topic), "/")
+	parent := ""
+	for i, segment := range segments {
+		prefix := ""
+		if colon := strings.IndexByte(segment, ':'); colon >= 0 {
+			prefix = segment[:colon]
+		}
+		if i > 0 && prefix != "" && prefix == parent {
+			segments[i] = segment[len(prefix)+1:]
+		}
+		if prefix != "" {
+			parent = prefix
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func decodeMotionAlarm(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error) {
+	v := &MotionAlarm{TopicMessage: header}
+	var err error
+	v.Source, err = tokenItem(msg.Source, "Source", "VideoSourceToken")
+	if err == nil {
+		v.State, err = boolItem(msg.Data, "State")
+	}
+	return v, err
+}
+
+func decodeCellMotion(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error) {
+	v := &CellMotion{TopicMessage: header, RuleSource: ruleSource(msg.Source)}
+	var err error
+	v.IsMotion, err = boolItem(msg.Data, "IsMotion")
+	return v, err
+}
+
+func decodeLineCrossed(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error) {
+	v := &LineCrossed{TopicMessage: header, RuleSource: ruleSource(msg.Source)}
+	var err error
+	v.ObjectID, err = intItem(msg.Data, "ObjectId")
+	return v, err
+}
+
+func decodeObjectsInside(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error) {
+	v := &ObjectsInside{TopicMessage: header, RuleSource: ruleSource(msg.Source)}
+	var err error
+	v.ObjectID, err = intItem(msg.Key, "ObjectId")
+	if err == nil {
+		v.IsInside, err = boolItem(msg.Data, "IsInside")
+	}
+	return v, err
+}
+
+func decodeGlobalSceneChange(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error) {
+	v := &GlobalSceneChange{TopicMessage: header}
+	var err error
+	v.Source, err = tokenItem(msg.Source, "Source", "VideoSourceToken")
+	if err == nil {
+		v.State, err = boolItem(msg.Data, "State")
+	}
+	return v, err
+}
+
+func decodeDigitalInput(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error) {
+	v := &DigitalInput{TopicMessage: header}
+	var err error
+	v.InputToken, err = tokenItem(msg.Source, "InputToken")
+	if err == nil {
+		v.LogicalState, err = boolItem(msg.Data, "LogicalState")
+	}
+	return v, err
+}
+
+func decodeRelay(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error) {
+	v := &Relay{TopicMessage: header}
+	var err error
+	v.RelayToken, err = tokenItem(msg.Source, "RelayToken")
+	if err != nil {
+		return v, err
+	}
+	state, ok := msg.Data.Simple("LogicalState")
+	if !ok {
+		return v, errors.New("missing item LogicalState")
+	}
+	v.LogicalState = onvif.RelayLogicalState(state)
+	return v, nil
+}
+
+func decodeProcessorUsage(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error) {
+	v := &ProcessorUsage{TopicMessage: header}
+	token, _ := msg.Source.Simple("Token")
+	v.Token = onvif.ReferenceToken(token)
+	value, ok := msg.Data.Simple("Value")
+	if !ok {
+		return v, errors.New("missing item Value")
+	}
+	var err error
+	v.Value, err = strconv.ParseFloat(strings.TrimSpace(string(value)), 64)
+	return v, errors.Annotate(err, "item Value")
+}
+
+func decodeMonitoringTime(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error) {
+	v := &MonitoringTime{TopicMessage: header}
+	status, ok := msg.Data.Simple("Status")
+	if !ok {
+		return v, errors.New("missing item Status")
+	}
+	var err error
+	v.Status, err = xsd.DateTime(status).Time()
+	return v, errors.Annotate(err, "item Status")
+}
+
+func decodeMonitoring(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error) {
+	return &Monitoring{TopicMessage: header, Source: msg.Source, Data: msg.Data}, nil
+}
+
+func ruleSource(source onvif.ItemList) RuleSource {
+	vsc, _ := source.Simple("VideoSourceConfigurationToken")
+	vac, _ := source.Simple("VideoAnalyticsConfigurationToken")
+	rule, _ := source.Simple("Rule")
+	return RuleSource{
+		VideoSourceConfigurationToken:    onvif.ReferenceToken(vsc),
+		VideoAnalyticsConfigurationToken: onvif.ReferenceToken(vac),
+		Rule:                             string(rule),
+	}
+}
+
+// tokenItem returns the first of the items names found.
+func tokenItem(items onvif.ItemList, names ...string) (onvif.ReferenceToken, error) {
+	for _, name := range names {
+		if v, ok := items.Simple(name); ok {
+			return onvif.ReferenceToken(v), nil
+		}
+	}
+	return "", errors.Errorf("missing item %s", names[0])
+}
+
+func boolItem(items onvif.ItemList, name string) (bool, error) {
+	v, ok := items.Simple(name)
+	if !ok {
+		return false, errors.Errorf("missing item %s", name)
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(string(v)))
+	return b, errors.Annotatef(err, "item %s", name)
+}
+
+func intItem(items onvif.ItemList, name string) (int, error) {
+	v, ok := items.Simple(name)
+	if !ok {
+		return 0, errors.Errorf("missing item %s", name)
+	}
+	i, err := strconv.Atoi(strings.TrimSpace(string(v)))
+	return i, errors.Annotatef(err, "item %s", name)
+}
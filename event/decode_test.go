@@ -0,0 +1,99 @@
+package event
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func notification(topic, message string) NotificationMessage {
+	var msg NotificationMessage
+	err := xml.Unmarshal([]byte(`<wsnt:NotificationMessage xmlns:wsnt="http://docs.oasis-open.org/wsn/b-2" xmlns:tt="http://www.onvif.org/ver10/schema">
+		<wsnt:Topic Dialect="http://www.onvif.org/ver10/tev/topicExpression/ConcreteSet">`+topic+`</wsnt:Topic>
+		<wsnt:Message><tt:Message UtcTime="2024-01-01T00:00:01Z" PropertyOperation="Changed">`+message+`</tt:Message></wsnt:Message>
+	</wsnt:NotificationMessage>`), &msg)
+	if err != nil {
+		panic(err)
+	}
+	return msg
+}
+
+func TestDecode(t *testing.T) {
+	v, err := Decode(notification("tns1:RuleEngine/tns1:CellMotionDetector/tns1:Motion", `
+		<tt:Source>
+			<tt:SimpleItem Name="VideoSourceConfigurationToken" Value="vsc"/>
+			<tt:SimpleItem Name="VideoAnalyticsConfigurationToken" Value="vac"/>
+			<tt:SimpleItem Name="Rule" Value="MyMotionDetectorRule"/>
+		</tt:Source>
+		<tt:Data><tt:SimpleItem Name="IsMotion" Value="true"/></tt:Data>`))
+	motion, ok := v.(*CellMotion)
+	if err != nil || !ok {
+		t.Fatalf("unexpected %#v, %v", v, err)
+	}
+	if motion.Topic != "tns1:RuleEngine/CellMotionDetector/Motion" || motion.Rule != "MyMotionDetectorRule" || !motion.IsMotion ||
+		motion.PropertyOperation != PropertyChanged || !motion.UtcTime.Equal(time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC)) {
+		t.Errorf("unexpected %+v", motion)
+	}
+
+	v, err = Decode(notification("tns1:VideoSource/GlobalSceneChange/ImagingService", `
+		<tt:Source><tt:SimpleItem Name="Source" Value="vs1"/></tt:Source>
+		<tt:Data><tt:SimpleItem Name="State" Value="false"/></tt:Data>`))
+	if scene, ok := v.(*GlobalSceneChange); err != nil || !ok || scene.Source != "vs1" || scene.State {
+		t.Errorf("unexpected %#v, %v", v, err)
+	}
+
+	v, err = Decode(notification("tns1:Monitoring/OperatingTime/LastReboot", `
+		<tt:Data><tt:SimpleItem Name="Status" Value="2023-12-31T12:00:00Z"/></tt:Data>`))
+	if reboot, ok := v.(*MonitoringTime); err != nil || !ok || reboot.Status.Year() != 2023 {
+		t.Errorf("unexpected %#v, %v", v, err)
+	}
+	v, err = Decode(notification("tns1:Monitoring/EnvironmentalConditions/FanDecreasedSpeed", ``))
+	if _, ok := v.(*Monitoring); err != nil || !ok {
+		t.Errorf("unexpected %#v, %v", v, err)
+	}
+
+	if _, err := Decode(notification("tns1:Device/Trigger/DigitalInput", `
+		<tt:Source><tt:SimpleItem Name="InputToken" Value="in1"/></tt:Source>
+		<tt:Data><tt:SimpleItem Name="LogicalState" Value="maybe"/></tt:Data>`)); err == nil {
+		t.Error("expected an error for an invalid LogicalState")
+	}
+	if v, err := Decode(notification("tnsacme:Door/Open", ``)); v != nil || err != nil {
+		t.Errorf("unexpected %#v, %v", v, err)
+	}
+
+	decoders := NewTopicDecoders()
+	decoders.Register("tnsacme:Door/*", func(header TopicMessage, msg MessageNotificationHolderType) (interface{}, error) {
+		return header.Topic, nil
+	})
+	if v, err := decoders.Decode(notification("tnsacme:Door/Open", ``)); v != "tnsacme:Door/Open" || err != nil {
+		t.Errorf("unexpected %#v, %v", v, err)
+	}
+}
+
+func TestDecodePrefix(t *testing.T) {
+	var msg NotificationMessage
+	err := xml.Unmarshal([]byte(`<wsnt:NotificationMessage xmlns:wsnt="http://docs.oasis-open.org/wsn/b-2" xmlns:tt="http://www.onvif.org/ver10/schema">
+		<wsnt:Topic Dialect="http://www.onvif.org/ver10/tev/topicExpression/ConcreteSet" xmlns:ns2="http://www.onvif.org/ver10/topics">ns2:Device/ns2:Trigger/DigitalInput</wsnt:Topic>
+		<wsnt:Message><tt:Message UtcTime="2024-01-01T00:00:01Z" PropertyOperation="Changed">
+			<tt:Source><tt:SimpleItem Name="InputToken" Value="in1"/></tt:Source>
+			<tt:Data><tt:SimpleItem Name="LogicalState" Value="true"/></tt:Data>
+		</tt:Message></wsnt:Message>
+	</wsnt:NotificationMessage>`), &msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := Decode(msg)
+	if input, ok := v.(*DigitalInput); err != nil || !ok || input.Topic != "tns1:Device/Trigger/DigitalInput" || input.InputToken != "in1" {
+		t.Errorf("unexpected %#v, %v", v, err)
+	}
+
+	// The prefix declared on an ancestor of the Topic, e.g. the envelope.
+	msg = notification("ev:RuleEngine/CellMotionDetector/Motion", `<tt:Data><tt:SimpleItem Name="IsMotion" Value="true"/></tt:Data>`)
+	if v, err := Decode(msg); v != nil || err != nil {
+		t.Errorf("unexpected %#v, %v", v, err)
+	}
+	msg.Topic.InheritNamespaces(map[string]string{"ev": TopicNamespaceONVIF, "tt": "http://www.onvif.org/ver10/schema"})
+	if v, err := Decode(msg); err != nil || v == nil || v.(*CellMotion).Topic != "tns1:RuleEngine/CellMotionDetector/Motion" {
+		t.Errorf("unexpected %#v, %v", v, err)
+	}
+}
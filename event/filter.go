@@ -0,0 +1,378 @@
+package event
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+	"github.com/ritj/onvif/xsd"
+)
+
+// The dialects of the filters supported by ONVIF devices
+const (
+	TopicExpressionConcreteSet = "http://www.onvif.org/ver10/tev/topicExpression/ConcreteSet"
+	MessageContentItemFilter   = "http://www.onvif.org/ver10/tev/messageContentFilter/ItemFilter"
+)
+
+// FilterBuilder composes the filter of a subscription: a ConcreteSet topic
+// expression, the union of the topics given, and an ItemFilter expression on
+// the content of the messages, the conjunction of the conditions given.
+//
+//	filter, err := event.NewFilter("tns1:RuleEngine//.").Rule("MyMotionDetectorRule").Within(props.TopicSet).Build()
+//
+// The first error met is returned by Build.
+type FilterBuilder struct {
+	topics     []string
+	conditions []string
+	namespaces map[string]string
+	set        *TopicSet
+	err        error
+}
+
+// NewFilter returns a FilterBuilder of the topics given, see Topic.
+func NewFilter(topics ...string) *FilterBuilder {
+	return (&FilterBuilder{namespaces: map[string]string{}}).Topic(topics...)
+}
+
+// Namespace declares the prefix of vendor topics. The prefixes tns1, tt and
+// tnsaxis are known.
+func (b *FilterBuilder) Namespace(prefix, uri string) *FilterBuilder {
+	b.namespaces[prefix] = uri
+	return b
+}
+
+// Topic adds topic expressions to the union, e.g. tns1:VideoSource/MotionAlarm,
+// tns1:RuleEngine//. for a topic and all its descendants, tns1:Device/*/Relay
+// for any topic at a level, or several expressions joined with |.
+func (b *FilterBuilder) Topic(expressions ...string) *FilterBuilder {
+	for _, expression := range expressions {
+		for _, expr := range strings.Split(expression, "|") {
+			expr = strings.TrimSpace(expr)
+			if _, err := parseTopicExpression(expr); err != nil {
+				b.fail(err)
+				continue
+			}
+			b.topics = append(b.topics, expr)
+		}
+	}
+	return b
+}
+
+// SourceItem keeps the messages with a Source item name of value.
+func (b *FilterBuilder) SourceItem(name, value string) *FilterBuilder {
+	return b.condition(`//tt:Source/tt:SimpleItem[@Name=%s and @Value=%s]`, name, value)
+}
+
+// SourceToken keeps the messages with a Source item of value token, whatever
+// its name, e.g. a video source or an input token.
+func (b *FilterBuilder) SourceToken(token string) *FilterBuilder {
+	return b.condition(`//tt:Source/tt:SimpleItem[@Value=%s]`, token)
+}
+
+// Rule keeps the messages of the analytics rule name.
+func (b *FilterBuilder) Rule(name string) *FilterBuilder {
+	return b.SourceItem("Rule", name)
+}
+
+// PropertyOperation keeps the messages of any of the operations given.
+func (b *FilterBuilder) PropertyOperation(operations ...PropertyOperation) *FilterBuilder {
+	if len(operations) == 0 {
+		return b
+	}
+	terms := make([]string, len(operations))
+	values := make([]string, len(operations))
+	for i, op := range operations {
+		terms[i] = "@PropertyOperation=%s"
+		values[i] = string(op)
+	}
+	return b.condition(`//tt:Message[`+strings.Join(terms, " or ")+`]`, values...)
+}
+
+// Within makes Build check that every topic expression selects some topics of set.
+func (b *FilterBuilder) Within(set TopicSet) *FilterBuilder {
+	b.set = &set
+	return b
+}
+
+// Build returns the filter, with the prefixes of its expressions declared on
+// them. It returns a nil filter, i.e. all the notifications, when neither a
+// topic nor a condition was given.
+func (b *FilterBuilder) Build() (*FilterType, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.topics) == 0 && len(b.conditions) == 0 {
+		return nil, nil
+	}
+	filter := &FilterType{}
+	if len(b.topics) > 0 {
+		expression := strings.Join(b.topics, "|")
+		if b.set != nil {
+			for _, topic := range b.topics {
+				nodes, err := b.set.Match(topic)
+				if err != nil {
+					return nil, err
+				}
+				if len(nodes) == 0 {
+					return nil, errors.Errorf("topic %s is not published by the device", topic)
+				}
+			}
+		}
+		var prefixes []string
+		for _, topic := range b.topics {
+			steps, _ := parseTopicExpression(topic)
+			for _, step := range steps {
+				if step.prefix != "" {
+					prefixes = append(prefixes, step.prefix)
+				}
+			}
+		}
+		namespaces, err := b.declare(prefixes)
+		if err != nil {
+			return nil, errors.Annotatef(err, "topic %s", expression)
+		}
+		filter.TopicExpression = &TopicExpressionType{
+			Dialect:    TopicExpressionConcreteSet,
+			TopicKinds: xsd.String(expression),
+			Namespaces: namespaces,
+		}
+	}
+	if len(b.conditions) > 0 {
+		namespaces, _ := b.declare([]string{"tt"})
+		filter.MessageContent = &QueryExpressionType{
+			Dialect:     MessageContentItemFilter,
+			MessageKind: xsd.String(strings.Join(b.conditions, " and ")),
+			Namespaces:  namespaces,
+		}
+	}
+	return filter, nil
+}
+
+// condition adds boolean(path), the values being quoted into the %s of path.
+func (b *FilterBuilder) condition(path string, values ...string) *FilterBuilder {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		literal, err := xpathLiteral(v)
+		if err != nil {
+			b.fail(err)
+			return b
+		}
+		args[i] = literal
+	}
+	b.conditions = append(b.conditions, "boolean("+fmt.Sprintf(path, args...)+")")
+	return b
+}
+
+// declare returns the xmlns attributes of the prefixes.
+func (b *FilterBuilder) declare(prefixes []string) ([]xml.Attr, error) {
+	sort.Strings(prefixes)
+	var attrs []xml.Attr
+	for i, prefix := range prefixes {
+		if i > 0 && prefixes[i-1] == prefix {
+			continue
+		}
+		uri, ok := b.namespaces[prefix]
+		if !ok {
+			uri, ok = topicPrefixes[prefix]
+		}
+		if !ok {
+			return nil, errors.Errorf("undeclared prefix %s", prefix)
+		}
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "xmlns:" + prefix}, Value: uri})
+	}
+	return attrs, nil
+}
+
+func (b *FilterBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Match returns the nodes selected by a ConcreteSet topic expression, see
+// FilterBuilder.Topic. The names are compared by their local name.
+func (s TopicSet) Match(expression string) ([]*TopicNode, error) {
+	var out []*TopicNode
+	seen := map[*TopicNode]bool{}
+	for _, expr := range strings.Split(expression, "|") {
+		steps, err := parseTopicExpression(strings.TrimSpace(expr))
+		if err != nil {
+			return nil, err
+		}
+		context := []*TopicNode{{Children: s.Children}}
+		for _, step := range steps {
+			var next []*TopicNode
+			for _, n := range context {
+				if step.name == "." {
+					n.Walk(func(d *TopicNode) bool {
+						next = append(next, d)
+						return true
+					})
+					continue
+				}
+				for _, c := range n.Children {
+					if !step.descendant {
+						if step.matches(c) {
+							next = append(next, c)
+						}
+						continue
+					}
+					c.Walk(func(d *TopicNode) bool {
+						if step.matches(d) {
+							next = append(next, d)
+						}
+						return true
+					})
+				}
+			}
+			context = next
+		}
+		for _, n := range context {
+			if !seen[n] {
+				seen[n] = true
+				out = append(out, n)
+			}
+		}
+	}
+	return out, nil
+}
+
+// topicStep is a segment of a topic expression.
+type topicStep struct {
+	prefix string
+	// name is * for any node, or . for the context node and its descendants.
+	name string
+	// descendant tells if the step follows a //.
+	descendant bool
+}
+
+func (step topicStep) matches(n *TopicNode) bool {
+	return step.matchesName(n.Name)
+}
+
+func (step topicStep) matchesName(name string) bool {
+	return step.name == "*" || step.name == name
+}
+
+// ValidateTopicExpression returns an error when expression is not a valid
+// ConcreteSet expression, see FilterBuilder.Topic.
+func ValidateTopicExpression(expression string) error {
+	for _, expr := range strings.Split(expression, "|") {
+		if _, err := parseTopicExpression(strings.TrimSpace(expr)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MatchTopic tells if the topic of a notification, e.g.
+// tns1:RuleEngine/CellMotionDetector/Motion, is selected by a ConcreteSet
+// expression, see FilterBuilder.Topic. The names are compared by their local name.
+func MatchTopic(expression, topic string) (bool, error) {
+	segments := strings.Split(strings.TrimSpace(topic), "/")
+	for i, segment := range segments {
+		segments[i] = segment[strings.IndexByte(segment, ':')+1:]
+	}
+	for _, expr := range strings.Split(expression, "|") {
+		steps, err := parseTopicExpression(strings.TrimSpace(expr))
+		if err != nil {
+			return false, err
+		}
+		if matchSteps(steps, segments) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func matchSteps(steps []topicStep, segments []string) bool {
+	if len(steps) == 0 {
+		return len(segments) == 0
+	}
+	step := steps[0]
+	switch {
+	case step.name == ".":
+		return true
+	case step.descendant:
+		for i, segment := range segments {
+			if step.matchesName(segment) && matchSteps(steps[1:], segments[i+1:]) {
+				return true
+			}
+		}
+		return false
+	}
+	return len(segments) > 0 && step.matchesName(segments[0]) && matchSteps(steps[1:], segments[1:])
+}
+
+func parseTopicExpression(expr string) ([]topicStep, error) {
+	if expr == "" {
+		return nil, errors.New("empty topic expression")
+	}
+	segments := strings.Split(expr, "/")
+	steps := make([]topicStep, 0, len(segments))
+	descendant := false
+	for i, segment := range segments {
+		if segment == "" {
+			if i == 0 || descendant || i == len(segments)-1 {
+				return nil, errors.Errorf("invalid topic expression %q", expr)
+			}
+			descendant = true
+			continue
+		}
+		step := topicStep{name: segment, descendant: descendant}
+		descendant = false
+		switch {
+		case i == 0 && (segment == "*" || segment == "."):
+			return nil, errors.Errorf("topic expression %q does not start with a topic", expr)
+		case segment == ".":
+			if !step.descendant || i != len(segments)-1 {
+				return nil, errors.Errorf("topic expression %q has . elsewhere than a trailing //.", expr)
+			}
+		case segment == "*":
+		default:
+			if colon := strings.IndexByte(segment, ':'); colon >= 0 {
+				step.prefix, step.name = segment[:colon], segment[colon+1:]
+				if !isNCName(step.prefix) {
+					return nil, errors.Errorf("invalid prefix %q in topic expression %q", step.prefix, expr)
+				}
+			} else if i == 0 {
+				return nil, errors.Errorf("root topic of %q has no prefix", expr)
+			}
+			if !isNCName(step.name) {
+				return nil, errors.Errorf("invalid name %q in topic expression %q", step.name, expr)
+			}
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// isNCName tells if s is a name without colon, as allowed in XML.
+func isNCName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// xpathLiteral quotes s as an XPath string literal.
+func xpathLiteral(s string) (string, error) {
+	switch {
+	case !strings.Contains(s, `"`):
+		return `"` + s + `"`, nil
+	case !strings.Contains(s, `'`):
+		return `'` + s + `'`, nil
+	}
+	return "", errors.Errorf("value %q holds both quotes", s)
+}
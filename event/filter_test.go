@@ -0,0 +1,76 @@
+package event
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestFilterBuilder(t *testing.T) {
+	var props GetEventPropertiesResponse
+	if err := xml.Unmarshal([]byte(eventProperties), &props); err != nil {
+		t.Fatal(err)
+	}
+	for expr, want := range map[string]int{
+		"tns1:RuleEngine//.":                             3,
+		"tns1:RuleEngine/*/Motion":                       1,
+		"tns1:RuleEngine//Motion|tns1:Device/tnsaxis:IO": 2,
+		"tns1:VideoSource/MotionAlarm":                   0,
+	} {
+		nodes, err := props.TopicSet.Match(expr)
+		if err != nil || len(nodes) != want {
+			t.Errorf("%s matched %d nodes, %v", expr, len(nodes), err)
+		}
+	}
+
+	for expr, want := range map[string]bool{
+		"tns1:RuleEngine//.":                          true,
+		"tns1:RuleEngine/*/Motion":                    true,
+		"tns1:RuleEngine/CellMotionDetector":          false,
+		"tns1:VideoSource//.|tns1:RuleEngine//Motion": true,
+		"tns1:RuleEngine//Crossed":                    false,
+	} {
+		if got, err := MatchTopic(expr, "tns1:RuleEngine/tns1:CellMotionDetector/Motion"); got != want || err != nil {
+			t.Errorf("%s matched %v, %v", expr, got, err)
+		}
+	}
+
+	filter, err := NewFilter("tns1:RuleEngine//.", "tns1:Device/tnsaxis:IO/Port").
+		Rule("MyMotionDetectorRule").
+		PropertyOperation(PropertyInitialized, PropertyChanged).
+		Within(props.TopicSet).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := xml.Marshal(Subscribe{Filter: filter})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<wsnt:TopicExpression Dialect="` + TopicExpressionConcreteSet + `" xmlns:tns1="http://www.onvif.org/ver10/topics" xmlns:tnsaxis="http://www.axis.com/2009/event/topics">tns1:RuleEngine//.|tns1:Device/tnsaxis:IO/Port</wsnt:TopicExpression>`,
+		`<wsnt:MessageContent Dialect="` + MessageContentItemFilter + `" xmlns:tt="http://www.onvif.org/ver10/schema">` +
+			`boolean(//tt:Source/tt:SimpleItem[@Name=&#34;Rule&#34; and @Value=&#34;MyMotionDetectorRule&#34;]) and ` +
+			`boolean(//tt:Message[@PropertyOperation=&#34;Initialized&#34; or @PropertyOperation=&#34;Changed&#34;])</wsnt:MessageContent>`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("missing %s in %s", want, b)
+		}
+	}
+
+	for _, builder := range []*FilterBuilder{
+		NewFilter("RuleEngine/CellMotionDetector"),
+		NewFilter("tns1:RuleEngine//"),
+		NewFilter("tns1:RuleEngine/./Motion"),
+		NewFilter("tns1:VideoSource/MotionAlarm").Within(props.TopicSet),
+		NewFilter("acme:Door/Open"),
+		NewFilter().SourceToken(`a"b'c`),
+	} {
+		if _, err := builder.Build(); err == nil {
+			t.Errorf("expected an error from %+v", builder)
+		}
+	}
+	if filter, err := NewFilter("acme:Door/Open").Namespace("acme", "urn:acme").Build(); err != nil || filter.TopicExpression.Namespaces[0].Value != "urn:acme" {
+		t.Errorf("unexpected filter %+v, %v", filter, err)
+	}
+}
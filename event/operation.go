@@ -23,9 +23,9 @@ type SubscriptionPolicy struct { //tev http://www.onvif.org/ver10/events/wsdl
 type Subscribe struct { //http://docs.oasis-open.org/wsn/b-2.xsd
 	XMLName                struct{}                   `xml:"wsnt:Subscribe"`
 	ConsumerReference      EndpointReferenceType      `xml:"wsnt:ConsumerReference"`
-	Filter                 FilterType                 `xml:"wsnt:Filter"`
-	SubscriptionPolicy     SubscriptionPolicy         `xml:"wsnt:SubscriptionPolicy"`
-	InitialTerminationTime AbsoluteOrRelativeTimeType `xml:"wsnt:InitialTerminationTime"`
+	Filter                 *FilterType                `xml:"wsnt:Filter,omitempty"`
+	InitialTerminationTime AbsoluteOrRelativeTimeType `xml:"wsnt:InitialTerminationTime,omitempty"`
+	SubscriptionPolicy     *SubscriptionPolicy        `xml:"wsnt:SubscriptionPolicy,omitempty"`
 }
 
 //SubscribeResponse message for subscribe event topic
@@ -35,33 +35,56 @@ type SubscribeResponse struct { //http://docs.oasis-open.org/wsn/b-2.xsd
 	TerminationTime       TerminationTime
 }
 
-//Renew action for refresh event topic subscription
+//Notify message pushed by the device to the consumer of a subscription
+type Notify struct { //http://docs.oasis-open.org/wsn/b-2.xsd
+	NotificationMessage []NotificationMessage
+}
+
+//Renew action for refresh event topic subscription, sent to the subscription manager
 type Renew struct { //http://docs.oasis-open.org/wsn/b-2.xsd
+	XMLName         string                     `xml:"wsnt:Renew"`
 	TerminationTime AbsoluteOrRelativeTimeType `xml:"wsnt:TerminationTime"`
 }
 
 //RenewResponse for Renew action
 type RenewResponse struct { //http://docs.oasis-open.org/wsn/b-2.xsd
-	TerminationTime TerminationTime `xml:"wsnt:TerminationTime"`
-	CurrentTime     CurrentTime     `xml:"wsnt:CurrentTime"`
+	TerminationTime TerminationTime
+	CurrentTime     CurrentTime
 }
 
-//Unsubscribe action for Unsubscribe event topic
+//Unsubscribe action for Unsubscribe event topic, sent to the subscription manager
 type Unsubscribe struct { //http://docs.oasis-open.org/wsn/b-2.xsd
-	Any string
+	XMLName string `xml:"wsnt:Unsubscribe"`
 }
 
 //UnsubscribeResponse message for Unsubscribe event topic
 type UnsubscribeResponse struct { //http://docs.oasis-open.org/wsn/b-2.xsd
-	Any string
+}
+
+//PauseSubscription action of the pausable subscription manager
+type PauseSubscription struct { //http://docs.oasis-open.org/wsn/b-2.xsd
+	XMLName string `xml:"wsnt:PauseSubscription"`
+}
+
+//PauseSubscriptionResponse message for PauseSubscription
+type PauseSubscriptionResponse struct { //http://docs.oasis-open.org/wsn/b-2.xsd
+}
+
+//ResumeSubscription action of the pausable subscription manager
+type ResumeSubscription struct { //http://docs.oasis-open.org/wsn/b-2.xsd
+	XMLName string `xml:"wsnt:ResumeSubscription"`
+}
+
+//ResumeSubscriptionResponse message for ResumeSubscription
+type ResumeSubscriptionResponse struct { //http://docs.oasis-open.org/wsn/b-2.xsd
 }
 
 //CreatePullPointSubscription action
 type CreatePullPointSubscription struct {
 	XMLName                string                     `xml:"tev:CreatePullPointSubscription"`
-	Filter                 FilterType                 `xml:"tev:Filter"`
-	InitialTerminationTime AbsoluteOrRelativeTimeType `xml:"wsnt:InitialTerminationTime"`
-	SubscriptionPolicy     SubscriptionPolicy         `xml:"wsnt:sSubscriptionPolicy"`
+	Filter                 *FilterType                `xml:"tev:Filter,omitempty"`
+	InitialTerminationTime AbsoluteOrRelativeTimeType `xml:"tev:InitialTerminationTime,omitempty"`
+	SubscriptionPolicy     *SubscriptionPolicy        `xml:"tev:SubscriptionPolicy,omitempty"`
 }
 
 //CreatePullPointSubscriptionResponse action
@@ -100,7 +123,7 @@ type PullMessages struct {
 type PullMessagesResponse struct {
 	CurrentTime         CurrentTime
 	TerminationTime     TerminationTime
-	NotificationMessage NotificationMessage
+	NotificationMessage []NotificationMessage
 }
 
 //PullMessagesFaultResponse response type
@@ -0,0 +1,189 @@
+package event
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ritj/onvif/xsd"
+	"github.com/ritj/onvif/xsd/onvif"
+)
+
+// Property is the current state of a property of a device, e.g. of a digital
+// input or of the motion of an analytics rule. It is identified by its topic
+// and the items of its Source and Key.
+type Property struct {
+	Topic   string
+	Source  onvif.ItemList
+	Key     onvif.ItemList
+	Data    onvif.ItemList
+	UtcTime time.Time
+	// Operation is the last operation applied, PropertyDeleted when the
+	// property does not exist anymore.
+	Operation PropertyOperation
+}
+
+// PropertyChange is a new value of a property. Previous is nil when the
+// property is new.
+type PropertyChange struct {
+	Property
+	Previous *Property
+}
+
+// PropertyStore folds the property notifications into the current state of
+// the properties. It only keeps the notifications with a PropertyOperation.
+type PropertyStore struct {
+	// OnChange is called, in the goroutine of Apply, when a property appears,
+	// when its Data changes and when it is deleted.
+	OnChange func(PropertyChange)
+
+	mu         sync.RWMutex
+	properties map[string]Property
+	// stale are the properties marked by Mark that no notification confirmed since.
+	stale map[string]bool
+}
+
+// NewPropertyStore returns an empty PropertyStore.
+func NewPropertyStore() *PropertyStore {
+	return &PropertyStore{properties: map[string]Property{}}
+}
+
+// Apply folds a notification into the state. It returns false when msg is
+// not about a property.
+func (s *PropertyStore) Apply(msg NotificationMessage) bool {
+	holder := msg.Message.Message
+	if holder.PropertyOperation == "" {
+		return false
+	}
+	p := Property{
+		Topic:     messageTopic(msg),
+		Source:    holder.Source,
+		Key:       holder.Key,
+		Data:      holder.Data,
+		UtcTime:   holder.UtcTime,
+		Operation: holder.PropertyOperation,
+	}
+	id := propertyID(p.Topic, p.Source, p.Key)
+
+	s.mu.Lock()
+	delete(s.stale, id)
+	previous, known := s.properties[id]
+	if p.Operation == PropertyDeleted {
+		delete(s.properties, id)
+	} else {
+		s.properties[id] = p
+	}
+	s.mu.Unlock()
+
+	if s.OnChange == nil {
+		return true
+	}
+	switch {
+	case !known && p.Operation == PropertyDeleted:
+	case !known:
+		s.OnChange(PropertyChange{Property: p})
+	case p.Operation == PropertyDeleted || !reflect.DeepEqual(previous.Data, p.Data):
+		s.OnChange(PropertyChange{Property: p, Previous: &previous})
+	}
+	return true
+}
+
+// Get returns the property of topic and of the Source and Key items given,
+// as name and value pairs.
+func (s *PropertyStore) Get(topic string, source, key map[string]string) (Property, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.properties[propertyID(normalizeTopic(topic), itemList(source), itemList(key))]
+	return p, ok
+}
+
+// Properties returns the properties of the topics of pattern, a topic or a
+// parent followed by /*, all of them when pattern is empty. They are sorted
+// by topic.
+func (s *PropertyStore) Properties(pattern string) []Property {
+	pattern = normalizeTopic(pattern)
+	parent := strings.TrimSuffix(pattern, "/*")
+	s.mu.RLock()
+	var out []Property
+	for _, p := range s.properties {
+		if pattern == "" || p.Topic == pattern || (parent != pattern && strings.HasPrefix(p.Topic, parent+"/")) {
+			out = append(out, p)
+		}
+	}
+	s.mu.RUnlock()
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Topic != out[j].Topic {
+			return out[i].Topic < out[j].Topic
+		}
+		return propertyID("", out[i].Source, out[i].Key) < propertyID("", out[j].Source, out[j].Key)
+	})
+	return out
+}
+
+// Reset forgets every property, their next notifications being reported as new.
+func (s *PropertyStore) Reset() {
+	s.mu.Lock()
+	s.properties = map[string]Property{}
+	s.stale = nil
+	s.mu.Unlock()
+}
+
+// Mark marks every property as stale until a notification about it is
+// applied, e.g. when a new subscription starts with the Initialized
+// notifications of the properties that still exist.
+func (s *PropertyStore) Mark() {
+	s.mu.Lock()
+	s.stale = make(map[string]bool, len(s.properties))
+	for id := range s.properties {
+		s.stale[id] = true
+	}
+	s.mu.Unlock()
+}
+
+// Sweep deletes the properties still stale since Mark, as if the device had
+// deleted them, and returns them.
+func (s *PropertyStore) Sweep() []Property {
+	s.mu.Lock()
+	var swept []Property
+	for id := range s.stale {
+		if p, ok := s.properties[id]; ok {
+			swept = append(swept, p)
+			delete(s.properties, id)
+		}
+	}
+	s.stale = nil
+	s.mu.Unlock()
+
+	if s.OnChange != nil {
+		for i := range swept {
+			p := swept[i]
+			p.Operation = PropertyDeleted
+			s.OnChange(PropertyChange{Property: p, Previous: &swept[i]})
+		}
+	}
+	return swept
+}
+
+// propertyID identifies a property by its topic and its simple Source and
+// Key items, whatever their order.
+func propertyID(topic string, source, key onvif.ItemList) string {
+	items := func(l onvif.ItemList) string {
+		pairs := make([]string, len(l.SimpleItem))
+		for i, item := range l.SimpleItem {
+			pairs[i] = item.Name + "=" + string(item.Value)
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, "&")
+	}
+	return topic + "?" + items(source) + "#" + items(key)
+}
+
+func itemList(items map[string]string) onvif.ItemList {
+	var l onvif.ItemList
+	for name, value := range items {
+		l.SimpleItem = append(l.SimpleItem, onvif.SimpleItem{Name: name, Value: xsd.AnySimpleType(value)})
+	}
+	return l
+}
@@ -0,0 +1,91 @@
+package event
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPropertyStore(t *testing.T) {
+	s := NewPropertyStore()
+	var changes []PropertyChange
+	s.OnChange = func(c PropertyChange) { changes = append(changes, c) }
+	input := func(op PropertyOperation, token, state string) NotificationMessage {
+		msg := notification("tns1:Device/Trigger/DigitalInput", `
+			<tt:Source><tt:SimpleItem Name="InputToken" Value="`+token+`"/></tt:Source>
+			<tt:Data><tt:SimpleItem Name="LogicalState" Value="`+state+`"/></tt:Data>`)
+		msg.Message.Message.PropertyOperation = op
+		return msg
+	}
+
+	s.Apply(input(PropertyInitialized, "in1", "false"))
+	s.Apply(input(PropertyInitialized, "in2", "false"))
+	s.Apply(input(PropertyChanged, "in1", "true"))
+	s.Apply(input(PropertyInitialized, "in1", "true"))
+	s.Apply(input(PropertyDeleted, "in2", "false"))
+	crossed := notification("tns1:RuleEngine/LineDetector/Crossed", "")
+	crossed.Message.Message.PropertyOperation = ""
+	if s.Apply(crossed) {
+		t.Error("a notification without PropertyOperation was applied")
+	}
+
+	if p, ok := s.Get("tns1:Device/Trigger/DigitalInput", map[string]string{"InputToken": "in1"}, nil); !ok || p.Data.SimpleItem[0].Value != "true" {
+		t.Errorf("unexpected in1 %+v", p)
+	}
+	if _, ok := s.Get("tns1:Device/Trigger/DigitalInput", map[string]string{"InputToken": "in2"}, nil); ok {
+		t.Error("in2 was not deleted")
+	}
+	if n := len(s.Properties("tns1:Device/*")); n != 1 {
+		t.Errorf("expected 1 property, got %d", n)
+	}
+
+	var got []string
+	for _, c := range changes {
+		token, _ := c.Source.Simple("InputToken")
+		got = append(got, string(c.Operation)+" "+string(token))
+	}
+	if want := "Initialized in1,Initialized in2,Changed in1,Deleted in2"; strings.Join(got, ",") != want {
+		t.Errorf("unexpected changes %s", strings.Join(got, ","))
+	}
+	if changes[2].Previous == nil || changes[2].Previous.Data.SimpleItem[0].Value != "false" {
+		t.Errorf("unexpected previous value %+v", changes[2].Previous)
+	}
+}
+
+func TestPropertyStoreSweep(t *testing.T) {
+	s := NewPropertyStore()
+	input := func(op PropertyOperation, token string) NotificationMessage {
+		msg := notification("tns1:Device/Trigger/DigitalInput", `
+			<tt:Source><tt:SimpleItem Name="InputToken" Value="`+token+`"/></tt:Source>
+			<tt:Data><tt:SimpleItem Name="LogicalState" Value="true"/></tt:Data>`)
+		msg.Message.Message.PropertyOperation = op
+		return msg
+	}
+	s.Apply(input(PropertyInitialized, "in1"))
+	s.Apply(input(PropertyInitialized, "in2"))
+
+	// in2 was deleted while no subscription existed, the new one only
+	// initializes in1 and in3.
+	var deleted []PropertyChange
+	s.OnChange = func(c PropertyChange) {
+		if c.Operation == PropertyDeleted {
+			deleted = append(deleted, c)
+		}
+	}
+	s.Mark()
+	s.Apply(input(PropertyInitialized, "in1"))
+	s.Apply(input(PropertyInitialized, "in3"))
+	swept := s.Sweep()
+
+	if len(swept) != 1 || len(deleted) != 1 || deleted[0].Previous == nil {
+		t.Fatalf("unexpected sweep %+v, changes %+v", swept, deleted)
+	}
+	if token, _ := swept[0].Source.Simple("InputToken"); token != "in2" {
+		t.Errorf("swept %s rather than in2", token)
+	}
+	if n := len(s.Properties("")); n != 2 {
+		t.Errorf("expected 2 properties, got %d", n)
+	}
+	if swept := s.Sweep(); len(swept) != 0 {
+		t.Errorf("swept %+v without a Mark", swept)
+	}
+}
@@ -0,0 +1,262 @@
+package event
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/ritj/onvif/xsd/onvif"
+)
+
+// The namespaces of the topics and of the topic sets
+const (
+	TopicNamespaceONVIF = "http://www.onvif.org/ver10/topics"
+	TopicSetNamespace   = "http://docs.oasis-open.org/wsn/t-1"
+)
+
+// topicPrefixes are the namespaces of the topics whose prefix is known
+// beforehand, as devices often declare it on the SOAP envelope.
+var topicPrefixes = map[string]string{
+	"tns1":    TopicNamespaceONVIF,
+	"wstop":   TopicSetNamespace,
+	"tt":      "http://www.onvif.org/ver10/schema",
+	"tnsaxis": "http://www.axis.com/2009/event/topics",
+}
+
+// TopicNode is a node of the topic tree of a device.
+type TopicNode struct {
+	// Name is the local name of the node.
+	Name string
+	// Prefix is the namespace prefix of the node, as written by the device.
+	Prefix string
+	// Namespace is the namespace of the node, empty when its prefix was
+	// declared out of the topic set and is not a well-known one.
+	Namespace string
+	// IsTopic tells if the node is marked with wstop:topic="true", i.e. if
+	// notifications are published on it.
+	IsTopic bool
+	// MessageDescription describes the items of the notifications of the topic.
+	MessageDescription *onvif.MessageDescription
+	Parent             *TopicNode
+	Children           []*TopicNode
+}
+
+// Path returns the topic as written in a ConcreteSet expression, e.g.
+// tns1:RuleEngine/CellMotionDetector/Motion. The prefix of a node is written
+// when it differs from the one of its parent.
+func (n *TopicNode) Path() string {
+	segment := n.Name
+	if n.Prefix != "" && (n.Parent == nil || n.Parent.Prefix != n.Prefix) {
+		segment = n.Prefix + ":" + n.Name
+	}
+	if n.Parent == nil {
+		return segment
+	}
+	return n.Parent.Path() + "/" + segment
+}
+
+// Walk calls fn on n then on its descendants, depth first, until fn returns false.
+func (n *TopicNode) Walk(fn func(*TopicNode) bool) bool {
+	if !fn(n) {
+		return false
+	}
+	for _, c := range n.Children {
+		if !c.Walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Walk calls fn on every node of the set, depth first, until fn returns false.
+func (s TopicSet) Walk(fn func(*TopicNode) bool) {
+	for _, n := range s.Children {
+		if !n.Walk(fn) {
+			return
+		}
+	}
+}
+
+// Leaves returns the nodes without children.
+func (s TopicSet) Leaves() []*TopicNode {
+	var out []*TopicNode
+	s.Walk(func(n *TopicNode) bool {
+		if len(n.Children) == 0 {
+			out = append(out, n)
+		}
+		return true
+	})
+	return out
+}
+
+// LeafTopics returns the paths of the nodes without children, e.g.
+// tns1:RuleEngine/CellMotionDetector/Motion.
+func (s TopicSet) LeafTopics() []string {
+	var out []string
+	for _, n := range s.Leaves() {
+		out = append(out, n.Path())
+	}
+	return out
+}
+
+// Find returns the node of path, or nil. The segments are compared by their
+// local name, whatever their prefix.
+func (s TopicSet) Find(path string) *TopicNode {
+	nodes := s.Children
+	var found *TopicNode
+	for _, segment := range strings.Split(path, "/") {
+		segment = segment[strings.IndexByte(segment, ':')+1:]
+		found = nil
+		for _, n := range nodes {
+			if n.Name == segment {
+				found = n
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		nodes = found.Children
+	}
+	return found
+}
+
+// UnmarshalXML builds the topic tree from the elements of the topic set.
+// The content is read again as written, so that the prefixes of the topics
+// are known.
+func (s *TopicSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw struct {
+		Inner []byte `xml:",innerxml"`
+	}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	scope := map[string]string{}
+	for prefix, ns := range topicPrefixes {
+		scope[prefix] = ns
+	}
+	for _, a := range start.Attr {
+		if a.Name.Space == "xmlns" {
+			scope[a.Name.Local] = a.Value
+		}
+	}
+
+	s.Children = nil
+	var parent *TopicNode
+	scopes := []map[string]string{scope}
+	rd := xml.NewDecoder(bytes.NewReader(raw.Inner))
+	for {
+		tok, err := rd.RawToken()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			scope := declare(scopes[len(scopes)-1], t.Attr)
+			switch {
+			case t.Name.Local == "MessageDescription":
+				desc, err := decodeRaw(rd, t)
+				if err != nil {
+					return err
+				}
+				if parent != nil {
+					parent.MessageDescription = desc
+				}
+				continue
+			case t.Name.Local == "documentation" && scope[t.Name.Space] == TopicSetNamespace:
+				if err := rd.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			node := &TopicNode{Name: t.Name.Local, Prefix: t.Name.Space, Namespace: scope[t.Name.Space], Parent: parent}
+			for _, a := range t.Attr {
+				if a.Name.Local == "topic" && scope[a.Name.Space] == TopicSetNamespace {
+					node.IsTopic = strings.TrimSpace(a.Value) == "true"
+				}
+			}
+			if parent == nil {
+				s.Children = append(s.Children, node)
+			} else {
+				parent.Children = append(parent.Children, node)
+			}
+			parent = node
+			scopes = append(scopes, scope)
+		case xml.EndElement:
+			if parent != nil {
+				parent = parent.Parent
+			}
+			scopes = scopes[:len(scopes)-1]
+		}
+	}
+}
+
+// declare returns the namespaces in scope of an element.
+func declare(scope map[string]string, attrs []xml.Attr) map[string]string {
+	var out map[string]string
+	for _, a := range attrs {
+		if a.Name.Space == "xmlns" {
+			if out == nil {
+				out = map[string]string{}
+				for k, v := range scope {
+					out[k] = v
+				}
+			}
+			out[a.Name.Local] = a.Value
+		}
+	}
+	if out == nil {
+		return scope
+	}
+	return out
+}
+
+// decodeRaw decodes the MessageDescription opened by start from a decoder
+// read with RawToken. The prefixes are dropped, as the schema of the
+// description is known.
+func decodeRaw(rd *xml.Decoder, start xml.StartElement) (*onvif.MessageDescription, error) {
+	var b bytes.Buffer
+	e := xml.NewEncoder(&b)
+	var tok xml.Token = start
+	for depth := 0; ; {
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			attrs := t.Attr[:0:0]
+			for _, a := range t.Attr {
+				if a.Name.Space != "xmlns" && a.Name.Local != "xmlns" {
+					attrs = append(attrs, xml.Attr{Name: xml.Name{Local: a.Name.Local}, Value: a.Value})
+				}
+			}
+			tok = xml.StartElement{Name: xml.Name{Local: t.Name.Local}, Attr: attrs}
+		case xml.EndElement:
+			depth--
+			tok = xml.EndElement{Name: xml.Name{Local: t.Name.Local}}
+		case xml.CharData:
+		default:
+			tok = nil
+		}
+		if tok != nil {
+			if err := e.EncodeToken(tok); err != nil {
+				return nil, err
+			}
+		}
+		if depth == 0 {
+			break
+		}
+		next, err := rd.RawToken()
+		if err != nil {
+			return nil, err
+		}
+		tok = xml.CopyToken(next)
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+	desc := &onvif.MessageDescription{}
+	return desc, xml.Unmarshal(b.Bytes(), desc)
+}
@@ -1,6 +1,12 @@
 package event
 
 import (
+	"bytes"
+	"encoding/xml"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/ritj/onvif/xsd"
 	"github.com/ritj/onvif/xsd/onvif"
 )
@@ -31,13 +37,58 @@ type MessageNotification struct {
 	Message MessageNotificationHolderType
 }
 
+//MessageNotificationHolderType is the tt:Message of a notification
 type MessageNotificationHolderType struct {
-	UtcTime           xsd.DateTime     `xml:",attr"`
-	PropertyOperation xsd.String       `xml:",attr"`
-	Source            onvif.SimpleItem `xml:"Source>SimpleItem"`
-	Data              onvif.SimpleItem `xml:"Data>SimpleItem"`
+	// UtcTime is zero when the device sent none, or one that is not a
+	// valid dateTime, see RawUtcTime.
+	UtcTime time.Time `xml:"UtcTime,attr"`
+	// RawUtcTime is the UtcTime attribute as sent.
+	RawUtcTime        xsd.DateTime      `xml:"-"`
+	PropertyOperation PropertyOperation `xml:"PropertyOperation,attr,omitempty"`
+	Source            onvif.ItemList    `xml:"Source"`
+	Key               onvif.ItemList    `xml:"Key"`
+	Data              onvif.ItemList    `xml:"Data"`
+}
+
+// UnmarshalXML accepts the UtcTime without a timezone some devices send, as UTC.
+// An invalid UtcTime is left zero rather than failing the whole reply.
+func (m *MessageNotificationHolderType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw struct {
+		UtcTime           xsd.DateTime      `xml:"UtcTime,attr"`
+		PropertyOperation PropertyOperation `xml:"PropertyOperation,attr"`
+		Source            onvif.ItemList    `xml:"Source"`
+		Key               onvif.ItemList    `xml:"Key"`
+		Data              onvif.ItemList    `xml:"Data"`
+	}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	*m = MessageNotificationHolderType{
+		RawUtcTime:        raw.UtcTime,
+		PropertyOperation: raw.PropertyOperation,
+		Source:            raw.Source,
+		Key:               raw.Key,
+		Data:              raw.Data,
+	}
+	if raw.UtcTime != "" {
+		if t, err := raw.UtcTime.Time(); err == nil {
+			m.UtcTime = t
+		}
+	}
+	return nil
 }
 
+// PropertyOperation tells how a property changed, it is empty in the
+// notifications that are not about a property.
+type PropertyOperation xsd.String
+
+// The operations of a property
+const (
+	PropertyInitialized PropertyOperation = "Initialized"
+	PropertyChanged     PropertyOperation = "Changed"
+	PropertyDeleted     PropertyOperation = "Deleted"
+)
+
 //ActionType for AttributedURIType
 type ActionType AttributedURIType
 
@@ -54,10 +105,23 @@ type EndpointReferenceType struct { //wsa http://www.w3.org/2005/08/addressing/w
 	Metadata            MetadataType
 }
 
+// MarshalXML writes the endpoint in the wsa namespace, e.g. as the
+// ConsumerReference of a Subscribe.
+func (r EndpointReferenceType) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	out := struct {
+		Address             AttributedURIType        `xml:"wsa:Address"`
+		ReferenceParameters *ReferenceParametersType `xml:"wsa:ReferenceParameters,omitempty"`
+	}{Address: r.Address}
+	if len(r.ReferenceParameters.Parameters) > 0 {
+		out.ReferenceParameters = &r.ReferenceParameters
+	}
+	return e.EncodeElement(out, start)
+}
+
 // FilterType struct
 type FilterType struct {
-	TopicExpression TopicExpressionType `xml:"wsnt:TopicExpression"`
-	MessageContent  *QueryExpressionType `xml:"wsnt:MessageContent"`
+	TopicExpression *TopicExpressionType `xml:"wsnt:TopicExpression,omitempty"`
+	MessageContent  *QueryExpressionType `xml:"wsnt:MessageContent,omitempty"`
 }
 
 //EndpointReference alais
@@ -65,7 +129,80 @@ type EndpointReference EndpointReferenceType
 
 //ReferenceParametersType in ws-addr
 type ReferenceParametersType struct { //wsa https://www.w3.org/2005/08/addressing/ws-addr.xsd
-	//Here can be anyAttribute
+	// Parameters holds the XML of every parameter, each one declaring its
+	// namespace, ready to be added to the SOAP header of the messages sent to
+	// the endpoint.
+	Parameters []string
+}
+
+// UnmarshalXML keeps the parameters as XML, with the namespaces they were in.
+func (r *ReferenceParametersType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.EndElement:
+			return nil
+		case xml.StartElement:
+			param, err := copyElement(d, t)
+			if err != nil {
+				return err
+			}
+			r.Parameters = append(r.Parameters, param)
+		}
+	}
+}
+
+// MarshalXML writes the parameters as they were read.
+func (r ReferenceParametersType) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(struct {
+		Content string `xml:",innerxml"`
+	}{strings.Join(r.Parameters, "")}, start)
+}
+
+// copyElement re-encodes the element opened by start, the namespaces of its
+// elements being declared on themselves rather than on their ancestors.
+func copyElement(d *xml.Decoder, start xml.StartElement) (string, error) {
+	var b bytes.Buffer
+	e := xml.NewEncoder(&b)
+	var tok xml.Token = start
+	for depth := 0; ; {
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			attrs := t.Attr[:0:0]
+			for _, a := range t.Attr {
+				if a.Name.Space != "xmlns" && !(a.Name.Space == "" && a.Name.Local == "xmlns") {
+					attrs = append(attrs, a)
+				}
+			}
+			t.Attr = attrs
+			tok = t
+		case xml.EndElement:
+			depth--
+		case xml.Comment, xml.ProcInst, xml.Directive:
+			tok = nil
+		}
+		if tok != nil {
+			if err := e.EncodeToken(tok); err != nil {
+				return "", err
+			}
+		}
+		if depth == 0 {
+			break
+		}
+		next, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+		tok = xml.CopyToken(next)
+	}
+	if err := e.Flush(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
 }
 
 //Metadata in ws-addr
@@ -80,10 +217,10 @@ type MetadataType struct { //wsa https://www.w3.org/2005/08/addressing/ws-addr.x
 //TopicSet alias
 type TopicSet TopicSetType //wstop http://docs.oasis-open.org/wsn/t-1.xsd
 
-//TopicSetType alias
+//TopicSetType is the tree of the topics of a device, see TopicSet.Find()
 type TopicSetType struct { //wstop http://docs.oasis-open.org/wsn/t-1.xsd
 	ExtensibleDocumented
-	//here can be any element
+	Children []*TopicNode
 }
 
 //ExtensibleDocumented struct
@@ -113,6 +250,8 @@ type NotificationMessage NotificationMessageHolderType //wsnt http://docs.oasis-
 type QueryExpressionType struct { //wsnt http://docs.oasis-open.org/wsn/b-2.xsd
 	Dialect     xsd.AnyURI `xml:"Dialect,attr"`
 	MessageKind xsd.String `xml:",chardata"` // boolean(ncex:Producer="15")
+	//Namespaces declares the prefixes of the expression, see FilterBuilder
+	Namespaces []xml.Attr `xml:",any,attr"`
 }
 
 //MessageContentType Alias
@@ -125,11 +264,39 @@ type QueryExpression QueryExpressionType
 type TopicExpressionType struct { //wsnt http://docs.oasis-open.org/wsn/b-2.xsd
 	Dialect    xsd.AnyURI `xml:"Dialect,attr"`
 	TopicKinds xsd.String `xml:",chardata"`
+	//Namespaces declares the prefixes of the expression, see FilterBuilder
+	Namespaces []xml.Attr `xml:",any,attr"`
 }
 
 //Topic Alias
 type Topic TopicExpressionType
 
+// InheritNamespaces declares on the Topic the namespaces of its ancestors,
+// which its prefixes may refer to, unless their prefix is bound on the Topic
+// itself.
+func (t *Topic) InheritNamespaces(namespaces map[string]string) {
+	prefixes := make([]string, 0, len(namespaces))
+	for prefix := range namespaces {
+		if _, ok := t.namespace(prefix); !ok && prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		t.Namespaces = append(t.Namespaces, xml.Attr{Name: xml.Name{Local: "xmlns:" + prefix}, Value: namespaces[prefix]})
+	}
+}
+
+// namespace returns the namespace the Topic binds to prefix.
+func (t Topic) namespace(prefix string) (string, bool) {
+	for _, a := range t.Namespaces {
+		if (a.Name.Space == "xmlns" && a.Name.Local == prefix) || (a.Name.Space == "" && a.Name.Local == "xmlns:"+prefix) {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
 // Capabilities of event
 type Capabilities struct { //tev
 	WSSubscriptionPolicySupport                   xsd.Boolean `xml:"WSSubscriptionPolicySupport,attr"`
@@ -0,0 +1,175 @@
+package event
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+const subscriptionReference = `<tev:CreatePullPointSubscriptionResponse xmlns:tev="http://www.onvif.org/ver10/events/wsdl" xmlns:wsa="http://www.w3.org/2005/08/addressing" xmlns:dom0="http://www.axis.com/2009/event">
+	<tev:SubscriptionReference>
+		<wsa:Address>http://192.168.1.10/onvif/services</wsa:Address>
+		<wsa:ReferenceParameters><dom0:SubscriptionId>42</dom0:SubscriptionId></wsa:ReferenceParameters>
+	</tev:SubscriptionReference>
+</tev:CreatePullPointSubscriptionResponse>`
+
+func TestReferenceParameters(t *testing.T) {
+	var reply CreatePullPointSubscriptionResponse
+	if err := xml.Unmarshal([]byte(subscriptionReference), &reply); err != nil {
+		t.Fatal(err)
+	}
+	ref := reply.SubscriptionReference
+	if ref.Address != "http://192.168.1.10/onvif/services" {
+		t.Errorf("unexpected address %q", ref.Address)
+	}
+	want := `<SubscriptionId xmlns="http://www.axis.com/2009/event">42</SubscriptionId>`
+	if len(ref.ReferenceParameters.Parameters) != 1 || ref.ReferenceParameters.Parameters[0] != want {
+		t.Errorf("unexpected parameters %q", ref.ReferenceParameters.Parameters)
+	}
+}
+
+const pullMessages = `<tev:PullMessagesResponse xmlns:tev="http://www.onvif.org/ver10/events/wsdl" xmlns:wsnt="http://docs.oasis-open.org/wsn/b-2" xmlns:tt="http://www.onvif.org/ver10/schema">
+	<tev:CurrentTime>2024-01-01T00:00:01Z</tev:CurrentTime>
+	<tev:TerminationTime>2024-01-01T00:01:00Z</tev:TerminationTime>
+	<wsnt:NotificationMessage>
+		<wsnt:Topic Dialect="http://www.onvif.org/ver10/tev/topicExpression/ConcreteSet">tns1:RuleEngine/CellMotionDetector/Motion</wsnt:Topic>
+		<wsnt:Message><tt:Message UtcTime="2024-01-01T00:00:01.5Z" PropertyOperation="Changed">
+			<tt:Source>
+				<tt:SimpleItem Name="VideoSourceConfigurationToken" Value="vsc"/>
+				<tt:SimpleItem Name="VideoAnalyticsConfigurationToken" Value="vac"/>
+				<tt:SimpleItem Name="Rule" Value="MyMotionDetectorRule"/>
+			</tt:Source>
+			<tt:Data><tt:SimpleItem Name="IsMotion" Value="true"/></tt:Data>
+		</tt:Message></wsnt:Message>
+	</wsnt:NotificationMessage>
+	<wsnt:NotificationMessage>
+		<wsnt:Topic Dialect="http://www.onvif.org/ver10/tev/topicExpression/ConcreteSet">tns1:RuleEngine/MotionRegionDetector/Motion</wsnt:Topic>
+		<wsnt:Message><tt:Message UtcTime="2024-01-01T00:00:02">
+			<tt:Key><tt:SimpleItem Name="ObjectId" Value="12"/></tt:Key>
+			<tt:Data>
+				<tt:SimpleItem Name="State" Value="true"/>
+				<tt:ElementItem Name="Region"><tt:Polygon><tt:Point x="0" y="0"/></tt:Polygon></tt:ElementItem>
+			</tt:Data>
+		</tt:Message></wsnt:Message>
+	</wsnt:NotificationMessage>
+</tev:PullMessagesResponse>`
+
+func TestPullMessagesResponse(t *testing.T) {
+	var reply PullMessagesResponse
+	if err := xml.Unmarshal([]byte(pullMessages), &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.NotificationMessage) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(reply.NotificationMessage))
+	}
+
+	first := reply.NotificationMessage[0].Message.Message
+	if !first.UtcTime.Equal(time.Date(2024, 1, 1, 0, 0, 1, 5e8, time.UTC)) || first.PropertyOperation != PropertyChanged {
+		t.Errorf("unexpected first message %+v", first)
+	}
+	if rule, _ := first.Source.Simple("Rule"); len(first.Source.SimpleItem) != 3 || rule != "MyMotionDetectorRule" {
+		t.Errorf("unexpected source %+v", first.Source)
+	}
+
+	second := reply.NotificationMessage[1].Message.Message
+	if !second.UtcTime.Equal(time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC)) || second.PropertyOperation != "" {
+		t.Errorf("unexpected second message %+v", second)
+	}
+	if id, _ := second.Key.Simple("ObjectId"); id != "12" {
+		t.Errorf("unexpected key %+v", second.Key)
+	}
+	region, ok := second.Data.Element("Region")
+	if !ok || region.Content != `<tt:Polygon><tt:Point x="0" y="0"/></tt:Polygon>` {
+		t.Errorf("unexpected data %+v", second.Data)
+	}
+}
+
+const badUtcTime = `<tev:PullMessagesResponse xmlns:tev="http://www.onvif.org/ver10/events/wsdl" xmlns:wsnt="http://docs.oasis-open.org/wsn/b-2" xmlns:tt="http://www.onvif.org/ver10/schema">
+	<wsnt:NotificationMessage>
+		<wsnt:Topic Dialect="http://www.onvif.org/ver10/tev/topicExpression/ConcreteSet">tns1:Device/Trigger/DigitalInput</wsnt:Topic>
+		<wsnt:Message><tt:Message UtcTime="2024-13-45 25:00" PropertyOperation="Changed">
+			<tt:Data><tt:SimpleItem Name="LogicalState" Value="true"/></tt:Data>
+		</tt:Message></wsnt:Message>
+	</wsnt:NotificationMessage>
+	<wsnt:NotificationMessage>
+		<wsnt:Topic Dialect="http://www.onvif.org/ver10/tev/topicExpression/ConcreteSet">tns1:Device/Trigger/DigitalInput</wsnt:Topic>
+		<wsnt:Message><tt:Message UtcTime="2024-01-01T00:00:02Z" PropertyOperation="Changed">
+			<tt:Data><tt:SimpleItem Name="LogicalState" Value="false"/></tt:Data>
+		</tt:Message></wsnt:Message>
+	</wsnt:NotificationMessage>
+</tev:PullMessagesResponse>`
+
+func TestBadUtcTime(t *testing.T) {
+	var reply PullMessagesResponse
+	if err := xml.Unmarshal([]byte(badUtcTime), &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.NotificationMessage) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(reply.NotificationMessage))
+	}
+
+	bad := reply.NotificationMessage[0].Message.Message
+	if !bad.UtcTime.IsZero() || bad.RawUtcTime != "2024-13-45 25:00" {
+		t.Errorf("unexpected time %v, raw %q", bad.UtcTime, bad.RawUtcTime)
+	}
+	if state, _ := bad.Data.Simple("LogicalState"); state != "true" {
+		t.Errorf("unexpected data %+v", bad.Data)
+	}
+	good := reply.NotificationMessage[1].Message.Message
+	if !good.UtcTime.Equal(time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC)) || good.RawUtcTime != "2024-01-01T00:00:02Z" {
+		t.Errorf("unexpected time %v, raw %q", good.UtcTime, good.RawUtcTime)
+	}
+}
+
+const eventProperties = `<tev:GetEventPropertiesResponse xmlns:tev="http://www.onvif.org/ver10/events/wsdl" xmlns:wstop="http://docs.oasis-open.org/wsn/t-1" xmlns:tt="http://www.onvif.org/ver10/schema" xmlns:tns1="http://www.onvif.org/ver10/topics">
+	<wstop:TopicSet>
+		<tns1:RuleEngine wstop:topic="false">
+			<CellMotionDetector>
+				<Motion wstop:topic="true">
+					<tt:MessageDescription IsProperty="true">
+						<tt:Source>
+							<tt:SimpleItemDescription Name="VideoSourceConfigurationToken" Type="tt:ReferenceToken"/>
+							<tt:SimpleItemDescription Name="Rule" Type="xs:string"/>
+						</tt:Source>
+						<tt:Data><tt:SimpleItemDescription Name="IsMotion" Type="xs:boolean"/></tt:Data>
+					</tt:MessageDescription>
+				</Motion>
+			</CellMotionDetector>
+		</tns1:RuleEngine>
+		<tns1:Device>
+			<tnsaxis:IO xmlns:tnsaxis="http://www.axis.com/2009/event/topics">
+				<Port wstop:topic="true"/>
+			</tnsaxis:IO>
+		</tns1:Device>
+	</wstop:TopicSet>
+</tev:GetEventPropertiesResponse>`
+
+func TestTopicSet(t *testing.T) {
+	var reply GetEventPropertiesResponse
+	if err := xml.Unmarshal([]byte(eventProperties), &reply); err != nil {
+		t.Fatal(err)
+	}
+	topics := reply.TopicSet.LeafTopics()
+	want := []string{"tns1:RuleEngine/CellMotionDetector/Motion", "tns1:Device/tnsaxis:IO/Port"}
+	if len(topics) != len(want) || topics[0] != want[0] || topics[1] != want[1] {
+		t.Fatalf("unexpected topics %q", topics)
+	}
+
+	motion := reply.TopicSet.Find("tns1:RuleEngine/CellMotionDetector/Motion")
+	if motion == nil || !motion.IsTopic || motion.Parent.IsTopic {
+		t.Fatalf("unexpected node %+v", motion)
+	}
+	if motion.Parent.Parent.Namespace != TopicNamespaceONVIF {
+		t.Errorf("unexpected namespace %q", motion.Parent.Parent.Namespace)
+	}
+	desc := motion.MessageDescription
+	if desc == nil || desc.IsProperty == nil || !bool(*desc.IsProperty) {
+		t.Fatalf("unexpected description %+v", desc)
+	}
+	if len(desc.Source.SimpleItemDescription) != 2 || desc.Data.SimpleItemDescription[0].Name != "IsMotion" {
+		t.Errorf("unexpected items %+v %+v", desc.Source, desc.Data)
+	}
+	if port := reply.TopicSet.Find("tns1:Device/IO/Port"); port == nil || port.Parent.Namespace != "http://www.axis.com/2009/event/topics" {
+		t.Errorf("unexpected node %+v", port)
+	}
+}
@@ -4,12 +4,8 @@ import (
 	"encoding/json"
 	"io"
 	"log"
-	"path"
-	"regexp"
-	"strings"
 	"testing"
 
-	"github.com/beevik/etree"
 	"github.com/ritj/onvif"
 	"github.com/ritj/onvif/device"
 	discover "github.com/ritj/onvif/ws-discovery"
@@ -46,36 +42,15 @@ func runDiscovery(interfaceName string) {
 		log.Printf("error %s", err)
 		return
 	}
-	for _, j := range devices {
-		doc := etree.NewDocument()
-		if err := doc.ReadFromString(j); err != nil {
-			log.Printf("error %s", err)
-		} else {
-
-			endpoints := doc.Root().FindElements("./Body/ProbeMatches/ProbeMatch/XAddrs")
-			scopes := doc.Root().FindElements("./Body/ProbeMatches/ProbeMatch/Scopes")
-
-			flag := false
-
-			host := &Host{}
-
-			for _, xaddr := range endpoints {
-				xaddr := strings.Split(strings.Split(xaddr.Text(), " ")[0], "/")[2]
-				host.URL = xaddr
-			}
-			if flag {
-				break
-			}
-			for _, scope := range scopes {
-				re := regexp.MustCompile(`onvif:\/\/www\.onvif\.org\/name\/[A-Za-z0-9-]+`)
-				match := re.FindStringSubmatch(scope.Text())
-				host.Name = path.Base(match[0])
-			}
-
-			hosts = append(hosts, host)
-
+	for _, device := range devices {
+		host := &Host{}
+		if len(device.XAddrs) > 0 {
+			host.URL = device.XAddrs[0].Host
 		}
-
+		if names := device.ScopeValues(discover.ScopeName); len(names) > 0 {
+			host.Name = names[0]
+		}
+		hosts = append(hosts, host)
 	}
 
 	bys, _ := json.Marshal(hosts)
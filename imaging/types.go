@@ -5,15 +5,41 @@ import (
 	"github.com/ritj/onvif/xsd/onvif"
 )
 
+type Capabilities struct {
+	ImageStabilization xsd.Boolean `xml:"ImageStabilization,attr"`
+	Presets            xsd.Boolean `xml:"Presets,attr"`
+}
+
+// ImagingPreset is a predefined set of imaging settings of a video source.
+type ImagingPreset struct {
+	Token onvif.ReferenceToken `xml:"token,attr"`
+	Type  ImagingPresetType    `xml:"type,attr"`
+	Name  onvif.Name           `xml:"Name"`
+}
+
+// ImagingPresetType is the category of an ImagingPreset, e.g. Custom,
+// ClearWeather, Cloudy, Fog, Rain, Snowing, Snow, WDR, Shade, Night, Indoor,
+// Fluorescent, Incandescent, Sodium(Natrium), Sunrise(Horizon),
+// Sunset(Rear) or ExtremeHot.
+type ImagingPresetType xsd.String
+
 type GetServiceCapabilities struct {
 	XMLName string `xml:"timg:GetServiceCapabilities"`
 }
 
+type GetServiceCapabilitiesResponse struct {
+	Capabilities Capabilities
+}
+
 type GetImagingSettings struct {
 	XMLName          string               `xml:"timg:GetImagingSettings"`
 	VideoSourceToken onvif.ReferenceToken `xml:"timg:VideoSourceToken"`
 }
 
+type GetImagingSettingsResponse struct {
+	ImagingSettings onvif.ImagingSettings20
+}
+
 type SetImagingSettings struct {
 	XMLName          string                  `xml:"timg:SetImagingSettings"`
 	VideoSourceToken onvif.ReferenceToken    `xml:"timg:VideoSourceToken"`
@@ -21,44 +47,76 @@ type SetImagingSettings struct {
 	ForcePersistence xsd.Boolean             `xml:"timg:ForcePersistence"`
 }
 
+type SetImagingSettingsResponse struct {
+}
+
 type GetOptions struct {
 	XMLName          string               `xml:"timg:GetOptions"`
 	VideoSourceToken onvif.ReferenceToken `xml:"timg:VideoSourceToken"`
 }
 
+type GetOptionsResponse struct {
+	ImagingOptions onvif.ImagingOptions20
+}
+
 type Move struct {
 	XMLName          string               `xml:"timg:Move"`
 	VideoSourceToken onvif.ReferenceToken `xml:"timg:VideoSourceToken"`
 	Focus            onvif.FocusMove      `xml:"timg:Focus"`
 }
 
+type MoveResponse struct {
+}
+
 type GetMoveOptions struct {
 	XMLName          string               `xml:"timg:GetMoveOptions"`
 	VideoSourceToken onvif.ReferenceToken `xml:"timg:VideoSourceToken"`
 }
 
+type GetMoveOptionsResponse struct {
+	MoveOptions onvif.MoveOptions20
+}
+
 type Stop struct {
 	XMLName          string               `xml:"timg:Stop"`
 	VideoSourceToken onvif.ReferenceToken `xml:"timg:VideoSourceToken"`
 }
 
+type StopResponse struct {
+}
+
 type GetStatus struct {
 	XMLName          string               `xml:"timg:GetStatus"`
 	VideoSourceToken onvif.ReferenceToken `xml:"timg:VideoSourceToken"`
 }
 
+type GetStatusResponse struct {
+	Status onvif.ImagingStatus20
+}
+
 type GetPresets struct {
 	XMLName          string               `xml:"timg:GetPresets"`
 	VideoSourceToken onvif.ReferenceToken `xml:"timg:VideoSourceToken"`
 }
 
+type GetPresetsResponse struct {
+	Preset []ImagingPreset
+}
+
 type GetCurrentPreset struct {
 	XMLName          string               `xml:"timg:GetCurrentPreset"`
 	VideoSourceToken onvif.ReferenceToken `xml:"timg:VideoSourceToken"`
 }
 
+type GetCurrentPresetResponse struct {
+	Preset *ImagingPreset
+}
+
 type SetCurrentPreset struct {
 	XMLName          string               `xml:"timg:SetCurrentPreset"`
 	VideoSourceToken onvif.ReferenceToken `xml:"timg:VideoSourceToken"`
 	PresetToken      onvif.ReferenceToken `xml:"timg:PresetToken"`
 }
+
+type SetCurrentPresetResponse struct {
+}
@@ -0,0 +1,106 @@
+package imaging
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+const imagingSettings = `<timg:GetImagingSettingsResponse xmlns:timg="http://www.onvif.org/ver20/imaging/wsdl" xmlns:tt="http://www.onvif.org/ver10/schema">
+	<timg:ImagingSettings>
+		<tt:BacklightCompensation><tt:Mode>OFF</tt:Mode><tt:Level>10</tt:Level></tt:BacklightCompensation>
+		<tt:Brightness>50</tt:Brightness>
+		<tt:Exposure><tt:Mode>AUTO</tt:Mode><tt:MinGain>0</tt:MinGain><tt:MaxGain>100</tt:MaxGain></tt:Exposure>
+		<tt:Focus><tt:AutoFocusMode>AUTO</tt:AutoFocusMode><tt:NearLimit>0.5</tt:NearLimit></tt:Focus>
+		<tt:IrCutFilter>AUTO</tt:IrCutFilter>
+	</timg:ImagingSettings>
+</timg:GetImagingSettingsResponse>`
+
+const imagingOptions = `<timg:GetOptionsResponse xmlns:timg="http://www.onvif.org/ver20/imaging/wsdl" xmlns:tt="http://www.onvif.org/ver10/schema">
+	<timg:ImagingOptions>
+		<tt:Brightness><tt:Min>0</tt:Min><tt:Max>100</tt:Max></tt:Brightness>
+		<tt:Focus>
+			<tt:AutoFocusModes>AUTO</tt:AutoFocusModes>
+			<tt:AutoFocusModes>MANUAL</tt:AutoFocusModes>
+			<tt:NearLimit><tt:Min>0.1</tt:Min><tt:Max>3</tt:Max></tt:NearLimit>
+		</tt:Focus>
+		<tt:IrCutFilterModes>ON</tt:IrCutFilterModes>
+		<tt:IrCutFilterModes>OFF</tt:IrCutFilterModes>
+		<tt:IrCutFilterModes>AUTO</tt:IrCutFilterModes>
+		<tt:Extension>
+			<tt:ImageStabilization><tt:Mode>OFF</tt:Mode><tt:Mode>ON</tt:Mode></tt:ImageStabilization>
+			<tt:Extension>
+				<tt:IrCutFilterAutoAdjustment><tt:BoundaryType>ToOn</tt:BoundaryType><tt:BoundaryOffset>true</tt:BoundaryOffset></tt:IrCutFilterAutoAdjustment>
+			</tt:Extension>
+		</tt:Extension>
+	</timg:ImagingOptions>
+</timg:GetOptionsResponse>`
+
+const moveOptions = `<timg:GetMoveOptionsResponse xmlns:timg="http://www.onvif.org/ver20/imaging/wsdl" xmlns:tt="http://www.onvif.org/ver10/schema">
+	<timg:MoveOptions>
+		<tt:Absolute><tt:Position><tt:Min>0</tt:Min><tt:Max>1</tt:Max></tt:Position></tt:Absolute>
+		<tt:Continuous><tt:Speed><tt:Min>-1</tt:Min><tt:Max>1</tt:Max></tt:Speed></tt:Continuous>
+	</timg:MoveOptions>
+</timg:GetMoveOptionsResponse>`
+
+const status = `<timg:GetStatusResponse xmlns:timg="http://www.onvif.org/ver20/imaging/wsdl" xmlns:tt="http://www.onvif.org/ver10/schema">
+	<timg:Status>
+		<tt:FocusStatus20><tt:Position>0.25</tt:Position><tt:MoveStatus>MOVING</tt:MoveStatus><tt:Error>motor blocked</tt:Error></tt:FocusStatus20>
+	</timg:Status>
+</timg:GetStatusResponse>`
+
+func TestImagingSettings(t *testing.T) {
+	var reply GetImagingSettingsResponse
+	if err := xml.Unmarshal([]byte(imagingSettings), &reply); err != nil {
+		t.Fatal(err)
+	}
+	s := reply.ImagingSettings
+	if s.Brightness != 50 || s.BacklightCompensation == nil || s.BacklightCompensation.Level != 10 ||
+		s.Exposure == nil || s.Exposure.Mode != "AUTO" || s.Exposure.MaxGain != 100 ||
+		s.Focus == nil || s.Focus.NearLimit != 0.5 || s.IrCutFilter == nil || *s.IrCutFilter != "AUTO" {
+		t.Errorf("unexpected settings %+v", s)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	var reply GetOptionsResponse
+	if err := xml.Unmarshal([]byte(imagingOptions), &reply); err != nil {
+		t.Fatal(err)
+	}
+	o := reply.ImagingOptions
+	if o.Brightness == nil || o.Brightness.Max != 100 || len(o.IrCutFilterModes) != 3 {
+		t.Errorf("unexpected options %+v", o)
+	}
+	if o.Focus == nil || len(o.Focus.AutoFocusModes) != 2 || o.Focus.NearLimit == nil || o.Focus.NearLimit.Min != 0.1 {
+		t.Errorf("unexpected focus options %+v", o.Focus)
+	}
+	if o.Extension == nil || o.Extension.ImageStabilization == nil || len(o.Extension.ImageStabilization.Mode) != 2 ||
+		o.Extension.Extension == nil || o.Extension.Extension.IrCutFilterAutoAdjustment == nil {
+		t.Fatalf("unexpected extension %+v", o.Extension)
+	}
+	if adjust := o.Extension.Extension.IrCutFilterAutoAdjustment; len(adjust.BoundaryType) != 1 || adjust.BoundaryType[0] != "ToOn" ||
+		adjust.BoundaryOffset == nil || !bool(*adjust.BoundaryOffset) {
+		t.Errorf("unexpected IR cut filter adjustment %+v", adjust)
+	}
+}
+
+func TestMoveOptions(t *testing.T) {
+	var reply GetMoveOptionsResponse
+	if err := xml.Unmarshal([]byte(moveOptions), &reply); err != nil {
+		t.Fatal(err)
+	}
+	o := reply.MoveOptions
+	if o.Absolute == nil || o.Absolute.Position.Max != 1 || o.Relative != nil || o.Continuous == nil || o.Continuous.Speed.Min != -1 {
+		t.Errorf("unexpected move options %+v", o)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	var reply GetStatusResponse
+	if err := xml.Unmarshal([]byte(status), &reply); err != nil {
+		t.Fatal(err)
+	}
+	f := reply.Status.FocusStatus20
+	if f == nil || f.Position != 0.25 || f.MoveStatus.Status != "MOVING" || f.Error != "motor blocked" {
+		t.Errorf("unexpected focus status %+v", f)
+	}
+}
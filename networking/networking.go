@@ -2,6 +2,7 @@ package networking
 
 import (
 	"bytes"
+	"context"
 	"net/http"
 
 	"github.com/juju/errors"
@@ -9,7 +10,17 @@ import (
 
 // SendSoap send soap message
 func SendSoap(httpClient *http.Client, endpoint, message string) (*http.Response, error) {
-	resp, err := httpClient.Post(endpoint, "application/soap+xml; charset=utf-8", bytes.NewBufferString(message))
+	return SendSoapContext(context.Background(), httpClient, endpoint, message)
+}
+
+// SendSoapContext sends a soap message, canceled when ctx is done.
+func SendSoapContext(ctx context.Context, httpClient *http.Client, endpoint, message string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBufferString(message))
+	if err != nil {
+		return nil, errors.Annotate(err, "Post")
+	}
+	req.Header.Set("Content-Type", "application/soap+xml; charset=utf-8")
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return resp, errors.Annotate(err, "Post")
 	}
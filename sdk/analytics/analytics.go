@@ -0,0 +1,4 @@
+package analytics
+
+//go:generate go run github.com/ritj/onvif/sdk/codegen -calls analytics
+//go:generate go run github.com/ritj/onvif/sdk/codegen -client analytics
@@ -0,0 +1,29 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package analytics
+
+import (
+	"github.com/ritj/onvif/analytics"
+	"github.com/ritj/onvif/sdk"
+)
+
+// The Call_ functions forward the request to dev.CallMethod() then parse the payload of the reply.
+// They are instances of sdk.Call, kept under the names of the former generated wrappers.
+var (
+	Call_CreateAnalyticsModules       = sdk.Call[analytics.CreateAnalyticsModules, analytics.CreateAnalyticsModulesResponse]
+	Call_CreateRules                  = sdk.Call[analytics.CreateRules, analytics.CreateRulesResponse]
+	Call_DeleteAnalyticsModules       = sdk.Call[analytics.DeleteAnalyticsModules, analytics.DeleteAnalyticsModulesResponse]
+	Call_DeleteRules                  = sdk.Call[analytics.DeleteRules, analytics.DeleteRulesResponse]
+	Call_GetAnalyticsModuleOptions    = sdk.Call[analytics.GetAnalyticsModuleOptions, analytics.GetAnalyticsModuleOptionsResponse]
+	Call_GetAnalyticsModules          = sdk.Call[analytics.GetAnalyticsModules, analytics.GetAnalyticsModulesResponse]
+	Call_GetRuleOptions               = sdk.Call[analytics.GetRuleOptions, analytics.GetRuleOptionsResponse]
+	Call_GetRules                     = sdk.Call[analytics.GetRules, analytics.GetRulesResponse]
+	Call_GetServiceCapabilities       = sdk.Call[analytics.GetServiceCapabilities, analytics.GetServiceCapabilitiesResponse]
+	Call_GetSupportedAnalyticsModules = sdk.Call[analytics.GetSupportedAnalyticsModules, analytics.GetSupportedAnalyticsModulesResponse]
+	Call_GetSupportedMetadata         = sdk.Call[analytics.GetSupportedMetadata, analytics.GetSupportedMetadataResponse]
+	Call_GetSupportedRules            = sdk.Call[analytics.GetSupportedRules, analytics.GetSupportedRulesResponse]
+	Call_ModifyAnalyticsModules       = sdk.Call[analytics.ModifyAnalyticsModules, analytics.ModifyAnalyticsModulesResponse]
+	Call_ModifyRules                  = sdk.Call[analytics.ModifyRules, analytics.ModifyRulesResponse]
+)
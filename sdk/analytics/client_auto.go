@@ -0,0 +1,125 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package analytics
+
+import (
+	"context"
+	goonvif "github.com/ritj/onvif"
+	"github.com/ritj/onvif/analytics"
+	"github.com/ritj/onvif/xsd"
+	"github.com/ritj/onvif/xsd/onvif"
+)
+
+// Client exposes the operations of the analytics service of a device.
+// It can be replaced by a fake in the tests of the code that depends on it.
+type Client interface {
+	CreateAnalyticsModules(ctx context.Context, configurationToken onvif.ReferenceToken, analyticsModule []onvif.Config) error
+	CreateRules(ctx context.Context, configurationToken onvif.ReferenceToken, rule []onvif.Config) error
+	DeleteAnalyticsModules(ctx context.Context, configurationToken onvif.ReferenceToken, analyticsModuleName []xsd.String) error
+	DeleteRules(ctx context.Context, configurationToken onvif.ReferenceToken, ruleName []xsd.String) error
+	GetAnalyticsModuleOptions(ctx context.Context, typ xsd.QName, configurationToken onvif.ReferenceToken) ([]analytics.AnalyticsModuleConfigOptions, error)
+	GetAnalyticsModules(ctx context.Context, configurationToken onvif.ReferenceToken) ([]onvif.Config, error)
+	GetRuleOptions(ctx context.Context, ruleType xsd.QName, configurationToken onvif.ReferenceToken) ([]analytics.ConfigOptions, error)
+	GetRules(ctx context.Context, configurationToken onvif.ReferenceToken) ([]onvif.Config, error)
+	GetServiceCapabilities(ctx context.Context) (analytics.Capabilities, error)
+	GetSupportedAnalyticsModules(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.SupportedAnalyticsModules, error)
+	GetSupportedMetadata(ctx context.Context, typ xsd.QName) ([]analytics.MetadataInfo, error)
+	GetSupportedRules(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.SupportedRules, error)
+	ModifyAnalyticsModules(ctx context.Context, configurationToken onvif.ReferenceToken, analyticsModule []onvif.Config) error
+	ModifyRules(ctx context.Context, configurationToken onvif.ReferenceToken, rule []onvif.Config) error
+}
+
+// NewClient returns a Client bound to dev.
+func NewClient(dev *goonvif.Device) Client {
+	return &client{dev: dev}
+}
+
+type client struct {
+	dev *goonvif.Device
+}
+
+// CreateAnalyticsModules calls the CreateAnalyticsModules operation.
+func (c *client) CreateAnalyticsModules(ctx context.Context, configurationToken onvif.ReferenceToken, analyticsModule []onvif.Config) error {
+	_, err := Call_CreateAnalyticsModules(ctx, c.dev, analytics.CreateAnalyticsModules{ConfigurationToken: configurationToken, AnalyticsModule: analyticsModule})
+	return err
+}
+
+// CreateRules calls the CreateRules operation.
+func (c *client) CreateRules(ctx context.Context, configurationToken onvif.ReferenceToken, rule []onvif.Config) error {
+	_, err := Call_CreateRules(ctx, c.dev, analytics.CreateRules{ConfigurationToken: configurationToken, Rule: rule})
+	return err
+}
+
+// DeleteAnalyticsModules calls the DeleteAnalyticsModules operation.
+func (c *client) DeleteAnalyticsModules(ctx context.Context, configurationToken onvif.ReferenceToken, analyticsModuleName []xsd.String) error {
+	_, err := Call_DeleteAnalyticsModules(ctx, c.dev, analytics.DeleteAnalyticsModules{ConfigurationToken: configurationToken, AnalyticsModuleName: analyticsModuleName})
+	return err
+}
+
+// DeleteRules calls the DeleteRules operation.
+func (c *client) DeleteRules(ctx context.Context, configurationToken onvif.ReferenceToken, ruleName []xsd.String) error {
+	_, err := Call_DeleteRules(ctx, c.dev, analytics.DeleteRules{ConfigurationToken: configurationToken, RuleName: ruleName})
+	return err
+}
+
+// GetAnalyticsModuleOptions calls the GetAnalyticsModuleOptions operation.
+func (c *client) GetAnalyticsModuleOptions(ctx context.Context, typ xsd.QName, configurationToken onvif.ReferenceToken) ([]analytics.AnalyticsModuleConfigOptions, error) {
+	reply, err := Call_GetAnalyticsModuleOptions(ctx, c.dev, analytics.GetAnalyticsModuleOptions{Type: typ, ConfigurationToken: configurationToken})
+	return reply.Options, err
+}
+
+// GetAnalyticsModules calls the GetAnalyticsModules operation.
+func (c *client) GetAnalyticsModules(ctx context.Context, configurationToken onvif.ReferenceToken) ([]onvif.Config, error) {
+	reply, err := Call_GetAnalyticsModules(ctx, c.dev, analytics.GetAnalyticsModules{ConfigurationToken: configurationToken})
+	return reply.AnalyticsModule, err
+}
+
+// GetRuleOptions calls the GetRuleOptions operation.
+func (c *client) GetRuleOptions(ctx context.Context, ruleType xsd.QName, configurationToken onvif.ReferenceToken) ([]analytics.ConfigOptions, error) {
+	reply, err := Call_GetRuleOptions(ctx, c.dev, analytics.GetRuleOptions{RuleType: ruleType, ConfigurationToken: configurationToken})
+	return reply.RuleOptions, err
+}
+
+// GetRules calls the GetRules operation.
+func (c *client) GetRules(ctx context.Context, configurationToken onvif.ReferenceToken) ([]onvif.Config, error) {
+	reply, err := Call_GetRules(ctx, c.dev, analytics.GetRules{ConfigurationToken: configurationToken})
+	return reply.Rule, err
+}
+
+// GetServiceCapabilities calls the GetServiceCapabilities operation.
+func (c *client) GetServiceCapabilities(ctx context.Context) (analytics.Capabilities, error) {
+	reply, err := Call_GetServiceCapabilities(ctx, c.dev, analytics.GetServiceCapabilities{})
+	return reply.Capabilities, err
+}
+
+// GetSupportedAnalyticsModules calls the GetSupportedAnalyticsModules operation.
+func (c *client) GetSupportedAnalyticsModules(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.SupportedAnalyticsModules, error) {
+	reply, err := Call_GetSupportedAnalyticsModules(ctx, c.dev, analytics.GetSupportedAnalyticsModules{ConfigurationToken: configurationToken})
+	return reply.SupportedAnalyticsModules, err
+}
+
+// GetSupportedMetadata calls the GetSupportedMetadata operation.
+func (c *client) GetSupportedMetadata(ctx context.Context, typ xsd.QName) ([]analytics.MetadataInfo, error) {
+	reply, err := Call_GetSupportedMetadata(ctx, c.dev, analytics.GetSupportedMetadata{Type: typ})
+	return reply.AnalyticsModule, err
+}
+
+// GetSupportedRules calls the GetSupportedRules operation.
+func (c *client) GetSupportedRules(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.SupportedRules, error) {
+	reply, err := Call_GetSupportedRules(ctx, c.dev, analytics.GetSupportedRules{ConfigurationToken: configurationToken})
+	return reply.SupportedRules, err
+}
+
+// ModifyAnalyticsModules calls the ModifyAnalyticsModules operation.
+func (c *client) ModifyAnalyticsModules(ctx context.Context, configurationToken onvif.ReferenceToken, analyticsModule []onvif.Config) error {
+	_, err := Call_ModifyAnalyticsModules(ctx, c.dev, analytics.ModifyAnalyticsModules{ConfigurationToken: configurationToken, AnalyticsModule: analyticsModule})
+	return err
+}
+
+// ModifyRules calls the ModifyRules operation.
+func (c *client) ModifyRules(ctx context.Context, configurationToken onvif.ReferenceToken, rule []onvif.Config) error {
+	_, err := Call_ModifyRules(ctx, c.dev, analytics.ModifyRules{ConfigurationToken: configurationToken, Rule: rule})
+	return err
+}
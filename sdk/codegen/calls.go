@@ -0,0 +1,78 @@
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// callPair relates a request type to the type of its response.
+type callPair struct {
+	Request  string
+	Response string
+}
+
+// discoverCalls pairs every request of the types package, a struct with an
+// XMLName, with the struct of the same name suffixed by Response.
+func discoverCalls(tp *typesPackage) []callPair {
+	var pairs []callPair
+	for name, st := range tp.structs {
+		if _, ok := tp.structs[name+"Response"]; !ok {
+			continue
+		}
+		for _, f := range st.Fields.List {
+			if len(f.Names) == 1 && f.Names[0].Name == "XMLName" {
+				pairs = append(pairs, callPair{Request: name, Response: name + "Response"})
+				break
+			}
+		}
+	}
+	return pairs
+}
+
+// handWritten are the Call_ wrappers written by hand, by package and request,
+// as they do more than sdk.Call.
+var handWritten = map[string]bool{
+	// It fixes the endpoints of the devices that advertise localhost.
+	"device.GetCapabilities": true,
+}
+
+// generateCalls writes calls_auto.go, which declares the Call_ wrappers of
+// the operations as instances of sdk.Call, but the handWritten ones.
+func generateCalls(pkg, structPkg string, pairs []callPair) ([]byte, error) {
+	var generated []callPair
+	for _, p := range pairs {
+		if !handWritten[pkg+"."+p.Request] {
+			generated = append(generated, p)
+		}
+	}
+	pairs = generated
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Request < pairs[j].Request })
+	qualifier := structPkg[strings.LastIndexByte(structPkg, '/')+1:]
+	if tp, err := parseTypesPackage(modulePath + "/" + structPkg); err == nil {
+		qualifier = tp.name
+	}
+
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated : DO NOT EDIT.\n// Copyright (c) 2022 Jean-Francois SMIGIELSKI\n// Distributed under the MIT License\n\n")
+	fmt.Fprintf(&file, "package %s\n\nimport (\n", pkg)
+	if qualifier != structPkg[strings.LastIndexByte(structPkg, '/')+1:] {
+		fmt.Fprintf(&file, "\t%s %q\n", qualifier, modulePath+"/"+structPkg)
+	} else {
+		fmt.Fprintf(&file, "\t%q\n", modulePath+"/"+structPkg)
+	}
+	fmt.Fprintf(&file, "\t%q\n)\n\n", modulePath+"/sdk")
+	fmt.Fprintf(&file, "// The Call_ functions forward the request to dev.CallMethod() then parse the payload of the reply.\n")
+	fmt.Fprintf(&file, "// They are instances of sdk.Call, kept under the names of the former generated wrappers.\n")
+	fmt.Fprintf(&file, "var (\n")
+	for _, p := range pairs {
+		fmt.Fprintf(&file, "\tCall_%s = sdk.Call[%s.%s, %s.%s]\n", p.Request, qualifier, p.Request, qualifier, p.Response)
+	}
+	fmt.Fprintf(&file, ")\n")
+	return format.Source(file.Bytes())
+}
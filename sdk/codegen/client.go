@@ -0,0 +1,302 @@
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+)
+
+const modulePath = "github.com/ritj/onvif"
+
+// clientMethod is a method of the generated Client, built from the request
+// and response types of an operation.
+type clientMethod struct {
+	Name    string
+	Params  []clientField
+	Results []clientField
+}
+
+type clientField struct {
+	Name string
+	Type string
+}
+
+// Signature renders the parameters and results of the method.
+func (m clientMethod) Signature(pkg string) string {
+	params := []string{"ctx context.Context"}
+	for _, p := range m.Params {
+		params = append(params, p.Name+" "+p.Type)
+	}
+	switch len(m.Results) {
+	case 0:
+		return fmt.Sprintf("(%s) error", strings.Join(params, ", "))
+	case 1:
+		return fmt.Sprintf("(%s) (%s, error)", strings.Join(params, ", "), m.Results[0].Type)
+	default:
+		return fmt.Sprintf("(%s) (%s.%sResponse, error)", strings.Join(params, ", "), pkg, m.Name)
+	}
+}
+
+// typesPackage is the parsed package holding the requests and responses.
+type typesPackage struct {
+	name    string
+	structs map[string]*ast.StructType
+	imports map[string]string
+	used    map[string]string
+	fset    *token.FileSet
+}
+
+func parseTypesPackage(importPath string) (*typesPackage, error) {
+	bp, err := build.Default.Import(importPath, ".", build.FindOnly)
+	if err != nil {
+		return nil, errors.Annotatef(err, "locate %s", importPath)
+	}
+	tp := &typesPackage{
+		structs: map[string]*ast.StructType{},
+		imports: map[string]string{},
+		used:    map[string]string{},
+		fset:    token.NewFileSet(),
+	}
+	pkgs, err := parser.ParseDir(tp.fset, bp.Dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parse %s", importPath)
+	}
+	for name, pkg := range pkgs {
+		tp.name = name
+		for _, f := range pkg.Files {
+			for _, imp := range f.Imports {
+				p, _ := strconv.Unquote(imp.Path.Value)
+				n := p[strings.LastIndexByte(p, '/')+1:]
+				if imp.Name != nil {
+					n = imp.Name.Name
+				}
+				tp.imports[n] = p
+			}
+			ast.Inspect(f, func(n ast.Node) bool {
+				if ts, ok := n.(*ast.TypeSpec); ok {
+					if st, ok := ts.Type.(*ast.StructType); ok {
+						tp.structs[ts.Name.Name] = st
+					}
+				}
+				return true
+			})
+		}
+	}
+	return tp, nil
+}
+
+// qualify renders a field type as seen from another package.
+func (tp *typesPackage) qualify(expr ast.Expr) string {
+	expr = qualifyExpr(expr, tp)
+	var b bytes.Buffer
+	printer.Fprint(&b, tp.fset, expr)
+	return b.String()
+}
+
+func qualifyExpr(expr ast.Expr, tp *typesPackage) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if e.IsExported() {
+			tp.used[tp.name] = ""
+			return &ast.SelectorExpr{X: ast.NewIdent(tp.name), Sel: ast.NewIdent(e.Name)}
+		}
+		return e
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			tp.used[x.Name] = tp.imports[x.Name]
+		}
+		return e
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualifyExpr(e.X, tp)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: e.Len, Elt: qualifyExpr(e.Elt, tp)}
+	default:
+		return e
+	}
+}
+
+// fields lists the exported fields of a struct but its XMLName.
+func (tp *typesPackage) fields(name string) []clientField {
+	st := tp.structs[name]
+	if st == nil {
+		return nil
+	}
+	var out []clientField
+	for _, f := range st.Fields.List {
+		names := f.Names
+		if len(names) == 0 {
+			// Embedded field, named after its type.
+			t := f.Type
+			if s, ok := t.(*ast.StarExpr); ok {
+				t = s.X
+			}
+			switch t := t.(type) {
+			case *ast.Ident:
+				names = []*ast.Ident{t}
+			case *ast.SelectorExpr:
+				names = []*ast.Ident{t.Sel}
+			}
+		}
+		for _, n := range names {
+			if !n.IsExported() || n.Name == "XMLName" {
+				continue
+			}
+			out = append(out, clientField{Name: n.Name, Type: tp.qualify(f.Type)})
+		}
+	}
+	return out
+}
+
+// paramName turns a field name into the name of a method parameter.
+func paramName(field string) string {
+	r := []rune(field)
+	i := 0
+	for i < len(r) && unicode.IsUpper(r[i]) && (i == 0 || i+1 >= len(r) || unicode.IsUpper(r[i+1])) {
+		r[i] = unicode.ToLower(r[i])
+		i++
+	}
+	name := string(r)
+	switch {
+	case name == "type":
+		name = "typ"
+	case token.IsKeyword(name) || types.Universe.Lookup(name) != nil:
+		name += "Value"
+	case name == "ctx" || name == "c" || name == "reply" || name == "err":
+		name += "Arg"
+	}
+	return name
+}
+
+// listCalls returns the operations that have a Call_ wrapper of the form
+// Call_X(ctx, dev, request) in dir.
+func listCalls(dir string) ([]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != "client_auto.go"
+	}, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, errors.Annotate(err, "parse")
+	}
+	var ops []string
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, d := range f.Decls {
+				switch d := d.(type) {
+				case *ast.FuncDecl:
+					// The wrappers that take more than (ctx, dev, request), e.g. the
+					// address of a subscription, are left out of the Client.
+					if d.Recv == nil && strings.HasPrefix(d.Name.Name, "Call_") && d.Type.Params.NumFields() == 3 {
+						ops = append(ops, strings.TrimPrefix(d.Name.Name, "Call_"))
+					}
+				case *ast.GenDecl:
+					for _, spec := range d.Specs {
+						if vs, ok := spec.(*ast.ValueSpec); ok {
+							for _, n := range vs.Names {
+								if strings.HasPrefix(n.Name, "Call_") {
+									ops = append(ops, strings.TrimPrefix(n.Name, "Call_"))
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(ops)
+	return ops, nil
+}
+
+// generateClient writes client_auto.go, a Client interface and its
+// implementation over the Call_ wrappers of the package in the current directory.
+func generateClient(pkg, structPkg string) ([]byte, error) {
+	ops, err := listCalls(".")
+	if err != nil {
+		return nil, err
+	}
+	tp, err := parseTypesPackage(modulePath + "/" + structPkg)
+	if err != nil {
+		return nil, err
+	}
+
+	var methods []clientMethod
+	for _, op := range ops {
+		m := clientMethod{Name: op, Results: tp.fields(op + "Response")}
+		for _, f := range tp.fields(op) {
+			m.Params = append(m.Params, clientField{Name: paramName(f.Name), Type: f.Type})
+		}
+		methods = append(methods, m)
+	}
+	tp.used[tp.name] = ""
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// Client exposes the operations of the %s service of a device.\n", tp.name)
+	fmt.Fprintf(&body, "// It can be replaced by a fake in the tests of the code that depends on it.\ntype Client interface {\n")
+	for _, m := range methods {
+		fmt.Fprintf(&body, "\t%s%s\n", m.Name, m.Signature(tp.name))
+	}
+	fmt.Fprintf(&body, "}\n\n")
+	fmt.Fprintf(&body, "// NewClient returns a Client bound to dev.\nfunc NewClient(dev *goonvif.Device) Client {\n\treturn &client{dev: dev}\n}\n\n")
+	fmt.Fprintf(&body, "type client struct {\n\tdev *goonvif.Device\n}\n\n")
+	for _, m := range methods {
+		var args []string
+		for i, p := range m.Params {
+			args = append(args, fmt.Sprintf("%s: %s", tp.fields(m.Name)[i].Name, p.Name))
+		}
+		fmt.Fprintf(&body, "// %s calls the %s operation.\n", m.Name, m.Name)
+		fmt.Fprintf(&body, "func (c *client) %s%s {\n", m.Name, m.Signature(tp.name))
+		call := fmt.Sprintf("Call_%s(ctx, c.dev, %s.%s{%s})", m.Name, tp.name, m.Name, strings.Join(args, ", "))
+		switch len(m.Results) {
+		case 0:
+			fmt.Fprintf(&body, "\t_, err := %s\n\treturn err\n", call)
+		case 1:
+			fmt.Fprintf(&body, "\treply, err := %s\n\treturn reply.%s, err\n", call, m.Results[0].Name)
+		default:
+			fmt.Fprintf(&body, "\treturn %s\n", call)
+		}
+		fmt.Fprintf(&body, "}\n\n")
+	}
+
+	imports := []string{"context"}
+	for name, p := range tp.used {
+		if name == tp.name {
+			p = modulePath + "/" + structPkg
+		}
+		// The fields of the responses returned whole are qualified but not written.
+		if !strings.Contains(body.String(), name+".") {
+			continue
+		}
+		imports = append(imports, p)
+	}
+	sort.Strings(imports)
+
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated : DO NOT EDIT.\n// Copyright (c) 2022 Jean-Francois SMIGIELSKI\n// Distributed under the MIT License\n\n")
+	fmt.Fprintf(&file, "package %s\n\nimport (\n\tgoonvif %q\n", pkg, modulePath)
+	for i, p := range imports {
+		if i > 0 && p == imports[i-1] {
+			continue
+		}
+		fmt.Fprintf(&file, "\t%q\n", p)
+	}
+	fmt.Fprintf(&file, ")\n\n")
+	file.Write(body.Bytes())
+	return format.Source(file.Bytes())
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParamName(t *testing.T) {
+	for in, want := range map[string]string{
+		"ProfileToken":     "profileToken",
+		"OSDToken":         "osdToken",
+		"PTZConfiguration": "ptzConfiguration",
+		"TTL":              "ttl",
+		"Type":             "typ",
+		"Any":              "anyValue",
+		"Range":            "rangeValue",
+	} {
+		if got := paramName(in); got != want {
+			t.Errorf("paramName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerateCallsHandWritten(t *testing.T) {
+	src, err := generateCalls("device", "device", []callPair{
+		{Request: "GetCapabilities", Response: "GetCapabilitiesResponse"},
+		{Request: "GetHostname", Response: "GetHostnameResponse"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(src); strings.Contains(s, "Call_GetCapabilities") || !strings.Contains(s, "Call_GetHostname") {
+		t.Errorf("unexpected calls\n%s", s)
+	}
+}
@@ -0,0 +1,516 @@
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+)
+
+// builtins maps the XML Schema datatypes onto the types of the xsd package.
+var builtins = map[string]string{
+	"anyType":            "xsd.AnyType",
+	"anySimpleType":      "xsd.AnySimpleType",
+	"string":             "xsd.String",
+	"normalizedString":   "xsd.NormalizedString",
+	"token":              "xsd.Token",
+	"language":           "xsd.Language",
+	"Name":               "xsd.Name",
+	"NCName":             "xsd.NCName",
+	"NMTOKEN":            "xsd.NMTOKEN",
+	"ID":                 "xsd.ID",
+	"IDREF":              "xsd.IDREF",
+	"boolean":            "xsd.Boolean",
+	"float":              "xsd.Float",
+	"double":             "xsd.Double",
+	"decimal":            "xsd.Decimal",
+	"duration":           "xsd.Duration",
+	"dateTime":           "xsd.DateTime",
+	"time":               "xsd.Time",
+	"date":               "xsd.Date",
+	"hexBinary":          "xsd.HexBinary",
+	"base64Binary":       "xsd.Base64Binary",
+	"anyURI":             "xsd.AnyURI",
+	"QName":              "xsd.QName",
+	"integer":            "xsd.Integer",
+	"nonPositiveInteger": "xsd.NonPositiveInteger",
+	"negativeInteger":    "xsd.NegativeInteger",
+	"long":               "xsd.Long",
+	"int":                "xsd.Int",
+	"short":              "xsd.Short",
+	"byte":               "xsd.Byte",
+	"nonNegativeInteger": "xsd.NonNegativeInteger",
+	"unsignedLong":       "xsd.UnsignedLong",
+	"unsignedInt":        "xsd.UnsignedInt",
+	"unsignedShort":      "xsd.UnsignedShort",
+	"unsignedByte":       "xsd.UnsignedByte",
+	"positiveInteger":    "xsd.PositiveInteger",
+}
+
+const xsdImportPath = "github.com/ritj/onvif/xsd"
+
+// goPackage is an existing Go package that holds the types of an XML namespace
+// the compiler does not generate itself.
+type goPackage struct {
+	Path  string
+	Name  string
+	types map[string]bool
+}
+
+// loadGoPackage lists the type declarations of the package at importPath.
+func loadGoPackage(importPath string) (*goPackage, error) {
+	bp, err := build.Default.Import(importPath, ".", build.FindOnly)
+	if err != nil {
+		return nil, errors.Annotatef(err, "locate %s", importPath)
+	}
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, bp.Dir, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parse %s", importPath)
+	}
+	gp := &goPackage{Path: importPath, Name: path.Base(importPath), types: map[string]bool{}}
+	for name, pkg := range pkgs {
+		if strings.HasSuffix(name, "_test") {
+			continue
+		}
+		gp.Name = name
+		for _, f := range pkg.Files {
+			for _, d := range f.Decls {
+				if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+					for _, s := range gd.Specs {
+						gp.types[s.(*ast.TypeSpec).Name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return gp, nil
+}
+
+// compiler turns the schemas of a WSDL document into Go declarations.
+type compiler struct {
+	defs      *wsdlDefinitions
+	schemas   []*xsdSchema
+	tns       string
+	prefix    string
+	externals map[string]*goPackage
+
+	elements     map[string]*xsdElement
+	complexTypes map[string]*xsdComplex
+	simpleTypes  map[string]*xsdSimpleType
+	schemaOf     map[interface{}]*xsdSchema
+
+	requests map[string]bool
+	emitted  map[string]bool
+	imports  map[string]string
+	out      bytes.Buffer
+}
+
+func newCompiler(defs *wsdlDefinitions, schemas []*xsdSchema, externals map[string]*goPackage) (*compiler, error) {
+	c := &compiler{
+		defs:         defs,
+		schemas:      schemas,
+		tns:          defs.TargetNamespace,
+		externals:    externals,
+		elements:     map[string]*xsdElement{},
+		complexTypes: map[string]*xsdComplex{},
+		simpleTypes:  map[string]*xsdSimpleType{},
+		schemaOf:     map[interface{}]*xsdSchema{},
+		requests:     map[string]bool{},
+		emitted:      map[string]bool{},
+		imports:      map[string]string{},
+	}
+	root := namespaces{}
+	root.collect(defs.Attrs)
+	for p, uri := range root {
+		if uri == c.tns && p != "" {
+			c.prefix = p
+		}
+	}
+	if c.prefix == "" {
+		return nil, errors.Errorf("no prefix declared for the target namespace %s", c.tns)
+	}
+	for _, s := range schemas {
+		if s.TargetNamespace != c.tns {
+			continue
+		}
+		for _, e := range s.Elements {
+			c.elements[e.Name] = e
+			c.schemaOf[e] = s
+		}
+		for _, t := range s.ComplexTypes {
+			c.complexTypes[t.Name] = t
+			c.schemaOf[t] = s
+		}
+		for _, t := range s.SimpleTypes {
+			c.simpleTypes[t.Name] = t
+			c.schemaOf[t] = s
+		}
+	}
+	for _, op := range c.operations() {
+		c.requests[op.Request] = true
+	}
+	return c, nil
+}
+
+// operation binds a WSDL operation to its request and response elements.
+type operation struct {
+	Name          string
+	Request       string
+	Response      string
+	Documentation string
+}
+
+// operations lists the operations of every portType whose input and output
+// messages are elements of the target namespace.
+func (c *compiler) operations() []operation {
+	ns := namespaces{}
+	ns.collect(c.defs.Attrs)
+	messages := map[string]string{}
+	for _, m := range c.defs.Messages {
+		if len(m.Parts) == 1 {
+			messages[m.Name] = m.Parts[0].Element
+		}
+	}
+	element := func(msg string) (string, bool) {
+		el := ns.resolve(messages[ns.resolve(msg).Local])
+		return el.Local, el.Space == c.tns && el.Local != ""
+	}
+	var ops []operation
+	for _, pt := range c.defs.PortTypes {
+		for _, o := range pt.Operations {
+			req, okReq := element(o.Input.Message)
+			rep, okRep := element(o.Output.Message)
+			if !okReq || !okRep {
+				log.Printf("skipping %s: messages outside %s", o.Name, c.tns)
+				continue
+			}
+			ops = append(ops, operation{Name: o.Name, Request: req, Response: rep, Documentation: o.Documentation})
+		}
+	}
+	return ops
+}
+
+// compileTypes emits a Go source file declaring every element and type of the
+// target namespace.
+func (c *compiler) compileTypes(pkg string) ([]byte, error) {
+	var names []string
+	for _, s := range c.schemas {
+		if s.TargetNamespace != c.tns {
+			continue
+		}
+		for _, e := range s.Elements {
+			names = append(names, "e:"+e.Name)
+		}
+		for _, t := range s.ComplexTypes {
+			names = append(names, "c:"+t.Name)
+		}
+		for _, t := range s.SimpleTypes {
+			names = append(names, "s:"+t.Name)
+		}
+	}
+	for _, n := range names {
+		switch kind, name := n[:1], n[2:]; kind {
+		case "e":
+			c.emitElement(c.elements[name])
+		case "c":
+			c.emitComplex(goName(name), c.complexTypes[name], c.schemaOf[c.complexTypes[name]].ns, false)
+		case "s":
+			c.emitSimple(goName(name), c.simpleTypes[name], c.schemaOf[c.simpleTypes[name]].ns)
+		}
+	}
+
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated : DO NOT EDIT.\n// Copyright (c) 2022 Jean-Francois SMIGIELSKI\n// Distributed under the MIT License\n\n")
+	fmt.Fprintf(&file, "package %s\n\n", pkg)
+	if len(c.imports) > 0 {
+		var paths []string
+		for p := range c.imports {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		file.WriteString("import (\n")
+		for _, p := range paths {
+			fmt.Fprintf(&file, "\t%q\n", p)
+		}
+		file.WriteString(")\n\n")
+	}
+	file.Write(c.out.Bytes())
+	return format.Source(file.Bytes())
+}
+
+// emitElement declares a top-level element. Elements typed by a named type
+// of the same name need no declaration of their own.
+func (c *compiler) emitElement(e *xsdElement) {
+	name := goName(e.Name)
+	if c.complexTypes[e.Name] != nil && e.ComplexType == nil {
+		return
+	}
+	if _, clash := c.complexTypes[e.Name]; clash {
+		name += "Element"
+	}
+	ns := c.schemaOf[e].ns
+	switch {
+	case e.ComplexType != nil:
+		c.emitComplex(name, e.ComplexType, ns, c.requests[e.Name])
+	case e.SimpleType != nil:
+		c.emitSimple(name, e.SimpleType, ns)
+	case e.Type != "" && c.requests[e.Name]:
+		// A request must carry its own element name.
+		if ct, ok := c.lookupComplex(ns.resolve(e.Type)); ok {
+			c.emitComplex(name, ct, c.schemaOf[ct].ns, true)
+		}
+	case e.Type != "":
+		if typ := c.goType(ns.resolve(e.Type), ns); typ != name && !c.emitted[name] {
+			c.emitted[name] = true
+			c.comment(name, e.Annotation.Documentation)
+			fmt.Fprintf(&c.out, "type %s %s\n\n", name, typ)
+		}
+	}
+}
+
+func (c *compiler) lookupComplex(n xml.Name) (*xsdComplex, bool) {
+	if n.Space != c.tns {
+		return nil, false
+	}
+	ct, ok := c.complexTypes[n.Local]
+	return ct, ok
+}
+
+func (c *compiler) emitSimple(name string, st *xsdSimpleType, ns namespaces) {
+	if c.emitted[name] {
+		return
+	}
+	c.emitted[name] = true
+
+	// goType records the import of the base of a restriction.
+	var base string
+	switch {
+	case st.Restriction != nil:
+		base = c.goType(ns.resolve(st.Restriction.Base), ns)
+	case st.List != nil:
+		// Lists are carried as a single whitespace-separated value.
+		base = c.use(xsdImportPath, "xsd.String")
+	default:
+		base = c.use(xsdImportPath, "xsd.AnySimpleType")
+	}
+	c.comment(name, st.Annotation.Documentation)
+	fmt.Fprintf(&c.out, "type %s %s\n\n", name, base)
+
+	if st.Restriction != nil && len(st.Restriction.Enumerations) > 0 {
+		fmt.Fprintf(&c.out, "const (\n")
+		for _, en := range st.Restriction.Enumerations {
+			fmt.Fprintf(&c.out, "\t%s%s %s = %q\n", name, goName(en.Value), name, en.Value)
+		}
+		fmt.Fprintf(&c.out, ")\n\n")
+	}
+}
+
+// emitComplex declares a struct for a complex type. Requests get an XMLName
+// and qualified field names, as the other request types of this module do.
+func (c *compiler) emitComplex(name string, ct *xsdComplex, ns namespaces, request bool) {
+	if c.emitted[name] {
+		return
+	}
+	c.emitted[name] = true
+
+	var fields bytes.Buffer
+	if request {
+		fmt.Fprintf(&fields, "\tXMLName string `xml:\"%s:%s\"`\n", c.prefix, strings.TrimSuffix(name, "Element"))
+	}
+
+	attrs := ct.Attributes
+	groups := []*xsdGroup{ct.Sequence, ct.All, ct.Choice}
+	switch {
+	case ct.ComplexContent != nil:
+		d := ct.ComplexContent.Extension
+		if d == nil {
+			d = ct.ComplexContent.Restriction
+		} else {
+			base := c.goType(ns.resolve(d.Base), ns)
+			fmt.Fprintf(&fields, "\t%s\n", base)
+		}
+		if d != nil {
+			groups = []*xsdGroup{d.Sequence, d.All, d.Choice}
+			attrs = append(attrs, d.Attributes...)
+		}
+	case ct.SimpleContent != nil:
+		d := ct.SimpleContent.Extension
+		if d == nil {
+			d = ct.SimpleContent.Restriction
+		}
+		if d != nil {
+			fmt.Fprintf(&fields, "\tValue %s `xml:\",chardata\"`\n", c.goType(ns.resolve(d.Base), ns))
+			attrs = append(attrs, d.Attributes...)
+		}
+	}
+
+	for _, g := range groups {
+		if g != nil {
+			c.emitGroup(&fields, name, g, ns, request, false)
+		}
+	}
+	for _, a := range attrs {
+		c.emitAttribute(&fields, name, a, ns)
+	}
+
+	c.comment(name, ct.Annotation.Documentation)
+	fmt.Fprintf(&c.out, "type %s struct {\n%s}\n\n", name, fields.String())
+}
+
+func (c *compiler) emitGroup(w *bytes.Buffer, parent string, g *xsdGroup, ns namespaces, request, optional bool) {
+	optional = optional || g.Kind == "choice" || g.MinOccurs == "0"
+	repeated := isRepeated(g.MaxOccurs)
+	for _, p := range g.Particles {
+		if p.Group != nil {
+			c.emitGroup(w, parent, p.Group, ns, request, optional)
+			continue
+		}
+		e := p.Element
+		name := e.Name
+		var typ string
+		switch {
+		case e.Ref != "":
+			ref := ns.resolve(e.Ref)
+			name = ref.Local
+			if el, ok := c.elements[ref.Local]; ok && ref.Space == c.tns && el.Type != "" {
+				typ = c.goType(c.schemaOf[el].ns.resolve(el.Type), c.schemaOf[el].ns)
+			} else {
+				typ = c.goType(ref, ns)
+			}
+		case e.ComplexType != nil:
+			typ = parent + goName(name)
+			c.emitComplex(typ, e.ComplexType, ns, false)
+		case e.SimpleType != nil:
+			typ = parent + goName(name)
+			c.emitSimple(typ, e.SimpleType, ns)
+		case e.Type != "":
+			typ = c.goType(ns.resolve(e.Type), ns)
+		default:
+			typ = c.use(xsdImportPath, "xsd.AnyType")
+		}
+
+		switch {
+		case repeated || isRepeated(e.MaxOccurs):
+			typ = "[]" + typ
+		case optional || e.MinOccurs == "0":
+			typ = "*" + typ
+		}
+
+		tag := name
+		if request {
+			tag = c.prefix + ":" + name
+		}
+		fmt.Fprintf(w, "\t%s %s `xml:%q`\n", goName(name), typ, tag)
+	}
+}
+
+func (c *compiler) emitAttribute(w *bytes.Buffer, parent string, a *xsdAttribute, ns namespaces) {
+	name := a.Name
+	var typ string
+	switch {
+	case a.Ref != "":
+		ref := ns.resolve(a.Ref)
+		name = ref.Local
+		typ = c.use(xsdImportPath, "xsd.AnySimpleType")
+	case a.SimpleType != nil:
+		typ = parent + goName(name)
+		c.emitSimple(typ, a.SimpleType, ns)
+	case a.Type != "":
+		typ = c.goType(ns.resolve(a.Type), ns)
+	default:
+		typ = c.use(xsdImportPath, "xsd.AnySimpleType")
+	}
+	if a.Use != "required" {
+		typ = "*" + typ
+	}
+	fmt.Fprintf(w, "\t%s %s `xml:\"%s,attr\"`\n", goName(name), typ, name)
+}
+
+// goType returns the Go type of a schema type reference, emitting it first
+// when it belongs to the target namespace.
+func (c *compiler) goType(n xml.Name, ns namespaces) string {
+	switch n.Space {
+	case nsXSD:
+		if t, ok := builtins[n.Local]; ok {
+			return c.use(xsdImportPath, t)
+		}
+	case c.tns:
+		if ct, ok := c.complexTypes[n.Local]; ok {
+			c.emitComplex(goName(n.Local), ct, c.schemaOf[ct].ns, false)
+			return goName(n.Local)
+		}
+		if st, ok := c.simpleTypes[n.Local]; ok {
+			c.emitSimple(goName(n.Local), st, c.schemaOf[st].ns)
+			return goName(n.Local)
+		}
+	default:
+		if gp, ok := c.externals[n.Space]; ok && gp.types[goName(n.Local)] {
+			return c.use(gp.Path, gp.Name+"."+goName(n.Local))
+		}
+	}
+	log.Printf("unknown type {%s}%s, falling back to xsd.AnyType", n.Space, n.Local)
+	return c.use(xsdImportPath, "xsd.AnyType")
+}
+
+// use records the import needed by a qualified Go type and returns the type.
+func (c *compiler) use(importPath, typ string) string {
+	c.imports[importPath] = typ
+	return typ
+}
+
+// comment writes the first sentence of the documentation of a declaration as
+// its doc comment, which starts with its name.
+func (c *compiler) comment(name, doc string) {
+	doc = strings.Join(strings.Fields(doc), " ")
+	if doc == "" {
+		return
+	}
+	if i := strings.Index(doc, ". "); i > 0 {
+		doc = doc[:i+1]
+	}
+	if !strings.HasPrefix(doc, name+" ") {
+		doc = name + " " + doc
+	}
+	fmt.Fprintf(&c.out, "// %s\n", doc)
+}
+
+func isRepeated(maxOccurs string) bool {
+	return maxOccurs != "" && maxOccurs != "0" && maxOccurs != "1"
+}
+
+// goName turns an XML name or enumeration value into an exported Go identifier.
+func goName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	if out == "" || unicode.IsDigit(rune(out[0])) {
+		out = "X" + out
+	}
+	return out
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testWSDL = `<?xml version="1.0" encoding="UTF-8"?>
+<wsdl:definitions xmlns:wsdl="http://schemas.xmlsoap.org/wsdl/" xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:tst="http://example.com/test/wsdl" targetNamespace="http://example.com/test/wsdl">
+	<wsdl:types>
+		<xs:schema targetNamespace="http://example.com/test/wsdl" elementFormDefault="qualified">
+			<xs:simpleType name="Mode">
+				<xs:restriction base="xs:string">
+					<xs:enumeration value="Auto"/>
+					<xs:enumeration value="Manual"/>
+				</xs:restriction>
+			</xs:simpleType>
+			<xs:simpleType name="Submode">
+				<xs:annotation><xs:documentation>narrows the modes. It is a test.</xs:documentation></xs:annotation>
+				<xs:restriction base="tst:Mode"/>
+			</xs:simpleType>
+			<xs:complexType name="Item">
+				<xs:sequence>
+					<xs:element name="Mode" type="tst:Mode"/>
+					<xs:element name="Level" type="xs:float" minOccurs="0"/>
+				</xs:sequence>
+				<xs:attribute name="token" type="xs:string" use="required"/>
+				<xs:attribute name="Enabled" type="xs:boolean"/>
+			</xs:complexType>
+			<xs:element name="GetItems">
+				<xs:complexType>
+					<xs:sequence>
+						<xs:element name="Token" type="xs:string"/>
+						<xs:element name="Limit" type="xs:int" minOccurs="0"/>
+					</xs:sequence>
+				</xs:complexType>
+			</xs:element>
+			<xs:element name="GetItemsResponse">
+				<xs:complexType>
+					<xs:sequence>
+						<xs:element name="Item" type="tst:Item" minOccurs="0" maxOccurs="unbounded"/>
+					</xs:sequence>
+				</xs:complexType>
+			</xs:element>
+		</xs:schema>
+	</wsdl:types>
+	<wsdl:message name="GetItemsRequest">
+		<wsdl:part name="parameters" element="tst:GetItems"/>
+	</wsdl:message>
+	<wsdl:message name="GetItemsResponse">
+		<wsdl:part name="parameters" element="tst:GetItemsResponse"/>
+	</wsdl:message>
+	<wsdl:portType name="Test">
+		<wsdl:operation name="GetItems">
+			<wsdl:input message="tst:GetItemsRequest"/>
+			<wsdl:output message="tst:GetItemsResponse"/>
+		</wsdl:operation>
+	</wsdl:portType>
+</wsdl:definitions>`
+
+func TestCompileTypes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.wsdl")
+	if err := os.WriteFile(path, []byte(testWSDL), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defs, schemas, err := loadWSDL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := newCompiler(defs, schemas, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ops := c.operations()
+	if len(ops) != 1 || ops[0].Request != "GetItems" || ops[0].Response != "GetItemsResponse" {
+		t.Fatalf("unexpected operations %+v", ops)
+	}
+
+	src, err := c.compileTypes("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := strings.Join(strings.Fields(string(src)), " ")
+	for _, want := range []string{
+		"type Mode xsd.String",
+		`ModeAuto Mode = "Auto"`,
+		"Mode Mode `xml:\"Mode\"`",
+		"Level *xsd.Float `xml:\"Level\"`",
+		"Token xsd.String `xml:\"token,attr\"`",
+		"Enabled *xsd.Boolean `xml:\"Enabled,attr\"`",
+		"XMLName string `xml:\"tst:GetItems\"`",
+		"Limit *xsd.Int `xml:\"tst:Limit\"`",
+		"Item []Item `xml:\"Item\"`",
+		"// Submode narrows the modes. type Submode Mode",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in\n%s", want, src)
+		}
+	}
+}
+
+func TestEmitSimpleImports(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.wsdl")
+	if err := os.WriteFile(path, []byte(testWSDL), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defs, schemas, err := loadWSDL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := newCompiler(defs, schemas, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Mode is emitted first, its xsd base is the only import.
+	c.emitted["Mode"] = true
+	st := c.simpleTypes["Submode"]
+	c.emitSimple("Submode", st, c.schemaOf[st].ns)
+	if len(c.imports) != 0 {
+		t.Errorf("unexpected imports %v for a local base", c.imports)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for in, want := range map[string]string{
+		"token":          "Token",
+		"WhiteHot":       "WhiteHot",
+		"802.11":         "X80211",
+		"ntp-server":     "NtpServer",
+		"RTP_RTSP_TCP":   "RTPRTSPTCP",
+		"anyElement":     "AnyElement",
+		"Value:Extended": "ValueExtended",
+	} {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -1,12 +1,42 @@
 // Copyright (c) 2022 Jean-Francois SMIGIELSKI
 // Distributed under the MIT License
 
+// Command codegen generates the SDK of the ONVIF services.
+//
+// Called with -calls, it declares a Call_ wrapper, an instance of sdk.Call,
+// for every request of the types package that has a matching response type,
+// or for the operations given as arguments:
+//
+//	codegen -calls media
+//	codegen -calls event CreatePullPointSubscription PullMessages
+//
+// Called with positional arguments, it generates the Call_ wrapper of a single
+// operation whose request and response types are written by hand:
+//
+//	codegen <package> <structPackage> <Request>
+//
+// Called with -wsdl, it compiles a WSDL document and the schemas it imports
+// that are found on disk. With -types it writes the request, response and
+// schema types of the service, with -sdk it writes the Call_ wrappers of every
+// operation of the service:
+//
+//	codegen -wsdl ../docs/wsdl/thermal.wsdl -types thermal
+//	codegen -wsdl ../../docs/wsdl/thermal.wsdl -sdk thermal -struct thermal
+//
+// Types of other namespaces are taken from existing Go packages, see -import.
+//
+// Called with -client, it writes a Client interface and its implementation
+// over the Call_ wrappers found in the current directory:
+//
+//	codegen -client media
 package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"os"
+	"strings"
 	"text/template"
 )
 
@@ -18,7 +48,6 @@ package {{.Package}}
 
 import (
 	"context"
-	"github.com/juju/errors"
 	"github.com/ritj/onvif"
 	"github.com/ritj/onvif/sdk"
 	"github.com/ritj/onvif/{{.StructPackage}}"
@@ -26,19 +55,7 @@ import (
 
 // Call_{{.TypeRequest}} forwards the call to dev.CallMethod() then parses the payload of the reply as a {{.TypeReply}}.
 func Call_{{.TypeRequest}}(ctx context.Context, dev *onvif.Device, request {{.StructPackage}}.{{.TypeRequest}}) ({{.StructPackage}}.{{.TypeReply}}, error) {
-	type Envelope struct {
-		Header struct{}
-		Body   struct {
-			{{.TypeReply}} {{.StructPackage}}.{{.TypeReply}}
-		}
-	}
-	var reply Envelope
-	if httpReply, err := dev.CallMethod(request); err != nil {
-		return reply.Body.{{.TypeReply}}, errors.Annotate(err, "call")
-	} else {
-		err = sdk.ReadAndParse(ctx, httpReply, &reply, "{{.TypeRequest}}")
-		return reply.Body.{{.TypeReply}}, errors.Annotate(err, "reply")
-	}
+	return sdk.Call[{{.StructPackage}}.{{.TypeRequest}}, {{.StructPackage}}.{{.TypeReply}}](ctx, dev, request)
 }
 `
 
@@ -49,15 +66,127 @@ type parserEnv struct {
 	TypeRequest   string
 }
 
+// importFlags collects the repeated -import namespace=importPath options.
+type importFlags map[string]string
+
+func (f importFlags) String() string { return fmt.Sprint(map[string]string(f)) }
+
+func (f importFlags) Set(v string) error {
+	i := strings.LastIndexByte(v, '=')
+	if i <= 0 {
+		return fmt.Errorf("expected namespace=importPath, got %q", v)
+	}
+	f[v[:i]] = v[i+1:]
+	return nil
+}
+
 func main() {
+	imports := importFlags{
+		"http://www.onvif.org/ver10/schema": "github.com/ritj/onvif/xsd/onvif",
+	}
+	wsdlPath := flag.String("wsdl", "", "WSDL document to compile")
+	typesPkg := flag.String("types", "", "write the types of the service into types_auto.go of this package")
+	sdkPkg := flag.String("sdk", "", "write the Call_ wrappers of the service for this package into calls_auto.go")
+	structPkg := flag.String("struct", "", "import path, relative to the module, of the package holding the types (-sdk, -calls and -client only)")
+	clientPkg := flag.String("client", "", "write the Client of this package into client_auto.go")
+	callsPkg := flag.String("calls", "", "write the Call_ wrappers of this package into calls_auto.go")
+	flag.Var(imports, "import", "namespace=importPath of a Go package holding the types of namespace (repeatable)")
 	flag.Parse()
-	env := parserEnv{
-		Package:       flag.Arg(0),
-		StructPackage: flag.Arg(1),
-		TypeRequest:   flag.Arg(2),
-		TypeReply:     flag.Arg(2) + "Response",
+
+	if *clientPkg != "" {
+		if *structPkg == "" {
+			*structPkg = *clientPkg
+		}
+		src, err := generateClient(*clientPkg, *structPkg)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if err := os.WriteFile("client_auto.go", src, 0o644); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+
+	if *callsPkg != "" {
+		if *structPkg == "" {
+			*structPkg = *callsPkg
+		}
+		tp, err := parseTypesPackage(modulePath + "/" + *structPkg)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		var pairs []callPair
+		if flag.NArg() > 0 {
+			for _, op := range flag.Args() {
+				pairs = append(pairs, callPair{Request: op, Response: op + "Response"})
+			}
+		} else {
+			pairs = discoverCalls(tp)
+		}
+		writeCalls(*callsPkg, *structPkg, pairs)
+		return
+	}
+
+	if *wsdlPath == "" {
+		generateCall(parserEnv{
+			Package:       flag.Arg(0),
+			StructPackage: flag.Arg(1),
+			TypeRequest:   flag.Arg(2),
+			TypeReply:     flag.Arg(2) + "Response",
+		})
+		return
+	}
+
+	defs, schemas, err := loadWSDL(*wsdlPath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	externals := map[string]*goPackage{}
+	for ns, importPath := range imports {
+		gp, err := loadGoPackage(importPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		externals[ns] = gp
+	}
+	c, err := newCompiler(defs, schemas, externals)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if *typesPkg != "" {
+		src, err := c.compileTypes(*typesPkg)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if err := os.WriteFile("types_auto.go", src, 0o644); err != nil {
+			log.Fatalln(err)
+		}
 	}
+	if *sdkPkg != "" {
+		if *structPkg == "" {
+			*structPkg = *sdkPkg
+		}
+		var pairs []callPair
+		for _, op := range c.operations() {
+			pairs = append(pairs, callPair{Request: goName(op.Request), Response: goName(op.Response)})
+		}
+		writeCalls(*sdkPkg, *structPkg, pairs)
+	}
+}
+
+func writeCalls(pkg, structPkg string, pairs []callPair) {
+	src, err := generateCalls(pkg, structPkg, pairs)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if err := os.WriteFile("calls_auto.go", src, 0o644); err != nil {
+		log.Fatalln(err)
+	}
+}
 
+// generateCall writes the Call_ wrapper of a single operation.
+func generateCall(env parserEnv) {
 	log.Println(env)
 
 	body, err := template.New("body").Parse(mainTemplate)
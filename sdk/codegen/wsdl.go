@@ -0,0 +1,263 @@
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package main
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const nsXSD = "http://www.w3.org/2001/XMLSchema"
+
+// namespaces maps the prefixes declared on an element to their URI.
+type namespaces map[string]string
+
+func (ns namespaces) collect(attrs []xml.Attr) {
+	for _, a := range attrs {
+		if a.Name.Space == "xmlns" {
+			ns[a.Name.Local] = a.Value
+		}
+	}
+}
+
+// resolve splits a "prefix:local" QName and returns the namespace URI of the prefix.
+func (ns namespaces) resolve(qname string) xml.Name {
+	if i := strings.IndexByte(qname, ':'); i >= 0 {
+		return xml.Name{Space: ns[qname[:i]], Local: qname[i+1:]}
+	}
+	return xml.Name{Space: ns[""], Local: qname}
+}
+
+type wsdlDefinitions struct {
+	Name            string         `xml:"name,attr"`
+	TargetNamespace string         `xml:"targetNamespace,attr"`
+	Attrs           []xml.Attr     `xml:",any,attr"`
+	Schemas         []*xsdSchema   `xml:"types>schema"`
+	Messages        []wsdlMessage  `xml:"message"`
+	PortTypes       []wsdlPortType `xml:"portType"`
+}
+
+type wsdlMessage struct {
+	Name  string `xml:"name,attr"`
+	Parts []struct {
+		Name    string `xml:"name,attr"`
+		Element string `xml:"element,attr"`
+	} `xml:"part"`
+}
+
+type wsdlPortType struct {
+	Name       string          `xml:"name,attr"`
+	Operations []wsdlOperation `xml:"operation"`
+}
+
+type wsdlOperation struct {
+	Name          string `xml:"name,attr"`
+	Documentation string `xml:"documentation"`
+	Input         struct {
+		Message string `xml:"message,attr"`
+	} `xml:"input"`
+	Output struct {
+		Message string `xml:"message,attr"`
+	} `xml:"output"`
+}
+
+type xsdSchema struct {
+	TargetNamespace string           `xml:"targetNamespace,attr"`
+	Attrs           []xml.Attr       `xml:",any,attr"`
+	Imports         []xsdImport      `xml:"import"`
+	Includes        []xsdImport      `xml:"include"`
+	Elements        []*xsdElement    `xml:"element"`
+	ComplexTypes    []*xsdComplex    `xml:"complexType"`
+	SimpleTypes     []*xsdSimpleType `xml:"simpleType"`
+
+	ns namespaces
+}
+
+type xsdImport struct {
+	Namespace      string `xml:"namespace,attr"`
+	SchemaLocation string `xml:"schemaLocation,attr"`
+}
+
+type xsdAnnotation struct {
+	Documentation string `xml:"documentation"`
+}
+
+type xsdElement struct {
+	Name        string         `xml:"name,attr"`
+	Type        string         `xml:"type,attr"`
+	Ref         string         `xml:"ref,attr"`
+	MinOccurs   string         `xml:"minOccurs,attr"`
+	MaxOccurs   string         `xml:"maxOccurs,attr"`
+	Annotation  xsdAnnotation  `xml:"annotation"`
+	ComplexType *xsdComplex    `xml:"complexType"`
+	SimpleType  *xsdSimpleType `xml:"simpleType"`
+}
+
+type xsdAttribute struct {
+	Name       string         `xml:"name,attr"`
+	Type       string         `xml:"type,attr"`
+	Ref        string         `xml:"ref,attr"`
+	Use        string         `xml:"use,attr"`
+	SimpleType *xsdSimpleType `xml:"simpleType"`
+}
+
+type xsdComplex struct {
+	Name           string          `xml:"name,attr"`
+	Mixed          bool            `xml:"mixed,attr"`
+	Annotation     xsdAnnotation   `xml:"annotation"`
+	Sequence       *xsdGroup       `xml:"sequence"`
+	All            *xsdGroup       `xml:"all"`
+	Choice         *xsdGroup       `xml:"choice"`
+	Attributes     []*xsdAttribute `xml:"attribute"`
+	ComplexContent *xsdContent     `xml:"complexContent"`
+	SimpleContent  *xsdContent     `xml:"simpleContent"`
+}
+
+type xsdContent struct {
+	Extension   *xsdDerivation `xml:"extension"`
+	Restriction *xsdDerivation `xml:"restriction"`
+}
+
+type xsdDerivation struct {
+	Base       string          `xml:"base,attr"`
+	Sequence   *xsdGroup       `xml:"sequence"`
+	All        *xsdGroup       `xml:"all"`
+	Choice     *xsdGroup       `xml:"choice"`
+	Attributes []*xsdAttribute `xml:"attribute"`
+}
+
+type xsdSimpleType struct {
+	Name        string        `xml:"name,attr"`
+	Annotation  xsdAnnotation `xml:"annotation"`
+	Restriction *struct {
+		Base         string `xml:"base,attr"`
+		Enumerations []struct {
+			Value string `xml:"value,attr"`
+		} `xml:"enumeration"`
+	} `xml:"restriction"`
+	List *struct {
+		ItemType string `xml:"itemType,attr"`
+	} `xml:"list"`
+	Union *struct {
+		MemberTypes string `xml:"memberTypes,attr"`
+	} `xml:"union"`
+}
+
+// xsdParticle is one entry of a model group, kept in document order.
+type xsdParticle struct {
+	Element *xsdElement
+	Group   *xsdGroup
+}
+
+// xsdGroup is a sequence, all or choice model group.
+type xsdGroup struct {
+	Kind      string
+	MinOccurs string
+	MaxOccurs string
+	Particles []xsdParticle
+}
+
+// UnmarshalXML keeps nested elements and groups in the order of the schema,
+// which is the order the fields must be marshalled in.
+func (g *xsdGroup) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	g.Kind = start.Name.Local
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "minOccurs":
+			g.MinOccurs = a.Value
+		case "maxOccurs":
+			g.MaxOccurs = a.Value
+		}
+	}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "element":
+				e := new(xsdElement)
+				if err := d.DecodeElement(e, &t); err != nil {
+					return err
+				}
+				g.Particles = append(g.Particles, xsdParticle{Element: e})
+			case "sequence", "choice", "all":
+				sub := new(xsdGroup)
+				if err := d.DecodeElement(sub, &t); err != nil {
+					return err
+				}
+				g.Particles = append(g.Particles, xsdParticle{Group: sub})
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// loadWSDL parses a WSDL document and every schema it imports or includes
+// that can be found on the local filesystem, relative to the importing file.
+func loadWSDL(path string) (*wsdlDefinitions, []*xsdSchema, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "read")
+	}
+	var defs wsdlDefinitions
+	if err := xml.Unmarshal(b, &defs); err != nil {
+		return nil, nil, errors.Annotatef(err, "decode %s", path)
+	}
+
+	rootNS := namespaces{}
+	rootNS.collect(defs.Attrs)
+
+	seen := map[string]bool{}
+	var schemas []*xsdSchema
+	var walk func(dir string, s *xsdSchema, parent namespaces) error
+	walk = func(dir string, s *xsdSchema, parent namespaces) error {
+		s.ns = namespaces{}
+		for k, v := range parent {
+			s.ns[k] = v
+		}
+		s.ns.collect(s.Attrs)
+		schemas = append(schemas, s)
+		for _, imp := range append(s.Imports, s.Includes...) {
+			if imp.SchemaLocation == "" || strings.Contains(imp.SchemaLocation, "://") {
+				continue
+			}
+			loc := filepath.Join(dir, imp.SchemaLocation)
+			if seen[loc] {
+				continue
+			}
+			seen[loc] = true
+			b, err := os.ReadFile(loc)
+			if err != nil {
+				// Imported schemas are usually mapped onto an existing Go package.
+				continue
+			}
+			sub := new(xsdSchema)
+			if err := xml.Unmarshal(b, sub); err != nil {
+				return errors.Annotatef(err, "decode %s", loc)
+			}
+			if err := walk(filepath.Dir(loc), sub, namespaces{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, s := range defs.Schemas {
+		if err := walk(filepath.Dir(path), s, rootNS); err != nil {
+			return nil, nil, err
+		}
+	}
+	return &defs, schemas, nil
+}
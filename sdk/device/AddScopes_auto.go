@@ -1,30 +0,0 @@
-// Code generated : DO NOT EDIT.
-// Copyright (c) 2022 Jean-Francois SMIGIELSKI
-// Distributed under the MIT License
-
-package device
-
-import (
-	"context"
-	"github.com/juju/errors"
-	"github.com/ritj/onvif"
-	"github.com/ritj/onvif/sdk"
-	"github.com/ritj/onvif/device"
-)
-
-// Call_AddScopes forwards the call to dev.CallMethod() then parses the payload of the reply as a AddScopesResponse.
-func Call_AddScopes(ctx context.Context, dev *onvif.Device, request device.AddScopes) (device.AddScopesResponse, error) {
-	type Envelope struct {
-		Header struct{}
-		Body   struct {
-			AddScopesResponse device.AddScopesResponse
-		}
-	}
-	var reply Envelope
-	if httpReply, err := dev.CallMethod(request); err != nil {
-		return reply.Body.AddScopesResponse, errors.Annotate(err, "call")
-	} else {
-		err = sdk.ReadAndParse(ctx, httpReply, &reply, "AddScopes")
-		return reply.Body.AddScopesResponse, errors.Annotate(err, "reply")
-	}
-}
@@ -0,0 +1,104 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package device
+
+import (
+	"github.com/ritj/onvif/device"
+	"github.com/ritj/onvif/sdk"
+)
+
+// The Call_ functions forward the request to dev.CallMethod() then parse the payload of the reply.
+// They are instances of sdk.Call, kept under the names of the former generated wrappers.
+var (
+	Call_AddIPAddressFilter            = sdk.Call[device.AddIPAddressFilter, device.AddIPAddressFilterResponse]
+	Call_AddScopes                     = sdk.Call[device.AddScopes, device.AddScopesResponse]
+	Call_CreateCertificate             = sdk.Call[device.CreateCertificate, device.CreateCertificateResponse]
+	Call_CreateDot1XConfiguration      = sdk.Call[device.CreateDot1XConfiguration, device.CreateDot1XConfigurationResponse]
+	Call_CreateStorageConfiguration    = sdk.Call[device.CreateStorageConfiguration, device.CreateStorageConfigurationResponse]
+	Call_CreateUsers                   = sdk.Call[device.CreateUsers, device.CreateUsersResponse]
+	Call_DeleteCertificates            = sdk.Call[device.DeleteCertificates, device.DeleteCertificatesResponse]
+	Call_DeleteDot1XConfiguration      = sdk.Call[device.DeleteDot1XConfiguration, device.DeleteDot1XConfigurationResponse]
+	Call_DeleteGeoLocation             = sdk.Call[device.DeleteGeoLocation, device.DeleteGeoLocationResponse]
+	Call_DeleteStorageConfiguration    = sdk.Call[device.DeleteStorageConfiguration, device.DeleteStorageConfigurationResponse]
+	Call_DeleteUsers                   = sdk.Call[device.DeleteUsers, device.DeleteUsersResponse]
+	Call_GetAccessPolicy               = sdk.Call[device.GetAccessPolicy, device.GetAccessPolicyResponse]
+	Call_GetCACertificates             = sdk.Call[device.GetCACertificates, device.GetCACertificatesResponse]
+	Call_GetCertificateInformation     = sdk.Call[device.GetCertificateInformation, device.GetCertificateInformationResponse]
+	Call_GetCertificates               = sdk.Call[device.GetCertificates, device.GetCertificatesResponse]
+	Call_GetCertificatesStatus         = sdk.Call[device.GetCertificatesStatus, device.GetCertificatesStatusResponse]
+	Call_GetClientCertificateMode      = sdk.Call[device.GetClientCertificateMode, device.GetClientCertificateModeResponse]
+	Call_GetDNS                        = sdk.Call[device.GetDNS, device.GetDNSResponse]
+	Call_GetDPAddresses                = sdk.Call[device.GetDPAddresses, device.GetDPAddressesResponse]
+	Call_GetDeviceInformation          = sdk.Call[device.GetDeviceInformation, device.GetDeviceInformationResponse]
+	Call_GetDiscoveryMode              = sdk.Call[device.GetDiscoveryMode, device.GetDiscoveryModeResponse]
+	Call_GetDot11Capabilities          = sdk.Call[device.GetDot11Capabilities, device.GetDot11CapabilitiesResponse]
+	Call_GetDot11Status                = sdk.Call[device.GetDot11Status, device.GetDot11StatusResponse]
+	Call_GetDot1XConfiguration         = sdk.Call[device.GetDot1XConfiguration, device.GetDot1XConfigurationResponse]
+	Call_GetDot1XConfigurations        = sdk.Call[device.GetDot1XConfigurations, device.GetDot1XConfigurationsResponse]
+	Call_GetDynamicDNS                 = sdk.Call[device.GetDynamicDNS, device.GetDynamicDNSResponse]
+	Call_GetEndpointReference          = sdk.Call[device.GetEndpointReference, device.GetEndpointReferenceResponse]
+	Call_GetGeoLocation                = sdk.Call[device.GetGeoLocation, device.GetGeoLocationResponse]
+	Call_GetHostname                   = sdk.Call[device.GetHostname, device.GetHostnameResponse]
+	Call_GetIPAddressFilter            = sdk.Call[device.GetIPAddressFilter, device.GetIPAddressFilterResponse]
+	Call_GetNTP                        = sdk.Call[device.GetNTP, device.GetNTPResponse]
+	Call_GetNetworkDefaultGateway      = sdk.Call[device.GetNetworkDefaultGateway, device.GetNetworkDefaultGatewayResponse]
+	Call_GetNetworkInterfaces          = sdk.Call[device.GetNetworkInterfaces, device.GetNetworkInterfacesResponse]
+	Call_GetNetworkProtocols           = sdk.Call[device.GetNetworkProtocols, device.GetNetworkProtocolsResponse]
+	Call_GetPkcs10Request              = sdk.Call[device.GetPkcs10Request, device.GetPkcs10RequestResponse]
+	Call_GetRelayOutputs               = sdk.Call[device.GetRelayOutputs, device.GetRelayOutputsResponse]
+	Call_GetRemoteDiscoveryMode        = sdk.Call[device.GetRemoteDiscoveryMode, device.GetRemoteDiscoveryModeResponse]
+	Call_GetRemoteUser                 = sdk.Call[device.GetRemoteUser, device.GetRemoteUserResponse]
+	Call_GetScopes                     = sdk.Call[device.GetScopes, device.GetScopesResponse]
+	Call_GetServiceCapabilities        = sdk.Call[device.GetServiceCapabilities, device.GetServiceCapabilitiesResponse]
+	Call_GetServices                   = sdk.Call[device.GetServices, device.GetServicesResponse]
+	Call_GetStorageConfiguration       = sdk.Call[device.GetStorageConfiguration, device.GetStorageConfigurationResponse]
+	Call_GetStorageConfigurations      = sdk.Call[device.GetStorageConfigurations, device.GetStorageConfigurationsResponse]
+	Call_GetSystemBackup               = sdk.Call[device.GetSystemBackup, device.GetSystemBackupResponse]
+	Call_GetSystemDateAndTime          = sdk.Call[device.GetSystemDateAndTime, device.GetSystemDateAndTimeResponse]
+	Call_GetSystemLog                  = sdk.Call[device.GetSystemLog, device.GetSystemLogResponse]
+	Call_GetSystemSupportInformation   = sdk.Call[device.GetSystemSupportInformation, device.GetSystemSupportInformationResponse]
+	Call_GetSystemUris                 = sdk.Call[device.GetSystemUris, device.GetSystemUrisResponse]
+	Call_GetUsers                      = sdk.Call[device.GetUsers, device.GetUsersResponse]
+	Call_GetWsdlUrl                    = sdk.Call[device.GetWsdlUrl, device.GetWsdlUrlResponse]
+	Call_GetZeroConfiguration          = sdk.Call[device.GetZeroConfiguration, device.GetZeroConfigurationResponse]
+	Call_LoadCACertificates            = sdk.Call[device.LoadCACertificates, device.LoadCACertificatesResponse]
+	Call_LoadCertificateWithPrivateKey = sdk.Call[device.LoadCertificateWithPrivateKey, device.LoadCertificateWithPrivateKeyResponse]
+	Call_LoadCertificates              = sdk.Call[device.LoadCertificates, device.LoadCertificatesResponse]
+	Call_RemoveIPAddressFilter         = sdk.Call[device.RemoveIPAddressFilter, device.RemoveIPAddressFilterResponse]
+	Call_RemoveScopes                  = sdk.Call[device.RemoveScopes, device.RemoveScopesResponse]
+	Call_RestoreSystem                 = sdk.Call[device.RestoreSystem, device.RestoreSystemResponse]
+	Call_ScanAvailableDot11Networks    = sdk.Call[device.ScanAvailableDot11Networks, device.ScanAvailableDot11NetworksResponse]
+	Call_SendAuxiliaryCommand          = sdk.Call[device.SendAuxiliaryCommand, device.SendAuxiliaryCommandResponse]
+	Call_SetAccessPolicy               = sdk.Call[device.SetAccessPolicy, device.SetAccessPolicyResponse]
+	Call_SetCertificatesStatus         = sdk.Call[device.SetCertificatesStatus, device.SetCertificatesStatusResponse]
+	Call_SetClientCertificateMode      = sdk.Call[device.SetClientCertificateMode, device.SetClientCertificateModeResponse]
+	Call_SetDNS                        = sdk.Call[device.SetDNS, device.SetDNSResponse]
+	Call_SetDPAddresses                = sdk.Call[device.SetDPAddresses, device.SetDPAddressesResponse]
+	Call_SetDiscoveryMode              = sdk.Call[device.SetDiscoveryMode, device.SetDiscoveryModeResponse]
+	Call_SetDot1XConfiguration         = sdk.Call[device.SetDot1XConfiguration, device.SetDot1XConfigurationResponse]
+	Call_SetDynamicDNS                 = sdk.Call[device.SetDynamicDNS, device.SetDynamicDNSResponse]
+	Call_SetGeoLocation                = sdk.Call[device.SetGeoLocation, device.SetGeoLocationResponse]
+	Call_SetHostname                   = sdk.Call[device.SetHostname, device.SetHostnameResponse]
+	Call_SetHostnameFromDHCP           = sdk.Call[device.SetHostnameFromDHCP, device.SetHostnameFromDHCPResponse]
+	Call_SetIPAddressFilter            = sdk.Call[device.SetIPAddressFilter, device.SetIPAddressFilterResponse]
+	Call_SetNTP                        = sdk.Call[device.SetNTP, device.SetNTPResponse]
+	Call_SetNetworkDefaultGateway      = sdk.Call[device.SetNetworkDefaultGateway, device.SetNetworkDefaultGatewayResponse]
+	Call_SetNetworkInterfaces          = sdk.Call[device.SetNetworkInterfaces, device.SetNetworkInterfacesResponse]
+	Call_SetNetworkProtocols           = sdk.Call[device.SetNetworkProtocols, device.SetNetworkProtocolsResponse]
+	Call_SetRelayOutputSettings        = sdk.Call[device.SetRelayOutputSettings, device.SetRelayOutputSettingsResponse]
+	Call_SetRelayOutputState           = sdk.Call[device.SetRelayOutputState, device.SetRelayOutputStateResponse]
+	Call_SetRemoteDiscoveryMode        = sdk.Call[device.SetRemoteDiscoveryMode, device.SetRemoteDiscoveryModeResponse]
+	Call_SetRemoteUser                 = sdk.Call[device.SetRemoteUser, device.SetRemoteUserResponse]
+	Call_SetScopes                     = sdk.Call[device.SetScopes, device.SetScopesResponse]
+	Call_SetStorageConfiguration       = sdk.Call[device.SetStorageConfiguration, device.SetStorageConfigurationResponse]
+	Call_SetSystemDateAndTime          = sdk.Call[device.SetSystemDateAndTime, device.SetSystemDateAndTimeResponse]
+	Call_SetSystemFactoryDefault       = sdk.Call[device.SetSystemFactoryDefault, device.SetSystemFactoryDefaultResponse]
+	Call_SetUser                       = sdk.Call[device.SetUser, device.SetUserResponse]
+	Call_SetZeroConfiguration          = sdk.Call[device.SetZeroConfiguration, device.SetZeroConfigurationResponse]
+	Call_StartFirmwareUpgrade          = sdk.Call[device.StartFirmwareUpgrade, device.StartFirmwareUpgradeResponse]
+	Call_StartSystemRestore            = sdk.Call[device.StartSystemRestore, device.StartSystemRestoreResponse]
+	Call_SystemReboot                  = sdk.Call[device.SystemReboot, device.SystemRebootResponse]
+	Call_UpgradeSystemFirmware         = sdk.Call[device.UpgradeSystemFirmware, device.UpgradeSystemFirmwareResponse]
+)
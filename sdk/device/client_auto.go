@@ -0,0 +1,653 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package device
+
+import (
+	"context"
+	goonvif "github.com/ritj/onvif"
+	"github.com/ritj/onvif/device"
+	"github.com/ritj/onvif/xsd"
+	"github.com/ritj/onvif/xsd/onvif"
+)
+
+// Client exposes the operations of the device service of a device.
+// It can be replaced by a fake in the tests of the code that depends on it.
+type Client interface {
+	AddIPAddressFilter(ctx context.Context, ipAddressFilter onvif.IPAddressFilter) error
+	AddScopes(ctx context.Context, scopeItem []xsd.AnyURI) error
+	CreateCertificate(ctx context.Context, certificateID xsd.Token, subject string, validNotBefore xsd.DateTime, validNotAfter xsd.DateTime) (onvif.Certificate, error)
+	CreateDot1XConfiguration(ctx context.Context, dot1XConfiguration onvif.Dot1XConfiguration) error
+	CreateStorageConfiguration(ctx context.Context, storageConfiguration device.StorageConfigurationData) (onvif.ReferenceToken, error)
+	CreateUsers(ctx context.Context, user onvif.User) error
+	DeleteCertificates(ctx context.Context, certificateID xsd.Token) error
+	DeleteDot1XConfiguration(ctx context.Context, dot1XConfigurationToken onvif.ReferenceToken) error
+	DeleteGeoLocation(ctx context.Context, location onvif.LocationEntity) error
+	DeleteStorageConfiguration(ctx context.Context, token onvif.ReferenceToken) error
+	DeleteUsers(ctx context.Context, username xsd.String) error
+	GetAccessPolicy(ctx context.Context) (onvif.BinaryData, error)
+	GetCACertificates(ctx context.Context) (onvif.Certificate, error)
+	GetCapabilities(ctx context.Context, category onvif.CapabilityCategory) (onvif.Capabilities, error)
+	GetCertificateInformation(ctx context.Context, certificateID xsd.Token) (onvif.CertificateInformation, error)
+	GetCertificates(ctx context.Context) (onvif.Certificate, error)
+	GetCertificatesStatus(ctx context.Context) (onvif.CertificateStatus, error)
+	GetClientCertificateMode(ctx context.Context) (xsd.Boolean, error)
+	GetDNS(ctx context.Context) (onvif.DNSInformation, error)
+	GetDPAddresses(ctx context.Context) (onvif.NetworkHost, error)
+	GetDeviceInformation(ctx context.Context) (device.GetDeviceInformationResponse, error)
+	GetDiscoveryMode(ctx context.Context) (onvif.DiscoveryMode, error)
+	GetDot11Capabilities(ctx context.Context) (onvif.Dot11Capabilities, error)
+	GetDot11Status(ctx context.Context, interfaceToken onvif.ReferenceToken) (onvif.Dot11Status, error)
+	GetDot1XConfiguration(ctx context.Context, dot1XConfigurationToken onvif.ReferenceToken) (onvif.Dot1XConfiguration, error)
+	GetDot1XConfigurations(ctx context.Context) (onvif.Dot1XConfiguration, error)
+	GetDynamicDNS(ctx context.Context) (onvif.DynamicDNSInformation, error)
+	GetEndpointReference(ctx context.Context) (string, error)
+	GetGeoLocation(ctx context.Context) (onvif.LocationEntity, error)
+	GetHostname(ctx context.Context) (onvif.HostnameInformation, error)
+	GetIPAddressFilter(ctx context.Context) (onvif.IPAddressFilter, error)
+	GetNTP(ctx context.Context) (onvif.NTPInformation, error)
+	GetNetworkDefaultGateway(ctx context.Context) (onvif.NetworkGateway, error)
+	GetNetworkInterfaces(ctx context.Context) (onvif.NetworkInterface, error)
+	GetNetworkProtocols(ctx context.Context) (onvif.NetworkProtocol, error)
+	GetPkcs10Request(ctx context.Context, certificateID xsd.Token, subject xsd.String, attributes onvif.BinaryData) (onvif.BinaryData, error)
+	GetRelayOutputs(ctx context.Context) (onvif.RelayOutput, error)
+	GetRemoteDiscoveryMode(ctx context.Context) (onvif.DiscoveryMode, error)
+	GetRemoteUser(ctx context.Context) (onvif.RemoteUser, error)
+	GetScopes(ctx context.Context) ([]onvif.Scope, error)
+	GetServiceCapabilities(ctx context.Context) (device.DeviceServiceCapabilities, error)
+	GetServices(ctx context.Context, includeCapability xsd.Boolean) (device.Service, error)
+	GetStorageConfiguration(ctx context.Context, token onvif.ReferenceToken) (device.StorageConfiguration, error)
+	GetStorageConfigurations(ctx context.Context) (device.StorageConfiguration, error)
+	GetSystemBackup(ctx context.Context) (onvif.BackupFile, error)
+	GetSystemDateAndTime(ctx context.Context) (onvif.SystemDateTime, error)
+	GetSystemLog(ctx context.Context, logType onvif.SystemLogType) (onvif.SystemLog, error)
+	GetSystemSupportInformation(ctx context.Context) (onvif.SupportInformation, error)
+	GetSystemUris(ctx context.Context) (device.GetSystemUrisResponse, error)
+	GetUsers(ctx context.Context) (onvif.User, error)
+	GetWsdlUrl(ctx context.Context) (xsd.AnyURI, error)
+	GetZeroConfiguration(ctx context.Context) (onvif.NetworkZeroConfiguration, error)
+	LoadCACertificates(ctx context.Context, caCertificate onvif.Certificate) error
+	LoadCertificateWithPrivateKey(ctx context.Context, certificateWithPrivateKey onvif.CertificateWithPrivateKey) error
+	LoadCertificates(ctx context.Context, nvtCertificate onvif.Certificate) error
+	RemoveIPAddressFilter(ctx context.Context, ipAddressFilter onvif.IPAddressFilter) error
+	RemoveScopes(ctx context.Context, scopeItem []xsd.AnyURI) ([]xsd.AnyURI, error)
+	RestoreSystem(ctx context.Context, backupFiles onvif.BackupFile) error
+	ScanAvailableDot11Networks(ctx context.Context, interfaceToken onvif.ReferenceToken) (onvif.Dot11AvailableNetworks, error)
+	SendAuxiliaryCommand(ctx context.Context, auxiliaryCommand onvif.AuxiliaryData) (onvif.AuxiliaryData, error)
+	SetAccessPolicy(ctx context.Context, policyFile onvif.BinaryData) error
+	SetCertificatesStatus(ctx context.Context, certificateStatus onvif.CertificateStatus) error
+	SetClientCertificateMode(ctx context.Context, enabled xsd.Boolean) error
+	SetDNS(ctx context.Context, fromDHCP xsd.Boolean, searchDomain xsd.Token, dnsManual onvif.IPAddress) error
+	SetDPAddresses(ctx context.Context, dpAddress onvif.NetworkHost) error
+	SetDiscoveryMode(ctx context.Context, discoveryMode onvif.DiscoveryMode) error
+	SetDot1XConfiguration(ctx context.Context, dot1XConfiguration onvif.Dot1XConfiguration) error
+	SetDynamicDNS(ctx context.Context, typ onvif.DynamicDNSType, name onvif.DNSName, ttl xsd.Duration) error
+	SetGeoLocation(ctx context.Context, location onvif.LocationEntity) error
+	SetHostname(ctx context.Context, name xsd.Token) error
+	SetHostnameFromDHCP(ctx context.Context, fromDHCP xsd.Boolean) (xsd.Boolean, error)
+	SetIPAddressFilter(ctx context.Context, ipAddressFilter onvif.IPAddressFilter) error
+	SetNTP(ctx context.Context, fromDHCP xsd.Boolean, ntpManual onvif.NetworkHost) error
+	SetNetworkDefaultGateway(ctx context.Context, iPv4Address onvif.IPv4Address, iPv6Address onvif.IPv6Address) error
+	SetNetworkInterfaces(ctx context.Context, interfaceToken onvif.ReferenceToken, networkInterface onvif.NetworkInterfaceSetConfiguration) (xsd.Boolean, error)
+	SetNetworkProtocols(ctx context.Context, networkProtocols onvif.NetworkProtocol) error
+	SetRelayOutputSettings(ctx context.Context, relayOutputToken onvif.ReferenceToken, properties onvif.RelayOutputSettings) error
+	SetRelayOutputState(ctx context.Context, relayOutputToken onvif.ReferenceToken, logicalState onvif.RelayLogicalState) error
+	SetRemoteDiscoveryMode(ctx context.Context, remoteDiscoveryMode onvif.DiscoveryMode) error
+	SetRemoteUser(ctx context.Context, remoteUser onvif.RemoteUser) error
+	SetScopes(ctx context.Context, scopes []xsd.AnyURI) error
+	SetStorageConfiguration(ctx context.Context, storageConfiguration device.StorageConfiguration) error
+	SetSystemDateAndTime(ctx context.Context, dateTimeType onvif.SetDateTimeType, daylightSavings xsd.Boolean, timeZone onvif.TimeZone, utcDateTime onvif.DateTime) error
+	SetSystemFactoryDefault(ctx context.Context, factoryDefault onvif.FactoryDefaultType) error
+	SetUser(ctx context.Context, user onvif.User) error
+	SetZeroConfiguration(ctx context.Context, interfaceToken onvif.ReferenceToken, enabled xsd.Boolean) error
+	StartFirmwareUpgrade(ctx context.Context) (device.StartFirmwareUpgradeResponse, error)
+	StartSystemRestore(ctx context.Context) (device.StartSystemRestoreResponse, error)
+	SystemReboot(ctx context.Context) (string, error)
+	UpgradeSystemFirmware(ctx context.Context, firmware onvif.AttachmentData) (string, error)
+}
+
+// NewClient returns a Client bound to dev.
+func NewClient(dev *goonvif.Device) Client {
+	return &client{dev: dev}
+}
+
+type client struct {
+	dev *goonvif.Device
+}
+
+// AddIPAddressFilter calls the AddIPAddressFilter operation.
+func (c *client) AddIPAddressFilter(ctx context.Context, ipAddressFilter onvif.IPAddressFilter) error {
+	_, err := Call_AddIPAddressFilter(ctx, c.dev, device.AddIPAddressFilter{IPAddressFilter: ipAddressFilter})
+	return err
+}
+
+// AddScopes calls the AddScopes operation.
+func (c *client) AddScopes(ctx context.Context, scopeItem []xsd.AnyURI) error {
+	_, err := Call_AddScopes(ctx, c.dev, device.AddScopes{ScopeItem: scopeItem})
+	return err
+}
+
+// CreateCertificate calls the CreateCertificate operation.
+func (c *client) CreateCertificate(ctx context.Context, certificateID xsd.Token, subject string, validNotBefore xsd.DateTime, validNotAfter xsd.DateTime) (onvif.Certificate, error) {
+	reply, err := Call_CreateCertificate(ctx, c.dev, device.CreateCertificate{CertificateID: certificateID, Subject: subject, ValidNotBefore: validNotBefore, ValidNotAfter: validNotAfter})
+	return reply.NvtCertificate, err
+}
+
+// CreateDot1XConfiguration calls the CreateDot1XConfiguration operation.
+func (c *client) CreateDot1XConfiguration(ctx context.Context, dot1XConfiguration onvif.Dot1XConfiguration) error {
+	_, err := Call_CreateDot1XConfiguration(ctx, c.dev, device.CreateDot1XConfiguration{Dot1XConfiguration: dot1XConfiguration})
+	return err
+}
+
+// CreateStorageConfiguration calls the CreateStorageConfiguration operation.
+func (c *client) CreateStorageConfiguration(ctx context.Context, storageConfiguration device.StorageConfigurationData) (onvif.ReferenceToken, error) {
+	reply, err := Call_CreateStorageConfiguration(ctx, c.dev, device.CreateStorageConfiguration{StorageConfiguration: storageConfiguration})
+	return reply.Token, err
+}
+
+// CreateUsers calls the CreateUsers operation.
+func (c *client) CreateUsers(ctx context.Context, user onvif.User) error {
+	_, err := Call_CreateUsers(ctx, c.dev, device.CreateUsers{User: user})
+	return err
+}
+
+// DeleteCertificates calls the DeleteCertificates operation.
+func (c *client) DeleteCertificates(ctx context.Context, certificateID xsd.Token) error {
+	_, err := Call_DeleteCertificates(ctx, c.dev, device.DeleteCertificates{CertificateID: certificateID})
+	return err
+}
+
+// DeleteDot1XConfiguration calls the DeleteDot1XConfiguration operation.
+func (c *client) DeleteDot1XConfiguration(ctx context.Context, dot1XConfigurationToken onvif.ReferenceToken) error {
+	_, err := Call_DeleteDot1XConfiguration(ctx, c.dev, device.DeleteDot1XConfiguration{Dot1XConfigurationToken: dot1XConfigurationToken})
+	return err
+}
+
+// DeleteGeoLocation calls the DeleteGeoLocation operation.
+func (c *client) DeleteGeoLocation(ctx context.Context, location onvif.LocationEntity) error {
+	_, err := Call_DeleteGeoLocation(ctx, c.dev, device.DeleteGeoLocation{Location: location})
+	return err
+}
+
+// DeleteStorageConfiguration calls the DeleteStorageConfiguration operation.
+func (c *client) DeleteStorageConfiguration(ctx context.Context, token onvif.ReferenceToken) error {
+	_, err := Call_DeleteStorageConfiguration(ctx, c.dev, device.DeleteStorageConfiguration{Token: token})
+	return err
+}
+
+// DeleteUsers calls the DeleteUsers operation.
+func (c *client) DeleteUsers(ctx context.Context, username xsd.String) error {
+	_, err := Call_DeleteUsers(ctx, c.dev, device.DeleteUsers{Username: username})
+	return err
+}
+
+// GetAccessPolicy calls the GetAccessPolicy operation.
+func (c *client) GetAccessPolicy(ctx context.Context) (onvif.BinaryData, error) {
+	reply, err := Call_GetAccessPolicy(ctx, c.dev, device.GetAccessPolicy{})
+	return reply.PolicyFile, err
+}
+
+// GetCACertificates calls the GetCACertificates operation.
+func (c *client) GetCACertificates(ctx context.Context) (onvif.Certificate, error) {
+	reply, err := Call_GetCACertificates(ctx, c.dev, device.GetCACertificates{})
+	return reply.CACertificate, err
+}
+
+// GetCapabilities calls the GetCapabilities operation.
+func (c *client) GetCapabilities(ctx context.Context, category onvif.CapabilityCategory) (onvif.Capabilities, error) {
+	reply, err := Call_GetCapabilities(ctx, c.dev, device.GetCapabilities{Category: category})
+	return reply.Capabilities, err
+}
+
+// GetCertificateInformation calls the GetCertificateInformation operation.
+func (c *client) GetCertificateInformation(ctx context.Context, certificateID xsd.Token) (onvif.CertificateInformation, error) {
+	reply, err := Call_GetCertificateInformation(ctx, c.dev, device.GetCertificateInformation{CertificateID: certificateID})
+	return reply.CertificateInformation, err
+}
+
+// GetCertificates calls the GetCertificates operation.
+func (c *client) GetCertificates(ctx context.Context) (onvif.Certificate, error) {
+	reply, err := Call_GetCertificates(ctx, c.dev, device.GetCertificates{})
+	return reply.NvtCertificate, err
+}
+
+// GetCertificatesStatus calls the GetCertificatesStatus operation.
+func (c *client) GetCertificatesStatus(ctx context.Context) (onvif.CertificateStatus, error) {
+	reply, err := Call_GetCertificatesStatus(ctx, c.dev, device.GetCertificatesStatus{})
+	return reply.CertificateStatus, err
+}
+
+// GetClientCertificateMode calls the GetClientCertificateMode operation.
+func (c *client) GetClientCertificateMode(ctx context.Context) (xsd.Boolean, error) {
+	reply, err := Call_GetClientCertificateMode(ctx, c.dev, device.GetClientCertificateMode{})
+	return reply.Enabled, err
+}
+
+// GetDNS calls the GetDNS operation.
+func (c *client) GetDNS(ctx context.Context) (onvif.DNSInformation, error) {
+	reply, err := Call_GetDNS(ctx, c.dev, device.GetDNS{})
+	return reply.DNSInformation, err
+}
+
+// GetDPAddresses calls the GetDPAddresses operation.
+func (c *client) GetDPAddresses(ctx context.Context) (onvif.NetworkHost, error) {
+	reply, err := Call_GetDPAddresses(ctx, c.dev, device.GetDPAddresses{})
+	return reply.DPAddress, err
+}
+
+// GetDeviceInformation calls the GetDeviceInformation operation.
+func (c *client) GetDeviceInformation(ctx context.Context) (device.GetDeviceInformationResponse, error) {
+	return Call_GetDeviceInformation(ctx, c.dev, device.GetDeviceInformation{})
+}
+
+// GetDiscoveryMode calls the GetDiscoveryMode operation.
+func (c *client) GetDiscoveryMode(ctx context.Context) (onvif.DiscoveryMode, error) {
+	reply, err := Call_GetDiscoveryMode(ctx, c.dev, device.GetDiscoveryMode{})
+	return reply.DiscoveryMode, err
+}
+
+// GetDot11Capabilities calls the GetDot11Capabilities operation.
+func (c *client) GetDot11Capabilities(ctx context.Context) (onvif.Dot11Capabilities, error) {
+	reply, err := Call_GetDot11Capabilities(ctx, c.dev, device.GetDot11Capabilities{})
+	return reply.Capabilities, err
+}
+
+// GetDot11Status calls the GetDot11Status operation.
+func (c *client) GetDot11Status(ctx context.Context, interfaceToken onvif.ReferenceToken) (onvif.Dot11Status, error) {
+	reply, err := Call_GetDot11Status(ctx, c.dev, device.GetDot11Status{InterfaceToken: interfaceToken})
+	return reply.Status, err
+}
+
+// GetDot1XConfiguration calls the GetDot1XConfiguration operation.
+func (c *client) GetDot1XConfiguration(ctx context.Context, dot1XConfigurationToken onvif.ReferenceToken) (onvif.Dot1XConfiguration, error) {
+	reply, err := Call_GetDot1XConfiguration(ctx, c.dev, device.GetDot1XConfiguration{Dot1XConfigurationToken: dot1XConfigurationToken})
+	return reply.Dot1XConfiguration, err
+}
+
+// GetDot1XConfigurations calls the GetDot1XConfigurations operation.
+func (c *client) GetDot1XConfigurations(ctx context.Context) (onvif.Dot1XConfiguration, error) {
+	reply, err := Call_GetDot1XConfigurations(ctx, c.dev, device.GetDot1XConfigurations{})
+	return reply.Dot1XConfiguration, err
+}
+
+// GetDynamicDNS calls the GetDynamicDNS operation.
+func (c *client) GetDynamicDNS(ctx context.Context) (onvif.DynamicDNSInformation, error) {
+	reply, err := Call_GetDynamicDNS(ctx, c.dev, device.GetDynamicDNS{})
+	return reply.DynamicDNSInformation, err
+}
+
+// GetEndpointReference calls the GetEndpointReference operation.
+func (c *client) GetEndpointReference(ctx context.Context) (string, error) {
+	reply, err := Call_GetEndpointReference(ctx, c.dev, device.GetEndpointReference{})
+	return reply.GUID, err
+}
+
+// GetGeoLocation calls the GetGeoLocation operation.
+func (c *client) GetGeoLocation(ctx context.Context) (onvif.LocationEntity, error) {
+	reply, err := Call_GetGeoLocation(ctx, c.dev, device.GetGeoLocation{})
+	return reply.Location, err
+}
+
+// GetHostname calls the GetHostname operation.
+func (c *client) GetHostname(ctx context.Context) (onvif.HostnameInformation, error) {
+	reply, err := Call_GetHostname(ctx, c.dev, device.GetHostname{})
+	return reply.HostnameInformation, err
+}
+
+// GetIPAddressFilter calls the GetIPAddressFilter operation.
+func (c *client) GetIPAddressFilter(ctx context.Context) (onvif.IPAddressFilter, error) {
+	reply, err := Call_GetIPAddressFilter(ctx, c.dev, device.GetIPAddressFilter{})
+	return reply.IPAddressFilter, err
+}
+
+// GetNTP calls the GetNTP operation.
+func (c *client) GetNTP(ctx context.Context) (onvif.NTPInformation, error) {
+	reply, err := Call_GetNTP(ctx, c.dev, device.GetNTP{})
+	return reply.NTPInformation, err
+}
+
+// GetNetworkDefaultGateway calls the GetNetworkDefaultGateway operation.
+func (c *client) GetNetworkDefaultGateway(ctx context.Context) (onvif.NetworkGateway, error) {
+	reply, err := Call_GetNetworkDefaultGateway(ctx, c.dev, device.GetNetworkDefaultGateway{})
+	return reply.NetworkGateway, err
+}
+
+// GetNetworkInterfaces calls the GetNetworkInterfaces operation.
+func (c *client) GetNetworkInterfaces(ctx context.Context) (onvif.NetworkInterface, error) {
+	reply, err := Call_GetNetworkInterfaces(ctx, c.dev, device.GetNetworkInterfaces{})
+	return reply.NetworkInterfaces, err
+}
+
+// GetNetworkProtocols calls the GetNetworkProtocols operation.
+func (c *client) GetNetworkProtocols(ctx context.Context) (onvif.NetworkProtocol, error) {
+	reply, err := Call_GetNetworkProtocols(ctx, c.dev, device.GetNetworkProtocols{})
+	return reply.NetworkProtocols, err
+}
+
+// GetPkcs10Request calls the GetPkcs10Request operation.
+func (c *client) GetPkcs10Request(ctx context.Context, certificateID xsd.Token, subject xsd.String, attributes onvif.BinaryData) (onvif.BinaryData, error) {
+	reply, err := Call_GetPkcs10Request(ctx, c.dev, device.GetPkcs10Request{CertificateID: certificateID, Subject: subject, Attributes: attributes})
+	return reply.Pkcs10Request, err
+}
+
+// GetRelayOutputs calls the GetRelayOutputs operation.
+func (c *client) GetRelayOutputs(ctx context.Context) (onvif.RelayOutput, error) {
+	reply, err := Call_GetRelayOutputs(ctx, c.dev, device.GetRelayOutputs{})
+	return reply.RelayOutputs, err
+}
+
+// GetRemoteDiscoveryMode calls the GetRemoteDiscoveryMode operation.
+func (c *client) GetRemoteDiscoveryMode(ctx context.Context) (onvif.DiscoveryMode, error) {
+	reply, err := Call_GetRemoteDiscoveryMode(ctx, c.dev, device.GetRemoteDiscoveryMode{})
+	return reply.RemoteDiscoveryMode, err
+}
+
+// GetRemoteUser calls the GetRemoteUser operation.
+func (c *client) GetRemoteUser(ctx context.Context) (onvif.RemoteUser, error) {
+	reply, err := Call_GetRemoteUser(ctx, c.dev, device.GetRemoteUser{})
+	return reply.RemoteUser, err
+}
+
+// GetScopes calls the GetScopes operation.
+func (c *client) GetScopes(ctx context.Context) ([]onvif.Scope, error) {
+	reply, err := Call_GetScopes(ctx, c.dev, device.GetScopes{})
+	return reply.Scopes, err
+}
+
+// GetServiceCapabilities calls the GetServiceCapabilities operation.
+func (c *client) GetServiceCapabilities(ctx context.Context) (device.DeviceServiceCapabilities, error) {
+	reply, err := Call_GetServiceCapabilities(ctx, c.dev, device.GetServiceCapabilities{})
+	return reply.Capabilities, err
+}
+
+// GetServices calls the GetServices operation.
+func (c *client) GetServices(ctx context.Context, includeCapability xsd.Boolean) (device.Service, error) {
+	reply, err := Call_GetServices(ctx, c.dev, device.GetServices{IncludeCapability: includeCapability})
+	return reply.Service, err
+}
+
+// GetStorageConfiguration calls the GetStorageConfiguration operation.
+func (c *client) GetStorageConfiguration(ctx context.Context, token onvif.ReferenceToken) (device.StorageConfiguration, error) {
+	reply, err := Call_GetStorageConfiguration(ctx, c.dev, device.GetStorageConfiguration{Token: token})
+	return reply.StorageConfiguration, err
+}
+
+// GetStorageConfigurations calls the GetStorageConfigurations operation.
+func (c *client) GetStorageConfigurations(ctx context.Context) (device.StorageConfiguration, error) {
+	reply, err := Call_GetStorageConfigurations(ctx, c.dev, device.GetStorageConfigurations{})
+	return reply.StorageConfigurations, err
+}
+
+// GetSystemBackup calls the GetSystemBackup operation.
+func (c *client) GetSystemBackup(ctx context.Context) (onvif.BackupFile, error) {
+	reply, err := Call_GetSystemBackup(ctx, c.dev, device.GetSystemBackup{})
+	return reply.BackupFiles, err
+}
+
+// GetSystemDateAndTime calls the GetSystemDateAndTime operation.
+func (c *client) GetSystemDateAndTime(ctx context.Context) (onvif.SystemDateTime, error) {
+	reply, err := Call_GetSystemDateAndTime(ctx, c.dev, device.GetSystemDateAndTime{})
+	return reply.SystemDateAndTime, err
+}
+
+// GetSystemLog calls the GetSystemLog operation.
+func (c *client) GetSystemLog(ctx context.Context, logType onvif.SystemLogType) (onvif.SystemLog, error) {
+	reply, err := Call_GetSystemLog(ctx, c.dev, device.GetSystemLog{LogType: logType})
+	return reply.SystemLog, err
+}
+
+// GetSystemSupportInformation calls the GetSystemSupportInformation operation.
+func (c *client) GetSystemSupportInformation(ctx context.Context) (onvif.SupportInformation, error) {
+	reply, err := Call_GetSystemSupportInformation(ctx, c.dev, device.GetSystemSupportInformation{})
+	return reply.SupportInformation, err
+}
+
+// GetSystemUris calls the GetSystemUris operation.
+func (c *client) GetSystemUris(ctx context.Context) (device.GetSystemUrisResponse, error) {
+	return Call_GetSystemUris(ctx, c.dev, device.GetSystemUris{})
+}
+
+// GetUsers calls the GetUsers operation.
+func (c *client) GetUsers(ctx context.Context) (onvif.User, error) {
+	reply, err := Call_GetUsers(ctx, c.dev, device.GetUsers{})
+	return reply.User, err
+}
+
+// GetWsdlUrl calls the GetWsdlUrl operation.
+func (c *client) GetWsdlUrl(ctx context.Context) (xsd.AnyURI, error) {
+	reply, err := Call_GetWsdlUrl(ctx, c.dev, device.GetWsdlUrl{})
+	return reply.WsdlUrl, err
+}
+
+// GetZeroConfiguration calls the GetZeroConfiguration operation.
+func (c *client) GetZeroConfiguration(ctx context.Context) (onvif.NetworkZeroConfiguration, error) {
+	reply, err := Call_GetZeroConfiguration(ctx, c.dev, device.GetZeroConfiguration{})
+	return reply.ZeroConfiguration, err
+}
+
+// LoadCACertificates calls the LoadCACertificates operation.
+func (c *client) LoadCACertificates(ctx context.Context, caCertificate onvif.Certificate) error {
+	_, err := Call_LoadCACertificates(ctx, c.dev, device.LoadCACertificates{CACertificate: caCertificate})
+	return err
+}
+
+// LoadCertificateWithPrivateKey calls the LoadCertificateWithPrivateKey operation.
+func (c *client) LoadCertificateWithPrivateKey(ctx context.Context, certificateWithPrivateKey onvif.CertificateWithPrivateKey) error {
+	_, err := Call_LoadCertificateWithPrivateKey(ctx, c.dev, device.LoadCertificateWithPrivateKey{CertificateWithPrivateKey: certificateWithPrivateKey})
+	return err
+}
+
+// LoadCertificates calls the LoadCertificates operation.
+func (c *client) LoadCertificates(ctx context.Context, nvtCertificate onvif.Certificate) error {
+	_, err := Call_LoadCertificates(ctx, c.dev, device.LoadCertificates{NVTCertificate: nvtCertificate})
+	return err
+}
+
+// RemoveIPAddressFilter calls the RemoveIPAddressFilter operation.
+func (c *client) RemoveIPAddressFilter(ctx context.Context, ipAddressFilter onvif.IPAddressFilter) error {
+	_, err := Call_RemoveIPAddressFilter(ctx, c.dev, device.RemoveIPAddressFilter{IPAddressFilter: ipAddressFilter})
+	return err
+}
+
+// RemoveScopes calls the RemoveScopes operation.
+func (c *client) RemoveScopes(ctx context.Context, scopeItem []xsd.AnyURI) ([]xsd.AnyURI, error) {
+	reply, err := Call_RemoveScopes(ctx, c.dev, device.RemoveScopes{ScopeItem: scopeItem})
+	return reply.ScopeItem, err
+}
+
+// RestoreSystem calls the RestoreSystem operation.
+func (c *client) RestoreSystem(ctx context.Context, backupFiles onvif.BackupFile) error {
+	_, err := Call_RestoreSystem(ctx, c.dev, device.RestoreSystem{BackupFiles: backupFiles})
+	return err
+}
+
+// ScanAvailableDot11Networks calls the ScanAvailableDot11Networks operation.
+func (c *client) ScanAvailableDot11Networks(ctx context.Context, interfaceToken onvif.ReferenceToken) (onvif.Dot11AvailableNetworks, error) {
+	reply, err := Call_ScanAvailableDot11Networks(ctx, c.dev, device.ScanAvailableDot11Networks{InterfaceToken: interfaceToken})
+	return reply.Networks, err
+}
+
+// SendAuxiliaryCommand calls the SendAuxiliaryCommand operation.
+func (c *client) SendAuxiliaryCommand(ctx context.Context, auxiliaryCommand onvif.AuxiliaryData) (onvif.AuxiliaryData, error) {
+	reply, err := Call_SendAuxiliaryCommand(ctx, c.dev, device.SendAuxiliaryCommand{AuxiliaryCommand: auxiliaryCommand})
+	return reply.AuxiliaryCommandResponse, err
+}
+
+// SetAccessPolicy calls the SetAccessPolicy operation.
+func (c *client) SetAccessPolicy(ctx context.Context, policyFile onvif.BinaryData) error {
+	_, err := Call_SetAccessPolicy(ctx, c.dev, device.SetAccessPolicy{PolicyFile: policyFile})
+	return err
+}
+
+// SetCertificatesStatus calls the SetCertificatesStatus operation.
+func (c *client) SetCertificatesStatus(ctx context.Context, certificateStatus onvif.CertificateStatus) error {
+	_, err := Call_SetCertificatesStatus(ctx, c.dev, device.SetCertificatesStatus{CertificateStatus: certificateStatus})
+	return err
+}
+
+// SetClientCertificateMode calls the SetClientCertificateMode operation.
+func (c *client) SetClientCertificateMode(ctx context.Context, enabled xsd.Boolean) error {
+	_, err := Call_SetClientCertificateMode(ctx, c.dev, device.SetClientCertificateMode{Enabled: enabled})
+	return err
+}
+
+// SetDNS calls the SetDNS operation.
+func (c *client) SetDNS(ctx context.Context, fromDHCP xsd.Boolean, searchDomain xsd.Token, dnsManual onvif.IPAddress) error {
+	_, err := Call_SetDNS(ctx, c.dev, device.SetDNS{FromDHCP: fromDHCP, SearchDomain: searchDomain, DNSManual: dnsManual})
+	return err
+}
+
+// SetDPAddresses calls the SetDPAddresses operation.
+func (c *client) SetDPAddresses(ctx context.Context, dpAddress onvif.NetworkHost) error {
+	_, err := Call_SetDPAddresses(ctx, c.dev, device.SetDPAddresses{DPAddress: dpAddress})
+	return err
+}
+
+// SetDiscoveryMode calls the SetDiscoveryMode operation.
+func (c *client) SetDiscoveryMode(ctx context.Context, discoveryMode onvif.DiscoveryMode) error {
+	_, err := Call_SetDiscoveryMode(ctx, c.dev, device.SetDiscoveryMode{DiscoveryMode: discoveryMode})
+	return err
+}
+
+// SetDot1XConfiguration calls the SetDot1XConfiguration operation.
+func (c *client) SetDot1XConfiguration(ctx context.Context, dot1XConfiguration onvif.Dot1XConfiguration) error {
+	_, err := Call_SetDot1XConfiguration(ctx, c.dev, device.SetDot1XConfiguration{Dot1XConfiguration: dot1XConfiguration})
+	return err
+}
+
+// SetDynamicDNS calls the SetDynamicDNS operation.
+func (c *client) SetDynamicDNS(ctx context.Context, typ onvif.DynamicDNSType, name onvif.DNSName, ttl xsd.Duration) error {
+	_, err := Call_SetDynamicDNS(ctx, c.dev, device.SetDynamicDNS{Type: typ, Name: name, TTL: ttl})
+	return err
+}
+
+// SetGeoLocation calls the SetGeoLocation operation.
+func (c *client) SetGeoLocation(ctx context.Context, location onvif.LocationEntity) error {
+	_, err := Call_SetGeoLocation(ctx, c.dev, device.SetGeoLocation{Location: location})
+	return err
+}
+
+// SetHostname calls the SetHostname operation.
+func (c *client) SetHostname(ctx context.Context, name xsd.Token) error {
+	_, err := Call_SetHostname(ctx, c.dev, device.SetHostname{Name: name})
+	return err
+}
+
+// SetHostnameFromDHCP calls the SetHostnameFromDHCP operation.
+func (c *client) SetHostnameFromDHCP(ctx context.Context, fromDHCP xsd.Boolean) (xsd.Boolean, error) {
+	reply, err := Call_SetHostnameFromDHCP(ctx, c.dev, device.SetHostnameFromDHCP{FromDHCP: fromDHCP})
+	return reply.RebootNeeded, err
+}
+
+// SetIPAddressFilter calls the SetIPAddressFilter operation.
+func (c *client) SetIPAddressFilter(ctx context.Context, ipAddressFilter onvif.IPAddressFilter) error {
+	_, err := Call_SetIPAddressFilter(ctx, c.dev, device.SetIPAddressFilter{IPAddressFilter: ipAddressFilter})
+	return err
+}
+
+// SetNTP calls the SetNTP operation.
+func (c *client) SetNTP(ctx context.Context, fromDHCP xsd.Boolean, ntpManual onvif.NetworkHost) error {
+	_, err := Call_SetNTP(ctx, c.dev, device.SetNTP{FromDHCP: fromDHCP, NTPManual: ntpManual})
+	return err
+}
+
+// SetNetworkDefaultGateway calls the SetNetworkDefaultGateway operation.
+func (c *client) SetNetworkDefaultGateway(ctx context.Context, iPv4Address onvif.IPv4Address, iPv6Address onvif.IPv6Address) error {
+	_, err := Call_SetNetworkDefaultGateway(ctx, c.dev, device.SetNetworkDefaultGateway{IPv4Address: iPv4Address, IPv6Address: iPv6Address})
+	return err
+}
+
+// SetNetworkInterfaces calls the SetNetworkInterfaces operation.
+func (c *client) SetNetworkInterfaces(ctx context.Context, interfaceToken onvif.ReferenceToken, networkInterface onvif.NetworkInterfaceSetConfiguration) (xsd.Boolean, error) {
+	reply, err := Call_SetNetworkInterfaces(ctx, c.dev, device.SetNetworkInterfaces{InterfaceToken: interfaceToken, NetworkInterface: networkInterface})
+	return reply.RebootNeeded, err
+}
+
+// SetNetworkProtocols calls the SetNetworkProtocols operation.
+func (c *client) SetNetworkProtocols(ctx context.Context, networkProtocols onvif.NetworkProtocol) error {
+	_, err := Call_SetNetworkProtocols(ctx, c.dev, device.SetNetworkProtocols{NetworkProtocols: networkProtocols})
+	return err
+}
+
+// SetRelayOutputSettings calls the SetRelayOutputSettings operation.
+func (c *client) SetRelayOutputSettings(ctx context.Context, relayOutputToken onvif.ReferenceToken, properties onvif.RelayOutputSettings) error {
+	_, err := Call_SetRelayOutputSettings(ctx, c.dev, device.SetRelayOutputSettings{RelayOutputToken: relayOutputToken, Properties: properties})
+	return err
+}
+
+// SetRelayOutputState calls the SetRelayOutputState operation.
+func (c *client) SetRelayOutputState(ctx context.Context, relayOutputToken onvif.ReferenceToken, logicalState onvif.RelayLogicalState) error {
+	_, err := Call_SetRelayOutputState(ctx, c.dev, device.SetRelayOutputState{RelayOutputToken: relayOutputToken, LogicalState: logicalState})
+	return err
+}
+
+// SetRemoteDiscoveryMode calls the SetRemoteDiscoveryMode operation.
+func (c *client) SetRemoteDiscoveryMode(ctx context.Context, remoteDiscoveryMode onvif.DiscoveryMode) error {
+	_, err := Call_SetRemoteDiscoveryMode(ctx, c.dev, device.SetRemoteDiscoveryMode{RemoteDiscoveryMode: remoteDiscoveryMode})
+	return err
+}
+
+// SetRemoteUser calls the SetRemoteUser operation.
+func (c *client) SetRemoteUser(ctx context.Context, remoteUser onvif.RemoteUser) error {
+	_, err := Call_SetRemoteUser(ctx, c.dev, device.SetRemoteUser{RemoteUser: remoteUser})
+	return err
+}
+
+// SetScopes calls the SetScopes operation.
+func (c *client) SetScopes(ctx context.Context, scopes []xsd.AnyURI) error {
+	_, err := Call_SetScopes(ctx, c.dev, device.SetScopes{Scopes: scopes})
+	return err
+}
+
+// SetStorageConfiguration calls the SetStorageConfiguration operation.
+func (c *client) SetStorageConfiguration(ctx context.Context, storageConfiguration device.StorageConfiguration) error {
+	_, err := Call_SetStorageConfiguration(ctx, c.dev, device.SetStorageConfiguration{StorageConfiguration: storageConfiguration})
+	return err
+}
+
+// SetSystemDateAndTime calls the SetSystemDateAndTime operation.
+func (c *client) SetSystemDateAndTime(ctx context.Context, dateTimeType onvif.SetDateTimeType, daylightSavings xsd.Boolean, timeZone onvif.TimeZone, utcDateTime onvif.DateTime) error {
+	_, err := Call_SetSystemDateAndTime(ctx, c.dev, device.SetSystemDateAndTime{DateTimeType: dateTimeType, DaylightSavings: daylightSavings, TimeZone: timeZone, UTCDateTime: utcDateTime})
+	return err
+}
+
+// SetSystemFactoryDefault calls the SetSystemFactoryDefault operation.
+func (c *client) SetSystemFactoryDefault(ctx context.Context, factoryDefault onvif.FactoryDefaultType) error {
+	_, err := Call_SetSystemFactoryDefault(ctx, c.dev, device.SetSystemFactoryDefault{FactoryDefault: factoryDefault})
+	return err
+}
+
+// SetUser calls the SetUser operation.
+func (c *client) SetUser(ctx context.Context, user onvif.User) error {
+	_, err := Call_SetUser(ctx, c.dev, device.SetUser{User: user})
+	return err
+}
+
+// SetZeroConfiguration calls the SetZeroConfiguration operation.
+func (c *client) SetZeroConfiguration(ctx context.Context, interfaceToken onvif.ReferenceToken, enabled xsd.Boolean) error {
+	_, err := Call_SetZeroConfiguration(ctx, c.dev, device.SetZeroConfiguration{InterfaceToken: interfaceToken, Enabled: enabled})
+	return err
+}
+
+// StartFirmwareUpgrade calls the StartFirmwareUpgrade operation.
+func (c *client) StartFirmwareUpgrade(ctx context.Context) (device.StartFirmwareUpgradeResponse, error) {
+	return Call_StartFirmwareUpgrade(ctx, c.dev, device.StartFirmwareUpgrade{})
+}
+
+// StartSystemRestore calls the StartSystemRestore operation.
+func (c *client) StartSystemRestore(ctx context.Context) (device.StartSystemRestoreResponse, error) {
+	return Call_StartSystemRestore(ctx, c.dev, device.StartSystemRestore{})
+}
+
+// SystemReboot calls the SystemReboot operation.
+func (c *client) SystemReboot(ctx context.Context) (string, error) {
+	reply, err := Call_SystemReboot(ctx, c.dev, device.SystemReboot{})
+	return reply.Message, err
+}
+
+// UpgradeSystemFirmware calls the UpgradeSystemFirmware operation.
+func (c *client) UpgradeSystemFirmware(ctx context.Context, firmware onvif.AttachmentData) (string, error) {
+	reply, err := Call_UpgradeSystemFirmware(ctx, c.dev, device.UpgradeSystemFirmware{Firmware: firmware})
+	return reply.Message, err
+}
@@ -0,0 +1,38 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ritj/onvif"
+	"github.com/ritj/onvif/device"
+)
+
+func TestGetCapabilitiesLocalhost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope" xmlns:tt="http://www.onvif.org/ver10/schema" xmlns:tds="http://www.onvif.org/ver10/device/wsdl">
+<env:Body><tds:GetCapabilitiesResponse><tds:Capabilities>
+	<tt:Device><tt:XAddr>http://localhost/onvif/device_service</tt:XAddr></tt:Device>
+	<tt:Media><tt:XAddr>http://localhost/onvif/media_service</tt:XAddr></tt:Media>
+</tds:Capabilities></tds:GetCapabilitiesResponse></env:Body></env:Envelope>`)
+	}))
+	defer srv.Close()
+	xaddr := strings.TrimPrefix(srv.URL, "http://")
+	dev, err := onvif.NewDevice(onvif.DeviceParams{Xaddr: xaddr})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := Call_GetCapabilities(context.Background(), dev, device.GetCapabilities{Category: "All"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(reply.Capabilities.Media.XAddr); got != "http://"+xaddr+"/onvif/media_service" {
+		t.Errorf("localhost XAddr not rewritten: %s", got)
+	}
+}
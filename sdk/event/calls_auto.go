@@ -0,0 +1,19 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package event
+
+import (
+	"github.com/ritj/onvif/event"
+	"github.com/ritj/onvif/sdk"
+)
+
+// The Call_ functions forward the request to dev.CallMethod() then parse the payload of the reply.
+// They are instances of sdk.Call, kept under the names of the former generated wrappers.
+var (
+	Call_CreatePullPointSubscription = sdk.Call[event.CreatePullPointSubscription, event.CreatePullPointSubscriptionResponse]
+	Call_GetEventProperties          = sdk.Call[event.GetEventProperties, event.GetEventPropertiesResponse]
+	Call_GetServiceCapabilities      = sdk.Call[event.GetServiceCapabilities, event.GetServiceCapabilitiesResponse]
+	Call_Subscribe                   = sdk.Call[event.Subscribe, event.SubscribeResponse]
+)
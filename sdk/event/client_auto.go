@@ -0,0 +1,50 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package event
+
+import (
+	"context"
+	goonvif "github.com/ritj/onvif"
+	"github.com/ritj/onvif/event"
+)
+
+// Client exposes the operations of the event service of a device.
+// It can be replaced by a fake in the tests of the code that depends on it.
+type Client interface {
+	CreatePullPointSubscription(ctx context.Context, filter *event.FilterType, initialTerminationTime event.AbsoluteOrRelativeTimeType, subscriptionPolicy *event.SubscriptionPolicy) (event.CreatePullPointSubscriptionResponse, error)
+	GetEventProperties(ctx context.Context) (event.GetEventPropertiesResponse, error)
+	GetServiceCapabilities(ctx context.Context) (event.Capabilities, error)
+	Subscribe(ctx context.Context, consumerReference event.EndpointReferenceType, filter *event.FilterType, initialTerminationTime event.AbsoluteOrRelativeTimeType, subscriptionPolicy *event.SubscriptionPolicy) (event.SubscribeResponse, error)
+}
+
+// NewClient returns a Client bound to dev.
+func NewClient(dev *goonvif.Device) Client {
+	return &client{dev: dev}
+}
+
+type client struct {
+	dev *goonvif.Device
+}
+
+// CreatePullPointSubscription calls the CreatePullPointSubscription operation.
+func (c *client) CreatePullPointSubscription(ctx context.Context, filter *event.FilterType, initialTerminationTime event.AbsoluteOrRelativeTimeType, subscriptionPolicy *event.SubscriptionPolicy) (event.CreatePullPointSubscriptionResponse, error) {
+	return Call_CreatePullPointSubscription(ctx, c.dev, event.CreatePullPointSubscription{Filter: filter, InitialTerminationTime: initialTerminationTime, SubscriptionPolicy: subscriptionPolicy})
+}
+
+// GetEventProperties calls the GetEventProperties operation.
+func (c *client) GetEventProperties(ctx context.Context) (event.GetEventPropertiesResponse, error) {
+	return Call_GetEventProperties(ctx, c.dev, event.GetEventProperties{})
+}
+
+// GetServiceCapabilities calls the GetServiceCapabilities operation.
+func (c *client) GetServiceCapabilities(ctx context.Context) (event.Capabilities, error) {
+	reply, err := Call_GetServiceCapabilities(ctx, c.dev, event.GetServiceCapabilities{})
+	return reply.Capabilities, err
+}
+
+// Subscribe calls the Subscribe operation.
+func (c *client) Subscribe(ctx context.Context, consumerReference event.EndpointReferenceType, filter *event.FilterType, initialTerminationTime event.AbsoluteOrRelativeTimeType, subscriptionPolicy *event.SubscriptionPolicy) (event.SubscribeResponse, error) {
+	return Call_Subscribe(ctx, c.dev, event.Subscribe{ConsumerReference: consumerReference, Filter: filter, InitialTerminationTime: initialTerminationTime, SubscriptionPolicy: subscriptionPolicy})
+}
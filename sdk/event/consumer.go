@@ -0,0 +1,307 @@
+package event
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/juju/errors"
+	"github.com/ritj/onvif"
+	"github.com/ritj/onvif/event"
+	"github.com/ritj/onvif/sdk"
+	"github.com/ritj/onvif/xsd"
+)
+
+// consumerNamespace is the namespace of the reference parameter that
+// identifies a subscription of a Consumer.
+const consumerNamespace = "urn:github.com:ritj:onvif:consumer"
+
+// maxNotifySize bounds the size of the Notify messages read by a Consumer.
+const maxNotifySize = 1 << 20
+
+// Consumer receives the notifications pushed by the devices with
+// WS-BaseNotification Notify messages, and manages the subscriptions that
+// make them push. It is an http.Handler to be served at BaseURL.
+//
+// Every subscription is told to push to BaseURL followed by its identifier,
+// a random UUID not to be guessed by others on the network, which is also
+// given as a reference parameter. A Notify is delivered to the subscription
+// found from the path, else from the reference parameter the device copied
+// in its header, else from the address of the subscription manager when it
+// is sent from the host of that address.
+type Consumer struct {
+	// BaseURL is the URL of the handler as reached from the devices, e.g.
+	// http://192.168.1.2:8080/onvif/notify
+	BaseURL string
+	// Lifetime is the termination time asked for the subscriptions, they are
+	// renewed once half of it has elapsed.
+	Lifetime time.Duration
+	// MinBackoff and MaxBackoff bound the delay before a new attempt after an
+	// error, doubled after every consecutive error.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Buffer is the capacity of the channel of every subscription.
+	Buffer int
+	// OnError is called with the errors the subscriptions recover from, they
+	// are logged when it is nil.
+	OnError func(error)
+
+	mu            sync.Mutex
+	subscriptions map[string]*pushSubscription
+}
+
+type pushSubscription struct {
+	id      string
+	manager event.AttributedURIType
+	in      chan event.NotificationMessage
+	done    chan struct{}
+}
+
+// NewConsumer returns a Consumer served at baseURL, with default settings.
+func NewConsumer(baseURL string) *Consumer {
+	return &Consumer{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Lifetime:   10 * time.Minute,
+		MinBackoff: time.Second,
+		MaxBackoff: time.Minute,
+		Buffer:     64,
+	}
+}
+
+// Subscribe makes dev push its notifications to the Consumer, and returns
+// the channel they are delivered on. The subscription is renewed, or made
+// again after an error, until ctx is done; then it is unsubscribed and the
+// channel is closed.
+func (c *Consumer) Subscribe(ctx context.Context, dev *onvif.Device, filter *event.FilterType) <-chan event.NotificationMessage {
+	sub := &pushSubscription{
+		id:   uuid.Must(uuid.NewV4()).String(),
+		in:   make(chan event.NotificationMessage),
+		done: make(chan struct{}),
+	}
+	c.mu.Lock()
+	if c.subscriptions == nil {
+		c.subscriptions = map[string]*pushSubscription{}
+	}
+	c.subscriptions[sub.id] = sub
+	c.mu.Unlock()
+
+	out := make(chan event.NotificationMessage, c.Buffer)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case msg := <-sub.in:
+				select {
+				case out <- msg:
+				case <-sub.done:
+					return
+				}
+			case <-sub.done:
+				return
+			}
+		}
+	}()
+	go c.maintain(ctx, dev, sub, filter)
+	return out
+}
+
+func (c *Consumer) maintain(ctx context.Context, dev *onvif.Device, sub *pushSubscription, filter *event.FilterType) {
+	defer func() {
+		c.mu.Lock()
+		delete(c.subscriptions, sub.id)
+		c.mu.Unlock()
+		close(sub.done)
+	}()
+	backoff := c.MinBackoff
+	for ctx.Err() == nil {
+		err := c.session(ctx, dev, sub, filter, func() { backoff = c.MinBackoff })
+		if ctx.Err() != nil {
+			return
+		}
+		c.fail(err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > c.MaxBackoff {
+			backoff = c.MaxBackoff
+		}
+	}
+}
+
+// session subscribes then renews the subscription until an error or the end
+// of ctx, then it unsubscribes. Every successful subscription or renewal calls ok.
+func (c *Consumer) session(ctx context.Context, dev *onvif.Device, sub *pushSubscription, filter *event.FilterType, ok func()) error {
+	lifetime := event.AbsoluteOrRelativeTimeType(xsd.Duration("").NewDuration(c.Lifetime))
+	reply, err := Call_Subscribe(ctx, dev, event.Subscribe{
+		ConsumerReference: event.EndpointReferenceType{
+			Address: event.AttributedURIType(c.BaseURL + "/" + sub.id),
+			ReferenceParameters: event.ReferenceParametersType{Parameters: []string{
+				`<SubscriptionId xmlns="` + consumerNamespace + `">` + sub.id + `</SubscriptionId>`,
+			}},
+		},
+		Filter:                 filter,
+		InitialTerminationTime: lifetime,
+	})
+	if err != nil {
+		return errors.Annotate(err, "subscribe")
+	}
+	ok()
+	subscription := reply.SubscriptionReference
+	c.mu.Lock()
+	sub.manager = subscription.Address
+	c.mu.Unlock()
+	defer func() {
+		err := unsubscribe(dev, subscription)
+		if err != nil && ctx.Err() != nil {
+			c.fail(errors.Annotate(err, "unsubscribe"))
+		}
+	}()
+
+	renew := time.NewTimer(c.renewDelay(reply.CurrentTime, reply.TerminationTime))
+	defer renew.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-renew.C:
+		}
+		reply, err := Call_Renew(ctx, dev, subscription, event.Renew{TerminationTime: lifetime})
+		if err != nil {
+			return errors.Annotate(err, "renew")
+		}
+		ok()
+		renew.Reset(c.renewDelay(reply.CurrentTime, reply.TerminationTime))
+	}
+}
+
+// renewDelay returns half of the lifetime of a subscription, given by the
+// device or else Lifetime.
+func (c *Consumer) renewDelay(current event.CurrentTime, termination event.TerminationTime) time.Duration {
+	lifetime := c.Lifetime
+	ct, err1 := xsd.DateTime(current).Time()
+	tt, err2 := xsd.DateTime(termination).Time()
+	if err1 == nil && err2 == nil && tt.After(ct) {
+		lifetime = tt.Sub(ct)
+	}
+	return lifetime / 2
+}
+
+// ServeHTTP receives the Notify messages.
+func (c *Consumer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "expected a POST", http.StatusMethodNotAllowed)
+		return
+	}
+	var envelope struct {
+		Header struct {
+			Parameters string `xml:",innerxml"`
+		}
+		Body struct {
+			Notify *event.Notify `xml:"Notify"`
+		}
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxNotifySize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, "Notify too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err == nil {
+		err = xml.Unmarshal(body, &envelope)
+	}
+	if err != nil || envelope.Body.Notify == nil {
+		http.Error(w, "expected a Notify", http.StatusBadRequest)
+		return
+	}
+	sdk.InheritNamespaces(&envelope, body)
+
+	messages := envelope.Body.Notify.NotificationMessage
+	sub := c.lookup(r.URL.Path, envelope.Header.Parameters, r.RemoteAddr, messages)
+	if sub == nil {
+		http.Error(w, "unknown subscription", http.StatusNotFound)
+		return
+	}
+	for _, msg := range messages {
+		select {
+		case sub.in <- msg:
+		case <-sub.done:
+		case <-r.Context().Done():
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (c *Consumer) lookup(path, header, remoteAddr string, messages []event.NotificationMessage) *pushSubscription {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if sub, ok := c.subscriptions[path[strings.LastIndexByte(path, '/')+1:]]; ok {
+		return sub
+	}
+	if id := headerSubscriptionID(header); id != "" {
+		if sub, ok := c.subscriptions[id]; ok {
+			return sub
+		}
+	}
+	for _, msg := range messages {
+		for _, sub := range c.subscriptions {
+			// The address of the manager is no secret, only its device is
+			// trusted to send it.
+			if sub.manager != "" && sub.manager == msg.SubscriptionReference.Address && sameHost(string(sub.manager), remoteAddr) {
+				return sub
+			}
+		}
+	}
+	return nil
+}
+
+// sameHost tells if a request from remoteAddr comes from the host of a URL.
+func sameHost(rawURL, remoteAddr string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil {
+		return ip.Equal(net.ParseIP(host))
+	}
+	return strings.EqualFold(u.Hostname(), host)
+}
+
+// headerSubscriptionID returns the identifier of the subscription the device
+// copied into the header of a Notify.
+func headerSubscriptionID(header string) string {
+	d := xml.NewDecoder(strings.NewReader(header))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return ""
+		}
+		if t, ok := tok.(xml.StartElement); ok && t.Name.Space == consumerNamespace && t.Name.Local == "SubscriptionId" {
+			var id string
+			if d.DecodeElement(&id, &t) != nil {
+				return ""
+			}
+			return strings.TrimSpace(id)
+		}
+	}
+}
+
+func (c *Consumer) fail(err error) {
+	if c.OnError != nil {
+		c.OnError(err)
+		return
+	}
+	sdk.Logger.Warn().Err(err).Msg("event consumer")
+}
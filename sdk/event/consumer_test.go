@@ -0,0 +1,145 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ritj/onvif"
+	"github.com/ritj/onvif/event"
+)
+
+func TestConsumer(t *testing.T) {
+	var mu sync.Mutex
+	var consumerRef string
+	subscribed := make(chan struct{}, 1)
+	unsubscribed := make(chan struct{}, 1)
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body := string(b)
+		switch {
+		case strings.Contains(body, "GetCapabilities"):
+			fmt.Fprintf(w, envelope, `<tds:GetCapabilitiesResponse><tds:Capabilities>
+				<tt:Events><tt:XAddr>`+srv.URL+`/onvif/events</tt:XAddr></tt:Events>
+			</tds:Capabilities></tds:GetCapabilitiesResponse>`)
+		case strings.Contains(body, "<wsnt:Subscribe>"):
+			mu.Lock()
+			consumerRef = body
+			mu.Unlock()
+			fmt.Fprintf(w, envelope, `<wsnt:SubscribeResponse>
+				<wsnt:SubscriptionReference><wsa:Address>`+srv.URL+`/onvif/subscription/7</wsa:Address></wsnt:SubscriptionReference>
+				<wsnt:CurrentTime>2024-01-01T00:00:00Z</wsnt:CurrentTime>
+				<wsnt:TerminationTime>2024-01-01T00:10:00Z</wsnt:TerminationTime>
+			</wsnt:SubscribeResponse>`)
+			subscribed <- struct{}{}
+		case r.URL.Path == "/onvif/subscription/7" && strings.Contains(body, "wsnt:Unsubscribe"):
+			fmt.Fprintf(w, envelope, `<wsnt:UnsubscribeResponse/>`)
+			unsubscribed <- struct{}{}
+		default:
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	dev, err := onvif.NewDevice(onvif.DeviceParams{Xaddr: strings.TrimPrefix(srv.URL, "http://")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	consumerSrv := httptest.NewServer(mux)
+	defer consumerSrv.Close()
+	// A Consumer made without NewConsumer works as well.
+	consumer := &Consumer{BaseURL: consumerSrv.URL + "/notify", Lifetime: 10 * time.Minute, MinBackoff: time.Second, MaxBackoff: time.Second}
+	mux.Handle("/notify/", consumer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := consumer.Subscribe(ctx, dev, nil)
+	select {
+	case <-subscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("not subscribed")
+	}
+
+	mu.Lock()
+	address := regexp.MustCompile(`<wsa:Address>` + regexp.QuoteMeta(consumerSrv.URL) + `/notify/([0-9a-f-]{36})</wsa:Address>`).FindStringSubmatch(consumerRef)
+	var hasParam bool
+	if address != nil {
+		hasParam = strings.Contains(consumerRef, `<wsa:ReferenceParameters><SubscriptionId xmlns="`+consumerNamespace+`">`+address[1]+`</SubscriptionId></wsa:ReferenceParameters>`)
+	}
+	mu.Unlock()
+	if address == nil || !hasParam {
+		t.Fatalf("unexpected consumer reference in %s", consumerRef)
+	}
+	id := address[1]
+
+	notify := func(path, header string) {
+		msg := `<?xml version="1.0"?>
+<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope" xmlns:wsnt="http://docs.oasis-open.org/wsn/b-2" xmlns:wsa="http://www.w3.org/2005/08/addressing">
+<env:Header>` + header + `</env:Header>
+<env:Body><wsnt:Notify><wsnt:NotificationMessage>
+	<wsnt:Topic Dialect="http://www.onvif.org/ver10/tev/topicExpression/ConcreteSet">tns1:Device/Trigger/DigitalInput</wsnt:Topic>
+</wsnt:NotificationMessage></wsnt:Notify></env:Body>
+</env:Envelope>`
+		resp, err := http.Post(consumerSrv.URL+path, "application/soap+xml", strings.NewReader(msg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("Notify to %s replied %s", path, resp.Status)
+		}
+		select {
+		case msg := <-events:
+			if msg.Topic.TopicKinds != "tns1:Device/Trigger/DigitalInput" {
+				t.Errorf("unexpected topic %q", msg.Topic.TopicKinds)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no notification")
+		}
+	}
+	notify("/notify/"+id, "")
+	notify("/notify/", `<c:SubscriptionId xmlns:c="`+consumerNamespace+`">`+id+`</c:SubscriptionId>`)
+
+	resp, err := http.Post(consumerSrv.URL+"/notify/"+id, "application/soap+xml", strings.NewReader("<a>"+strings.Repeat(" ", maxNotifySize)+"</a>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("too large Notify replied %s", resp.Status)
+	}
+
+	cancel()
+	select {
+	case <-unsubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("not unsubscribed")
+	}
+	for range events {
+	}
+}
+
+func TestConsumerLookupManager(t *testing.T) {
+	c := &Consumer{}
+	sub := &pushSubscription{id: "4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f", manager: "http://192.168.1.10/onvif/subscription/7"}
+	c.subscriptions = map[string]*pushSubscription{sub.id: sub}
+	var msg event.NotificationMessage
+	msg.SubscriptionReference.Address = sub.manager
+	messages := []event.NotificationMessage{msg}
+
+	if got := c.lookup("/notify/", "", "192.168.1.10:40000", messages); got != sub {
+		t.Error("Notify of the device not delivered")
+	}
+	if got := c.lookup("/notify/", "", "192.168.1.66:40000", messages); got != nil {
+		t.Error("Notify of another host delivered from the address of the manager")
+	}
+}
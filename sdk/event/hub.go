@@ -0,0 +1,179 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/juju/errors"
+	"github.com/ritj/onvif"
+	"github.com/ritj/onvif/event"
+)
+
+// Policy tells what a Hub does with a notification when the channel of a
+// subscription is full.
+type Policy int
+
+// The policies of the subscriptions of a Hub
+const (
+	// Block waits for the subscription to receive the notification. It holds
+	// back every subscription of the hub, and the pulls of the device.
+	Block Policy = iota
+	// DropNewest discards the notification.
+	DropNewest
+	// DropOldest discards the oldest notification of the channel to make room.
+	DropOldest
+)
+
+// Hub shares a single subscription of a device between any number of
+// subscriptions in the process, as the devices only allow a few, see
+// event.Capabilities.MaxPullPoints.
+type Hub struct {
+	// Subscriber runs the subscription of the device, its settings may be
+	// changed until Run is called.
+	Subscriber *Subscriber
+
+	mu            sync.Mutex
+	subscriptions map[*HubSubscription]struct{}
+	stopped       bool
+}
+
+// HubOptions are the settings of a subscription of a Hub.
+type HubOptions struct {
+	// Topic is a ConcreteSet expression of the topics delivered, see
+	// event.FilterBuilder.Topic. All are delivered when it is empty.
+	Topic string
+	// Buffer is the capacity of the channel.
+	Buffer int
+	// Policy tells what to do when the channel is full.
+	Policy Policy
+}
+
+// HubSubscription is a subscription of a Hub.
+type HubSubscription struct {
+	// C delivers the notifications, it is closed by Close or when the hub stops.
+	C <-chan event.NotificationMessage
+
+	hub       *Hub
+	options   HubOptions
+	c         chan event.NotificationMessage
+	mu        sync.Mutex
+	closed    bool
+	done      chan struct{}
+	closeOnce sync.Once
+	dropped   uint64
+}
+
+// NewHub returns a Hub of the events of dev, with the default settings of NewSubscriber.
+func NewHub(dev *onvif.Device) *Hub {
+	return &Hub{
+		Subscriber:    NewSubscriber(dev),
+		subscriptions: map[*HubSubscription]struct{}{},
+	}
+}
+
+// Subscribe adds a subscription, which receives the notifications from now on.
+func (h *Hub) Subscribe(options HubOptions) (*HubSubscription, error) {
+	if options.Topic != "" {
+		if err := event.ValidateTopicExpression(options.Topic); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	c := make(chan event.NotificationMessage, options.Buffer)
+	sub := &HubSubscription{C: c, hub: h, options: options, c: c, done: make(chan struct{})}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		return nil, errors.New("hub stopped")
+	}
+	h.subscriptions[sub] = struct{}{}
+	return sub, nil
+}
+
+// Run runs the subscription of the device and dispatches its notifications
+// until ctx is done, then it closes the channels of the subscriptions.
+func (h *Hub) Run(ctx context.Context) {
+	for msg := range h.Subscriber.Run(ctx) {
+		h.mu.Lock()
+		subscriptions := make([]*HubSubscription, 0, len(h.subscriptions))
+		for sub := range h.subscriptions {
+			subscriptions = append(subscriptions, sub)
+		}
+		h.mu.Unlock()
+		for _, sub := range subscriptions {
+			sub.send(ctx, msg)
+		}
+	}
+
+	h.mu.Lock()
+	h.stopped = true
+	subscriptions := h.subscriptions
+	h.subscriptions = nil
+	h.mu.Unlock()
+	for sub := range subscriptions {
+		sub.close()
+	}
+}
+
+// Close removes the subscription and closes its channel.
+func (s *HubSubscription) Close() {
+	s.hub.mu.Lock()
+	delete(s.hub.subscriptions, s)
+	s.hub.mu.Unlock()
+	s.close()
+}
+
+// Dropped returns the number of notifications discarded as the channel was full.
+func (s *HubSubscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+func (s *HubSubscription) close() {
+	s.closeOnce.Do(func() { close(s.done) })
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.c)
+	}
+}
+
+func (s *HubSubscription) send(ctx context.Context, msg event.NotificationMessage) {
+	if s.options.Topic != "" {
+		if ok, _ := event.MatchTopic(s.options.Topic, string(msg.Topic.TopicKinds)); !ok {
+			return
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	switch s.options.Policy {
+	case Block:
+		select {
+		case s.c <- msg:
+		case <-s.done:
+		case <-ctx.Done():
+		}
+		return
+	case DropOldest:
+		for cap(s.c) > 0 {
+			select {
+			case s.c <- msg:
+				return
+			default:
+			}
+			select {
+			case <-s.c:
+				atomic.AddUint64(&s.dropped, 1)
+			default:
+			}
+		}
+	}
+	select {
+	case s.c <- msg:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+}
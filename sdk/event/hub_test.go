@@ -0,0 +1,65 @@
+package event
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ritj/onvif"
+)
+
+func TestHub(t *testing.T) {
+	d := newFakeDevice(t)
+	dev, err := onvif.NewDevice(onvif.DeviceParams{Xaddr: strings.TrimPrefix(d.URL, "http://")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hub := NewHub(dev)
+	hub.Subscriber.OnError = func(error) {}
+	motion, err := hub.Subscribe(HubOptions{Topic: "tns1:VideoSource//.", Buffer: 1, Policy: DropOldest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := hub.Subscribe(HubOptions{Topic: "tns1:RuleEngine//.", Buffer: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hub.Subscribe(HubOptions{Topic: "VideoSource"}); err == nil {
+		t.Error("expected an error for a topic without prefix")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		hub.Run(ctx)
+		close(done)
+	}()
+	// The fake device sends a MotionAlarm at every pull, the channel of one
+	// message keeps the last ones.
+	deadline := time.After(5 * time.Second)
+	for motion.Dropped() < 2 {
+		select {
+		case <-deadline:
+			t.Fatal("no notification dropped")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	msg := <-motion.C
+	if msg.Topic.TopicKinds != "tns1:VideoSource/MotionAlarm" {
+		t.Errorf("unexpected topic %q", msg.Topic.TopicKinds)
+	}
+	motion.Close()
+	for range motion.C {
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("hub not stopped")
+	}
+	if _, ok := <-other.C; ok {
+		t.Error("unexpected notification of another topic")
+	}
+}
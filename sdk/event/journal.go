@@ -0,0 +1,318 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/ritj/onvif"
+	"github.com/ritj/onvif/event"
+	"github.com/ritj/onvif/sdk"
+)
+
+// segmentLayout names the files of a Journal after the start of their period.
+const segmentLayout = "20060102T150405Z"
+
+// Journal records the notifications of a device in a directory, to answer
+// what happened on it at some time even when it has no search service.
+//
+// The notifications are appended as JSON lines to a file per period of
+// SegmentDuration after their reception, and queried by their UtcTime: a
+// notification whose UtcTime is more than a period away from its reception,
+// e.g. from a device with a wrong clock, may be missed by the queries.
+//
+// The zero values of the fields are usable, as in &Journal{Dir: dir}.
+type Journal struct {
+	Dir string
+	// SegmentDuration is the period of the notifications of a file, an hour
+	// when zero.
+	SegmentDuration time.Duration
+	// MaxAge and MaxSize, when not zero, bound the age and the total size of
+	// the files kept. The oldest files are removed when a file is started.
+	MaxAge  time.Duration
+	MaxSize int64
+
+	mu      sync.Mutex
+	file    *os.File
+	segment time.Time
+	// now is time.Now when nil.
+	now func() time.Time
+}
+
+// JournalEntry is a notification recorded in a Journal.
+type JournalEntry struct {
+	Received time.Time
+	// Device identifies the device of the notification, see DeviceID.
+	Device  string `json:",omitempty"`
+	Message event.NotificationMessage
+}
+
+// DeviceID identifies a device in a Journal: its endpoint reference, which
+// stays the same whatever its address, or else its address.
+func DeviceID(dev *onvif.Device) string {
+	params := dev.GetDeviceParams()
+	if params.EndpointReference != "" {
+		return params.EndpointReference
+	}
+	return params.Xaddr
+}
+
+// Time returns the time of the notification, or of its reception when the
+// device gave none.
+func (e JournalEntry) Time() time.Time {
+	if t := e.Message.Message.Message.UtcTime; !t.IsZero() {
+		return t
+	}
+	return e.Received
+}
+
+// JournalQuery selects the entries of a Journal. The zero values select all of them.
+type JournalQuery struct {
+	// From and To bound the time of the entries, To excluded.
+	From, To time.Time
+	// Topic is a ConcreteSet expression of the topics selected, see
+	// event.FilterBuilder.Topic.
+	Topic string
+	// Device selects the entries of a device, see DeviceID.
+	Device string
+}
+
+// OpenJournal returns a Journal in dir, created if missing, with files of an hour.
+func OpenJournal(dir string) (*Journal, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Journal{Dir: dir, SegmentDuration: time.Hour, now: time.Now}, nil
+}
+
+// Append records a notification of the device identified by device, see
+// DeviceID.
+func (j *Journal) Append(device string, msg event.NotificationMessage) error {
+	received := j.clock().UTC()
+	line, err := json.Marshal(JournalEntry{Received: received, Device: device, Message: msg})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if segment := received.Truncate(j.segmentDuration()); j.file == nil || !segment.Equal(j.segment) {
+		if err := j.rotate(segment); err != nil {
+			return err
+		}
+	}
+	// A single write, so that a crash leaves at most a partial last line.
+	_, err = j.file.Write(append(line, '\n'))
+	return errors.Trace(err)
+}
+
+// Close closes the current file.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return errors.Trace(err)
+}
+
+func (j *Journal) clock() time.Time {
+	if j.now == nil {
+		return time.Now()
+	}
+	return j.now()
+}
+
+func (j *Journal) segmentDuration() time.Duration {
+	if j.SegmentDuration <= 0 {
+		return time.Hour
+	}
+	return j.SegmentDuration
+}
+
+func (j *Journal) rotate(segment time.Time) error {
+	if j.file != nil {
+		j.file.Close()
+		j.file = nil
+	}
+	name := filepath.Join(j.Dir, segment.Format(segmentLayout)+".jsonl")
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	j.file, j.segment = f, segment
+	return j.prune()
+}
+
+// Prune removes the files beyond MaxAge and MaxSize.
+func (j *Journal) Prune() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.prune()
+}
+
+func (j *Journal) prune() error {
+	segments, err := j.segments()
+	if err != nil {
+		return err
+	}
+	var size int64
+	sizes := make([]int64, len(segments))
+	for i, s := range segments {
+		if info, err := os.Stat(s.path); err == nil {
+			sizes[i] = info.Size()
+			size += sizes[i]
+		}
+	}
+	now := j.clock().UTC()
+	for i, s := range segments {
+		if s.start.Equal(j.segment) {
+			break
+		}
+		expired := j.MaxAge > 0 && now.Sub(s.start.Add(j.segmentDuration())) > j.MaxAge
+		oversized := j.MaxSize > 0 && size > j.MaxSize
+		if !expired && !oversized {
+			break
+		}
+		if err := os.Remove(s.path); err != nil {
+			return errors.Trace(err)
+		}
+		size -= sizes[i]
+	}
+	return nil
+}
+
+type journalSegment struct {
+	path  string
+	start time.Time
+}
+
+// segments returns the files of the journal, the oldest first.
+func (j *Journal) segments() ([]journalSegment, error) {
+	names, err := filepath.Glob(filepath.Join(j.Dir, "*.jsonl"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var out []journalSegment
+	for _, name := range names {
+		start, err := time.Parse(segmentLayout, strings.TrimSuffix(filepath.Base(name), ".jsonl"))
+		if err == nil {
+			out = append(out, journalSegment{path: name, start: start})
+		}
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].start.Before(out[b].start) })
+	return out, nil
+}
+
+// Query returns the entries selected by q, sorted by their time.
+func (j *Journal) Query(q JournalQuery) ([]JournalEntry, error) {
+	var out []JournalEntry
+	err := j.scan(q, func(e JournalEntry) bool {
+		out = append(out, e)
+		return true
+	})
+	sort.SliceStable(out, func(a, b int) bool { return out[a].Time().Before(out[b].Time()) })
+	return out, err
+}
+
+// Replay sends the notifications selected by q on a channel, like the live
+// ones of a Subscriber, in the order they were received. The channel is
+// closed at the end of the journal or when ctx is done.
+func (j *Journal) Replay(ctx context.Context, q JournalQuery) (<-chan event.NotificationMessage, error) {
+	if q.Topic != "" {
+		if err := event.ValidateTopicExpression(q.Topic); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	out := make(chan event.NotificationMessage)
+	go func() {
+		defer close(out)
+		err := j.scan(q, func(e JournalEntry) bool {
+			select {
+			case out <- e.Message:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if err != nil {
+			sdk.Logger.Warn().Err(err).Msg("event journal replay")
+		}
+	}()
+	return out, nil
+}
+
+// scan calls fn with the entries of q, in the order they were received,
+// until it returns false.
+func (j *Journal) scan(q JournalQuery, fn func(JournalEntry) bool) error {
+	if q.Topic != "" {
+		if err := event.ValidateTopicExpression(q.Topic); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	segments, err := j.segments()
+	if err != nil {
+		return err
+	}
+	period := j.segmentDuration()
+	for _, s := range segments {
+		// The entries of a file were received during its period, their time
+		// is taken as at most a period away.
+		if !q.From.IsZero() && s.start.Add(2*period).Before(q.From) {
+			continue
+		}
+		if !q.To.IsZero() && s.start.Add(-period).After(q.To) {
+			continue
+		}
+		more, err := scanSegment(s.path, q, fn)
+		if err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanSegment(path string, q JournalQuery, fn func(JournalEntry) bool) (bool, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		// Removed since listed.
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		var e JournalEntry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			// A partial line left by a crash.
+			continue
+		}
+		t := e.Time()
+		if (!q.From.IsZero() && t.Before(q.From)) || (!q.To.IsZero() && !t.Before(q.To)) {
+			continue
+		}
+		if q.Device != "" && e.Device != q.Device {
+			continue
+		}
+		if q.Topic != "" {
+			if ok, _ := event.MatchTopic(q.Topic, string(e.Message.Topic.TopicKinds)); !ok {
+				continue
+			}
+		}
+		if !fn(e) {
+			return false, nil
+		}
+	}
+	return true, errors.Annotate(scanner.Err(), path)
+}
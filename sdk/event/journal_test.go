@@ -0,0 +1,105 @@
+package event
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ritj/onvif/event"
+	"github.com/ritj/onvif/xsd"
+)
+
+func TestJournal(t *testing.T) {
+	j, err := OpenJournal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	now := time.Date(2024, 1, 1, 1, 30, 0, 0, time.UTC)
+	j.now = func() time.Time { return now }
+	record := func(at time.Time, topic string) {
+		now = at
+		var msg event.NotificationMessage
+		msg.Topic.TopicKinds = xsd.String(topic)
+		msg.Message.Message.UtcTime = at
+		device := "urn:uuid:camera1"
+		if topic == "tns1:VideoSource/MotionAlarm" {
+			device = "urn:uuid:camera2"
+		}
+		if err := j.Append(device, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	base := time.Date(2024, 1, 1, 1, 30, 0, 0, time.UTC)
+	record(base, "tns1:Device/Trigger/DigitalInput")
+	record(base.Add(time.Hour), "tns1:VideoSource/MotionAlarm")
+	record(base.Add(time.Hour+time.Minute), "tns1:Device/Trigger/DigitalInput")
+	record(base.Add(2*time.Hour), "tns1:VideoSource/MotionAlarm")
+
+	entries, err := j.Query(JournalQuery{From: base.Add(30 * time.Minute), To: base.Add(90 * time.Minute)})
+	if err != nil || len(entries) != 2 || !entries[0].Time().Equal(base.Add(time.Hour)) {
+		t.Fatalf("unexpected entries %+v, %v", entries, err)
+	}
+	entries, err = j.Query(JournalQuery{Topic: "tns1:Device//."})
+	if err != nil || len(entries) != 2 || entries[0].Device != "urn:uuid:camera1" {
+		t.Fatalf("unexpected entries %+v, %v", entries, err)
+	}
+	entries, err = j.Query(JournalQuery{Device: "urn:uuid:camera2"})
+	if err != nil || len(entries) != 2 || entries[0].Message.Topic.TopicKinds != "tns1:VideoSource/MotionAlarm" {
+		t.Fatalf("unexpected entries %+v, %v", entries, err)
+	}
+
+	replay, err := j.Replay(context.Background(), JournalQuery{Topic: "tns1:VideoSource/MotionAlarm"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var replayed int
+	for msg := range replay {
+		if msg.Topic.TopicKinds != "tns1:VideoSource/MotionAlarm" {
+			t.Errorf("unexpected topic %q", msg.Topic.TopicKinds)
+		}
+		replayed++
+	}
+	if replayed != 2 {
+		t.Errorf("expected 2 notifications, got %d", replayed)
+	}
+
+	// A crash while writing leaves a partial line.
+	f, err := os.OpenFile(j.file.Name(), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"Received":"2024-`)
+	f.Close()
+	if entries, err := j.Query(JournalQuery{}); err != nil || len(entries) != 4 {
+		t.Errorf("unexpected entries %d, %v", len(entries), err)
+	}
+
+	j.MaxAge = 80 * time.Minute
+	record(base.Add(3*time.Hour), "tns1:VideoSource/MotionAlarm")
+	segments, _ := j.segments()
+	if len(segments) != 2 {
+		t.Errorf("expected 2 files after pruning, got %d", len(segments))
+	}
+}
+
+func TestJournalLiteral(t *testing.T) {
+	j := &Journal{Dir: t.TempDir()}
+	defer j.Close()
+	for i := 0; i < 3; i++ {
+		var msg event.NotificationMessage
+		msg.Topic.TopicKinds = "tns1:Device/Trigger/DigitalInput"
+		if err := j.Append("192.168.1.10", msg); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	// An hour may start between the notifications, not more.
+	if segments, _ := j.segments(); len(segments) > 2 {
+		t.Errorf("expected a file per hour, got %d files", len(segments))
+	}
+	if entries, err := j.Query(JournalQuery{}); err != nil || len(entries) != 3 {
+		t.Errorf("unexpected entries %d, %v", len(entries), err)
+	}
+}
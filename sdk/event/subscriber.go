@@ -0,0 +1,204 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/ritj/onvif"
+	"github.com/ritj/onvif/event"
+	"github.com/ritj/onvif/sdk"
+	"github.com/ritj/onvif/xsd"
+)
+
+// Subscriber runs a PullPoint subscription of a device. It creates the
+// subscription, pulls its notifications, renews it before it terminates and
+// creates it again when the device lost it, e.g. after a reboot.
+//
+// The fields may be changed until Run is called.
+type Subscriber struct {
+	// Filter restricts the notifications to some topics, all are delivered when nil.
+	Filter *event.FilterType
+	// Lifetime is the termination time asked for the subscription, it is
+	// renewed once half of it has elapsed.
+	Lifetime time.Duration
+	// PullTimeout and MessageLimit are the parameters of PullMessages. They
+	// are lowered to the limits of the device when it replies with a
+	// PullMessagesFaultResponse.
+	PullTimeout  time.Duration
+	MessageLimit int
+	// MinBackoff and MaxBackoff bound the delay before a new attempt after an
+	// error, doubled after every consecutive error.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Buffer is the capacity of the channel of notifications.
+	Buffer int
+	// Synchronize makes the loop reconcile Properties when it subscribes
+	// again: the new subscription starts with the Initialized notifications
+	// of the properties that exist, the others, deleted while no subscription
+	// existed, are deleted from Properties once they are received.
+	Synchronize bool
+	// Properties, when set, is fed with every notification before it is delivered.
+	Properties *event.PropertyStore
+	// Journal, when set, records every notification before it is delivered.
+	Journal *Journal
+	// OnError is called with the errors the loop recovers from, they are
+	// logged when it is nil.
+	OnError func(error)
+
+	dev *onvif.Device
+}
+
+// NewSubscriber returns a Subscriber of the events of dev, with default settings.
+func NewSubscriber(dev *onvif.Device) *Subscriber {
+	return &Subscriber{
+		Lifetime:     time.Minute,
+		PullTimeout:  10 * time.Second,
+		MessageLimit: 100,
+		MinBackoff:   time.Second,
+		MaxBackoff:   time.Minute,
+		Buffer:       64,
+		Synchronize:  true,
+		dev:          dev,
+	}
+}
+
+// Run starts the loop in a goroutine and returns the channel of the
+// notifications. When ctx is done, the pending PullMessages is cancelled and
+// the loop unsubscribes, giving up after unsubscribeTimeout, then closes the
+// channel.
+func (s *Subscriber) Run(ctx context.Context) <-chan event.NotificationMessage {
+	out := make(chan event.NotificationMessage, s.Buffer)
+	go s.loop(ctx, out)
+	return out
+}
+
+func (s *Subscriber) loop(ctx context.Context, out chan<- event.NotificationMessage) {
+	defer close(out)
+	backoff := s.MinBackoff
+	for resubscribe := false; ctx.Err() == nil; resubscribe = true {
+		err := s.session(ctx, out, resubscribe, func() { backoff = s.MinBackoff })
+		if ctx.Err() != nil {
+			return
+		}
+		s.fail(err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > s.MaxBackoff {
+			backoff = s.MaxBackoff
+		}
+	}
+}
+
+// session creates a subscription and pulls its notifications until an error
+// or the end of ctx, then it unsubscribes. Every successful pull calls ok.
+func (s *Subscriber) session(ctx context.Context, out chan<- event.NotificationMessage, resubscribe bool, ok func()) error {
+	reply, err := Call_CreatePullPointSubscription(ctx, s.dev, event.CreatePullPointSubscription{
+		Filter:                 s.Filter,
+		InitialTerminationTime: event.AbsoluteOrRelativeTimeType(xsd.Duration("").NewDuration(s.Lifetime)),
+	})
+	if err != nil {
+		return errors.Annotate(err, "subscribe")
+	}
+	subscription := reply.SubscriptionReference
+	defer func() {
+		err := unsubscribe(s.dev, subscription)
+		// A device that failed the session likely fails this too, the
+		// error is only worth reporting on a regular stop.
+		if err != nil && ctx.Err() != nil {
+			s.fail(errors.Annotate(err, "unsubscribe"))
+		}
+	}()
+
+	// The Initialized notifications end with the first pull without any.
+	sweep := resubscribe && s.Synchronize && s.Properties != nil
+	if sweep {
+		s.Properties.Mark()
+	}
+
+	renewAt := s.renewTime(reply.CurrentTime, reply.TerminationTime)
+	timeout, limit := s.PullTimeout, s.MessageLimit
+	for ctx.Err() == nil {
+		if time.Now().After(renewAt) {
+			reply, err := Call_Renew(ctx, s.dev, subscription, event.Renew{
+				TerminationTime: event.AbsoluteOrRelativeTimeType(xsd.Duration("").NewDuration(s.Lifetime)),
+			})
+			if err != nil {
+				return errors.Annotate(err, "renew")
+			}
+			renewAt = s.renewTime(reply.CurrentTime, reply.TerminationTime)
+		}
+
+		reply, err := Call_PullMessages(ctx, s.dev, subscription, event.PullMessages{
+			Timeout:      xsd.Duration("").NewDuration(timeout),
+			MessageLimit: xsd.Int(limit),
+		})
+		if err != nil {
+			var limits event.PullMessagesFaultResponse
+			if f, isFault := errors.Cause(err).(*sdk.Fault); isFault && f.DecodeDetail("PullMessagesFaultResponse", &limits) == nil {
+				if t, l := lowerLimits(timeout, limit, limits); t != timeout || l != limit {
+					timeout, limit = t, l
+					s.fail(errors.Annotatef(err, "pull limited to %s and %d messages", timeout, limit))
+					continue
+				}
+			}
+			return errors.Annotate(err, "pull")
+		}
+		ok()
+		initialized := false
+		for _, msg := range reply.NotificationMessage {
+			initialized = initialized || msg.Message.Message.PropertyOperation == event.PropertyInitialized
+			if s.Properties != nil {
+				s.Properties.Apply(msg)
+			}
+			if s.Journal != nil {
+				if err := s.Journal.Append(DeviceID(s.dev), msg); err != nil {
+					s.fail(errors.Annotate(err, "journal"))
+				}
+			}
+			select {
+			case out <- msg:
+			case <-ctx.Done():
+			}
+		}
+		if sweep && !initialized {
+			s.Properties.Sweep()
+			sweep = false
+		}
+	}
+	return nil
+}
+
+// renewTime returns when to renew a subscription, half of its lifetime
+// given by the device or else half of Lifetime.
+func (s *Subscriber) renewTime(current event.CurrentTime, termination event.TerminationTime) time.Time {
+	lifetime := s.Lifetime
+	c, err1 := xsd.DateTime(current).Time()
+	t, err2 := xsd.DateTime(termination).Time()
+	if err1 == nil && err2 == nil && t.After(c) {
+		lifetime = t.Sub(c)
+	}
+	return time.Now().Add(lifetime / 2)
+}
+
+// lowerLimits applies the maximums of a PullMessagesFaultResponse.
+func lowerLimits(timeout time.Duration, limit int, fault event.PullMessagesFaultResponse) (time.Duration, int) {
+	if max, err := fault.MaxTimeout.Duration(); err == nil && max > 0 && max < timeout {
+		timeout = max
+	}
+	if max := int(fault.MaxMessageLimit); max > 0 && max < limit {
+		limit = max
+	}
+	return timeout, limit
+}
+
+func (s *Subscriber) fail(err error) {
+	if s.OnError != nil {
+		s.OnError(err)
+		return
+	}
+	sdk.Logger.Warn().Err(err).Msg("event subscriber")
+}
@@ -0,0 +1,124 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ritj/onvif"
+)
+
+const envelope = `<?xml version="1.0" encoding="UTF-8"?>
+<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope" xmlns:tt="http://www.onvif.org/ver10/schema" xmlns:tds="http://www.onvif.org/ver10/device/wsdl" xmlns:tev="http://www.onvif.org/ver10/events/wsdl" xmlns:wsnt="http://docs.oasis-open.org/wsn/b-2" xmlns:wsa="http://www.w3.org/2005/08/addressing" xmlns:tns1="http://www.onvif.org/ver10/topics">
+<env:Body>%s</env:Body>
+</env:Envelope>`
+
+// fakeDevice answers the event operations, the first PullMessages with a
+// PullMessagesFaultResponse.
+type fakeDevice struct {
+	*httptest.Server
+	mu       sync.Mutex
+	pulls    []string
+	unsubbed bool
+}
+
+func newFakeDevice(t *testing.T) *fakeDevice {
+	d := &fakeDevice{}
+	d.Server = httptest.NewServer(http.HandlerFunc(d.serve))
+	t.Cleanup(d.Close)
+	return d
+}
+
+func (d *fakeDevice) serve(w http.ResponseWriter, r *http.Request) {
+	b, _ := io.ReadAll(r.Body)
+	body := string(b)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	switch {
+	case strings.Contains(body, "GetCapabilities"):
+		fmt.Fprintf(w, envelope, `<tds:GetCapabilitiesResponse><tds:Capabilities>
+			<tt:Events><tt:XAddr>`+d.URL+`/onvif/events</tt:XAddr></tt:Events>
+		</tds:Capabilities></tds:GetCapabilitiesResponse>`)
+	case r.URL.Path == "/onvif/events" && strings.Contains(body, "tev:CreatePullPointSubscription"):
+		fmt.Fprintf(w, envelope, `<tev:CreatePullPointSubscriptionResponse>
+			<tev:SubscriptionReference><wsa:Address>`+d.URL+`/onvif/subscription/1</wsa:Address></tev:SubscriptionReference>
+			<wsnt:CurrentTime>2024-01-01T00:00:00Z</wsnt:CurrentTime>
+			<wsnt:TerminationTime>2024-01-01T00:01:00Z</wsnt:TerminationTime>
+		</tev:CreatePullPointSubscriptionResponse>`)
+	case r.URL.Path == "/onvif/subscription/1" && strings.Contains(body, "tev:PullMessages"):
+		d.pulls = append(d.pulls, body)
+		if len(d.pulls) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, envelope, `<env:Fault>
+				<env:Code><env:Value>env:Receiver</env:Value></env:Code>
+				<env:Reason><env:Text xml:lang="en">Limits exceeded</env:Text></env:Reason>
+				<env:Detail><tev:PullMessagesFaultResponse><tev:MaxTimeout>PT5S</tev:MaxTimeout><tev:MaxMessageLimit>10</tev:MaxMessageLimit></tev:PullMessagesFaultResponse></env:Detail>
+			</env:Fault>`)
+			return
+		}
+		fmt.Fprintf(w, envelope, `<tev:PullMessagesResponse>
+			<tev:CurrentTime>2024-01-01T00:00:01Z</tev:CurrentTime>
+			<tev:TerminationTime>2024-01-01T00:01:00Z</tev:TerminationTime>
+			<wsnt:NotificationMessage>
+				<wsnt:Topic Dialect="http://www.onvif.org/ver10/tev/topicExpression/ConcreteSet">tns1:VideoSource/MotionAlarm</wsnt:Topic>
+				<wsnt:Message><tt:Message UtcTime="2024-01-01T00:00:01Z" PropertyOperation="Changed">
+					<tt:Source><tt:SimpleItem Name="Source" Value="vs1"/></tt:Source>
+					<tt:Data><tt:SimpleItem Name="State" Value="true"/></tt:Data>
+				</tt:Message></wsnt:Message>
+			</wsnt:NotificationMessage>
+		</tev:PullMessagesResponse>`)
+	case r.URL.Path == "/onvif/subscription/1" && strings.Contains(body, "wsnt:Unsubscribe"):
+		d.unsubbed = true
+		fmt.Fprintf(w, envelope, `<wsnt:UnsubscribeResponse/>`)
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func TestSubscriber(t *testing.T) {
+	d := newFakeDevice(t)
+	dev, err := onvif.NewDevice(onvif.DeviceParams{Xaddr: strings.TrimPrefix(d.URL, "http://")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := NewSubscriber(dev)
+	var faults int
+	s.OnError = func(err error) { faults++ }
+	events := s.Run(ctx)
+
+	select {
+	case msg := <-events:
+		if msg.Topic.TopicKinds != "tns1:VideoSource/MotionAlarm" {
+			t.Errorf("unexpected topic %q", msg.Topic.TopicKinds)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification")
+	}
+	cancel()
+	for range events {
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if faults != 1 {
+		t.Errorf("expected the limits fault to be reported once, got %d", faults)
+	}
+	if !strings.Contains(d.pulls[1], "<tev:Timeout>PT5S</tev:Timeout>") || !strings.Contains(d.pulls[1], "<tev:MessageLimit>10</tev:MessageLimit>") {
+		t.Errorf("limits not applied to %s", d.pulls[1])
+	}
+	if !strings.Contains(d.pulls[0], "PullPointSubscription/PullMessagesRequest</wsa:Action>") {
+		t.Errorf("missing WS-Addressing action in %s", d.pulls[0])
+	}
+	if !d.unsubbed {
+		t.Error("not unsubscribed")
+	}
+}
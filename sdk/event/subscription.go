@@ -0,0 +1,83 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"time"
+
+	"github.com/ritj/onvif"
+	"github.com/ritj/onvif/event"
+	"github.com/ritj/onvif/sdk"
+)
+
+// The operations of a subscription are not sent to the event service but to
+// its subscription manager, at the address of the SubscriptionReference
+// returned by Subscribe or CreatePullPointSubscription. The reference
+// parameters of the subscription are copied into the SOAP header, along with
+// the WS-Addressing Action and To.
+
+// Call_PullMessages pulls the pending notifications of a PullPoint subscription.
+func Call_PullMessages(ctx context.Context, dev *onvif.Device, subscription event.EndpointReferenceType, request event.PullMessages) (event.PullMessagesResponse, error) {
+	return callSubscription[event.PullMessages, event.PullMessagesResponse](ctx, dev, subscription,
+		"http://www.onvif.org/ver10/events/wsdl/PullPointSubscription/PullMessagesRequest", request)
+}
+
+// Call_Seek moves the read pointer of a PullPoint subscription to request.UtcTime.
+func Call_Seek(ctx context.Context, dev *onvif.Device, subscription event.EndpointReferenceType, request event.Seek) (event.SeekResponse, error) {
+	return callSubscription[event.Seek, event.SeekResponse](ctx, dev, subscription,
+		"http://www.onvif.org/ver10/events/wsdl/PullPointSubscription/SeekRequest", request)
+}
+
+// Call_SetSynchronizationPoint asks for the current state of every property
+// of a PullPoint subscription.
+func Call_SetSynchronizationPoint(ctx context.Context, dev *onvif.Device, subscription event.EndpointReferenceType, request event.SetSynchronizationPoint) (event.SetSynchronizationPointResponse, error) {
+	return callSubscription[event.SetSynchronizationPoint, event.SetSynchronizationPointResponse](ctx, dev, subscription,
+		"http://www.onvif.org/ver10/events/wsdl/PullPointSubscription/SetSynchronizationPointRequest", request)
+}
+
+// Call_Renew extends the subscription until request.TerminationTime.
+func Call_Renew(ctx context.Context, dev *onvif.Device, subscription event.EndpointReferenceType, request event.Renew) (event.RenewResponse, error) {
+	return callSubscription[event.Renew, event.RenewResponse](ctx, dev, subscription,
+		"http://docs.oasis-open.org/wsn/bw-2/SubscriptionManager/RenewRequest", request)
+}
+
+// Call_Unsubscribe terminates the subscription.
+func Call_Unsubscribe(ctx context.Context, dev *onvif.Device, subscription event.EndpointReferenceType, request event.Unsubscribe) (event.UnsubscribeResponse, error) {
+	return callSubscription[event.Unsubscribe, event.UnsubscribeResponse](ctx, dev, subscription,
+		"http://docs.oasis-open.org/wsn/bw-2/SubscriptionManager/UnsubscribeRequest", request)
+}
+
+// Call_PauseSubscription suspends the delivery of the notifications of the subscription.
+func Call_PauseSubscription(ctx context.Context, dev *onvif.Device, subscription event.EndpointReferenceType, request event.PauseSubscription) (event.PauseSubscriptionResponse, error) {
+	return callSubscription[event.PauseSubscription, event.PauseSubscriptionResponse](ctx, dev, subscription,
+		"http://docs.oasis-open.org/wsn/bw-2/PausableSubscriptionManager/PauseSubscriptionRequest", request)
+}
+
+// Call_ResumeSubscription resumes the delivery of the notifications of a paused subscription.
+func Call_ResumeSubscription(ctx context.Context, dev *onvif.Device, subscription event.EndpointReferenceType, request event.ResumeSubscription) (event.ResumeSubscriptionResponse, error) {
+	return callSubscription[event.ResumeSubscription, event.ResumeSubscriptionResponse](ctx, dev, subscription,
+		"http://docs.oasis-open.org/wsn/bw-2/PausableSubscriptionManager/ResumeSubscriptionRequest", request)
+}
+
+// unsubscribeTimeout bounds the Unsubscribe sent when a subscription ends,
+// whose context is usually done already.
+const unsubscribeTimeout = 5 * time.Second
+
+// unsubscribe ends a subscription, giving up after unsubscribeTimeout.
+func unsubscribe(dev *onvif.Device, subscription event.EndpointReferenceType) error {
+	ctx, cancel := context.WithTimeout(context.Background(), unsubscribeTimeout)
+	defer cancel()
+	_, err := Call_Unsubscribe(ctx, dev, subscription, event.Unsubscribe{})
+	return err
+}
+
+func callSubscription[Req, Resp any](ctx context.Context, dev *onvif.Device, subscription event.EndpointReferenceType, action string, request Req) (Resp, error) {
+	var to bytes.Buffer
+	xml.EscapeText(&to, []byte(subscription.Address))
+	headers := append([]string{
+		"<wsa:Action>" + action + "</wsa:Action>",
+		"<wsa:To>" + to.String() + "</wsa:To>",
+	}, subscription.ReferenceParameters.Parameters...)
+	return sdk.CallAt[Req, Resp](ctx, dev, string(subscription.Address), request, headers...)
+}
@@ -0,0 +1,26 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package imaging
+
+import (
+	"github.com/ritj/onvif/imaging"
+	"github.com/ritj/onvif/sdk"
+)
+
+// The Call_ functions forward the request to dev.CallMethod() then parse the payload of the reply.
+// They are instances of sdk.Call, kept under the names of the former generated wrappers.
+var (
+	Call_GetCurrentPreset       = sdk.Call[imaging.GetCurrentPreset, imaging.GetCurrentPresetResponse]
+	Call_GetImagingSettings     = sdk.Call[imaging.GetImagingSettings, imaging.GetImagingSettingsResponse]
+	Call_GetMoveOptions         = sdk.Call[imaging.GetMoveOptions, imaging.GetMoveOptionsResponse]
+	Call_GetOptions             = sdk.Call[imaging.GetOptions, imaging.GetOptionsResponse]
+	Call_GetPresets             = sdk.Call[imaging.GetPresets, imaging.GetPresetsResponse]
+	Call_GetServiceCapabilities = sdk.Call[imaging.GetServiceCapabilities, imaging.GetServiceCapabilitiesResponse]
+	Call_GetStatus              = sdk.Call[imaging.GetStatus, imaging.GetStatusResponse]
+	Call_Move                   = sdk.Call[imaging.Move, imaging.MoveResponse]
+	Call_SetCurrentPreset       = sdk.Call[imaging.SetCurrentPreset, imaging.SetCurrentPresetResponse]
+	Call_SetImagingSettings     = sdk.Call[imaging.SetImagingSettings, imaging.SetImagingSettingsResponse]
+	Call_Stop                   = sdk.Call[imaging.Stop, imaging.StopResponse]
+)
@@ -0,0 +1,104 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package imaging
+
+import (
+	"context"
+	goonvif "github.com/ritj/onvif"
+	"github.com/ritj/onvif/imaging"
+	"github.com/ritj/onvif/xsd"
+	"github.com/ritj/onvif/xsd/onvif"
+)
+
+// Client exposes the operations of the imaging service of a device.
+// It can be replaced by a fake in the tests of the code that depends on it.
+type Client interface {
+	GetCurrentPreset(ctx context.Context, videoSourceToken onvif.ReferenceToken) (*imaging.ImagingPreset, error)
+	GetImagingSettings(ctx context.Context, videoSourceToken onvif.ReferenceToken) (onvif.ImagingSettings20, error)
+	GetMoveOptions(ctx context.Context, videoSourceToken onvif.ReferenceToken) (onvif.MoveOptions20, error)
+	GetOptions(ctx context.Context, videoSourceToken onvif.ReferenceToken) (onvif.ImagingOptions20, error)
+	GetPresets(ctx context.Context, videoSourceToken onvif.ReferenceToken) ([]imaging.ImagingPreset, error)
+	GetServiceCapabilities(ctx context.Context) (imaging.Capabilities, error)
+	GetStatus(ctx context.Context, videoSourceToken onvif.ReferenceToken) (onvif.ImagingStatus20, error)
+	Move(ctx context.Context, videoSourceToken onvif.ReferenceToken, focus onvif.FocusMove) error
+	SetCurrentPreset(ctx context.Context, videoSourceToken onvif.ReferenceToken, presetToken onvif.ReferenceToken) error
+	SetImagingSettings(ctx context.Context, videoSourceToken onvif.ReferenceToken, imagingSettings onvif.ImagingSettings20, forcePersistence xsd.Boolean) error
+	Stop(ctx context.Context, videoSourceToken onvif.ReferenceToken) error
+}
+
+// NewClient returns a Client bound to dev.
+func NewClient(dev *goonvif.Device) Client {
+	return &client{dev: dev}
+}
+
+type client struct {
+	dev *goonvif.Device
+}
+
+// GetCurrentPreset calls the GetCurrentPreset operation.
+func (c *client) GetCurrentPreset(ctx context.Context, videoSourceToken onvif.ReferenceToken) (*imaging.ImagingPreset, error) {
+	reply, err := Call_GetCurrentPreset(ctx, c.dev, imaging.GetCurrentPreset{VideoSourceToken: videoSourceToken})
+	return reply.Preset, err
+}
+
+// GetImagingSettings calls the GetImagingSettings operation.
+func (c *client) GetImagingSettings(ctx context.Context, videoSourceToken onvif.ReferenceToken) (onvif.ImagingSettings20, error) {
+	reply, err := Call_GetImagingSettings(ctx, c.dev, imaging.GetImagingSettings{VideoSourceToken: videoSourceToken})
+	return reply.ImagingSettings, err
+}
+
+// GetMoveOptions calls the GetMoveOptions operation.
+func (c *client) GetMoveOptions(ctx context.Context, videoSourceToken onvif.ReferenceToken) (onvif.MoveOptions20, error) {
+	reply, err := Call_GetMoveOptions(ctx, c.dev, imaging.GetMoveOptions{VideoSourceToken: videoSourceToken})
+	return reply.MoveOptions, err
+}
+
+// GetOptions calls the GetOptions operation.
+func (c *client) GetOptions(ctx context.Context, videoSourceToken onvif.ReferenceToken) (onvif.ImagingOptions20, error) {
+	reply, err := Call_GetOptions(ctx, c.dev, imaging.GetOptions{VideoSourceToken: videoSourceToken})
+	return reply.ImagingOptions, err
+}
+
+// GetPresets calls the GetPresets operation.
+func (c *client) GetPresets(ctx context.Context, videoSourceToken onvif.ReferenceToken) ([]imaging.ImagingPreset, error) {
+	reply, err := Call_GetPresets(ctx, c.dev, imaging.GetPresets{VideoSourceToken: videoSourceToken})
+	return reply.Preset, err
+}
+
+// GetServiceCapabilities calls the GetServiceCapabilities operation.
+func (c *client) GetServiceCapabilities(ctx context.Context) (imaging.Capabilities, error) {
+	reply, err := Call_GetServiceCapabilities(ctx, c.dev, imaging.GetServiceCapabilities{})
+	return reply.Capabilities, err
+}
+
+// GetStatus calls the GetStatus operation.
+func (c *client) GetStatus(ctx context.Context, videoSourceToken onvif.ReferenceToken) (onvif.ImagingStatus20, error) {
+	reply, err := Call_GetStatus(ctx, c.dev, imaging.GetStatus{VideoSourceToken: videoSourceToken})
+	return reply.Status, err
+}
+
+// Move calls the Move operation.
+func (c *client) Move(ctx context.Context, videoSourceToken onvif.ReferenceToken, focus onvif.FocusMove) error {
+	_, err := Call_Move(ctx, c.dev, imaging.Move{VideoSourceToken: videoSourceToken, Focus: focus})
+	return err
+}
+
+// SetCurrentPreset calls the SetCurrentPreset operation.
+func (c *client) SetCurrentPreset(ctx context.Context, videoSourceToken onvif.ReferenceToken, presetToken onvif.ReferenceToken) error {
+	_, err := Call_SetCurrentPreset(ctx, c.dev, imaging.SetCurrentPreset{VideoSourceToken: videoSourceToken, PresetToken: presetToken})
+	return err
+}
+
+// SetImagingSettings calls the SetImagingSettings operation.
+func (c *client) SetImagingSettings(ctx context.Context, videoSourceToken onvif.ReferenceToken, imagingSettings onvif.ImagingSettings20, forcePersistence xsd.Boolean) error {
+	_, err := Call_SetImagingSettings(ctx, c.dev, imaging.SetImagingSettings{VideoSourceToken: videoSourceToken, ImagingSettings: imagingSettings, ForcePersistence: forcePersistence})
+	return err
+}
+
+// Stop calls the Stop operation.
+func (c *client) Stop(ctx context.Context, videoSourceToken onvif.ReferenceToken) error {
+	_, err := Call_Stop(ctx, c.dev, imaging.Stop{VideoSourceToken: videoSourceToken})
+	return err
+}
@@ -0,0 +1,4 @@
+package imaging
+
+//go:generate go run github.com/ritj/onvif/sdk/codegen -calls imaging
+//go:generate go run github.com/ritj/onvif/sdk/codegen -client imaging
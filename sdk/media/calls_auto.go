@@ -0,0 +1,94 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package media
+
+import (
+	"github.com/ritj/onvif/media"
+	"github.com/ritj/onvif/sdk"
+)
+
+// The Call_ functions forward the request to dev.CallMethod() then parse the payload of the reply.
+// They are instances of sdk.Call, kept under the names of the former generated wrappers.
+var (
+	Call_AddAudioDecoderConfiguration               = sdk.Call[media.AddAudioDecoderConfiguration, media.AddAudioDecoderConfigurationResponse]
+	Call_AddAudioEncoderConfiguration               = sdk.Call[media.AddAudioEncoderConfiguration, media.AddAudioEncoderConfigurationResponse]
+	Call_AddAudioOutputConfiguration                = sdk.Call[media.AddAudioOutputConfiguration, media.AddAudioOutputConfigurationResponse]
+	Call_AddAudioSourceConfiguration                = sdk.Call[media.AddAudioSourceConfiguration, media.AddAudioSourceConfigurationResponse]
+	Call_AddMetadataConfiguration                   = sdk.Call[media.AddMetadataConfiguration, media.AddMetadataConfigurationResponse]
+	Call_AddPTZConfiguration                        = sdk.Call[media.AddPTZConfiguration, media.AddPTZConfigurationResponse]
+	Call_AddVideoAnalyticsConfiguration             = sdk.Call[media.AddVideoAnalyticsConfiguration, media.AddVideoAnalyticsConfigurationResponse]
+	Call_AddVideoEncoderConfiguration               = sdk.Call[media.AddVideoEncoderConfiguration, media.AddVideoEncoderConfigurationResponse]
+	Call_AddVideoSourceConfiguration                = sdk.Call[media.AddVideoSourceConfiguration, media.AddVideoSourceConfigurationResponse]
+	Call_CreateOSD                                  = sdk.Call[media.CreateOSD, media.CreateOSDResponse]
+	Call_CreateProfile                              = sdk.Call[media.CreateProfile, media.CreateProfileResponse]
+	Call_DeleteOSD                                  = sdk.Call[media.DeleteOSD, media.DeleteOSDResponse]
+	Call_DeleteProfile                              = sdk.Call[media.DeleteProfile, media.DeleteProfileResponse]
+	Call_GetAudioDecoderConfiguration               = sdk.Call[media.GetAudioDecoderConfiguration, media.GetAudioDecoderConfigurationResponse]
+	Call_GetAudioDecoderConfigurationOptions        = sdk.Call[media.GetAudioDecoderConfigurationOptions, media.GetAudioDecoderConfigurationOptionsResponse]
+	Call_GetAudioDecoderConfigurations              = sdk.Call[media.GetAudioDecoderConfigurations, media.GetAudioDecoderConfigurationsResponse]
+	Call_GetAudioEncoderConfiguration               = sdk.Call[media.GetAudioEncoderConfiguration, media.GetAudioEncoderConfigurationResponse]
+	Call_GetAudioEncoderConfigurationOptions        = sdk.Call[media.GetAudioEncoderConfigurationOptions, media.GetAudioEncoderConfigurationOptionsResponse]
+	Call_GetAudioEncoderConfigurations              = sdk.Call[media.GetAudioEncoderConfigurations, media.GetAudioEncoderConfigurationsResponse]
+	Call_GetAudioOutputConfiguration                = sdk.Call[media.GetAudioOutputConfiguration, media.GetAudioOutputConfigurationResponse]
+	Call_GetAudioOutputConfigurationOptions         = sdk.Call[media.GetAudioOutputConfigurationOptions, media.GetAudioOutputConfigurationOptionsResponse]
+	Call_GetAudioOutputConfigurations               = sdk.Call[media.GetAudioOutputConfigurations, media.GetAudioOutputConfigurationsResponse]
+	Call_GetAudioOutputs                            = sdk.Call[media.GetAudioOutputs, media.GetAudioOutputsResponse]
+	Call_GetAudioSourceConfiguration                = sdk.Call[media.GetAudioSourceConfiguration, media.GetAudioSourceConfigurationResponse]
+	Call_GetAudioSourceConfigurationOptions         = sdk.Call[media.GetAudioSourceConfigurationOptions, media.GetAudioSourceConfigurationOptionsResponse]
+	Call_GetAudioSourceConfigurations               = sdk.Call[media.GetAudioSourceConfigurations, media.GetAudioSourceConfigurationsResponse]
+	Call_GetAudioSources                            = sdk.Call[media.GetAudioSources, media.GetAudioSourcesResponse]
+	Call_GetCompatibleAudioDecoderConfigurations    = sdk.Call[media.GetCompatibleAudioDecoderConfigurations, media.GetCompatibleAudioDecoderConfigurationsResponse]
+	Call_GetCompatibleAudioEncoderConfigurations    = sdk.Call[media.GetCompatibleAudioEncoderConfigurations, media.GetCompatibleAudioEncoderConfigurationsResponse]
+	Call_GetCompatibleAudioOutputConfigurations     = sdk.Call[media.GetCompatibleAudioOutputConfigurations, media.GetCompatibleAudioOutputConfigurationsResponse]
+	Call_GetCompatibleAudioSourceConfigurations     = sdk.Call[media.GetCompatibleAudioSourceConfigurations, media.GetCompatibleAudioSourceConfigurationsResponse]
+	Call_GetCompatibleMetadataConfigurations        = sdk.Call[media.GetCompatibleMetadataConfigurations, media.GetCompatibleMetadataConfigurationsResponse]
+	Call_GetCompatibleVideoAnalyticsConfigurations  = sdk.Call[media.GetCompatibleVideoAnalyticsConfigurations, media.GetCompatibleVideoAnalyticsConfigurationsResponse]
+	Call_GetCompatibleVideoEncoderConfigurations    = sdk.Call[media.GetCompatibleVideoEncoderConfigurations, media.GetCompatibleVideoEncoderConfigurationsResponse]
+	Call_GetCompatibleVideoSourceConfigurations     = sdk.Call[media.GetCompatibleVideoSourceConfigurations, media.GetCompatibleVideoSourceConfigurationsResponse]
+	Call_GetGuaranteedNumberOfVideoEncoderInstances = sdk.Call[media.GetGuaranteedNumberOfVideoEncoderInstances, media.GetGuaranteedNumberOfVideoEncoderInstancesResponse]
+	Call_GetMetadataConfiguration                   = sdk.Call[media.GetMetadataConfiguration, media.GetMetadataConfigurationResponse]
+	Call_GetMetadataConfigurationOptions            = sdk.Call[media.GetMetadataConfigurationOptions, media.GetMetadataConfigurationOptionsResponse]
+	Call_GetMetadataConfigurations                  = sdk.Call[media.GetMetadataConfigurations, media.GetMetadataConfigurationsResponse]
+	Call_GetOSD                                     = sdk.Call[media.GetOSD, media.GetOSDResponse]
+	Call_GetOSDOptions                              = sdk.Call[media.GetOSDOptions, media.GetOSDOptionsResponse]
+	Call_GetOSDs                                    = sdk.Call[media.GetOSDs, media.GetOSDsResponse]
+	Call_GetProfile                                 = sdk.Call[media.GetProfile, media.GetProfileResponse]
+	Call_GetProfiles                                = sdk.Call[media.GetProfiles, media.GetProfilesResponse]
+	Call_GetServiceCapabilities                     = sdk.Call[media.GetServiceCapabilities, media.GetServiceCapabilitiesResponse]
+	Call_GetSnapshotUri                             = sdk.Call[media.GetSnapshotUri, media.GetSnapshotUriResponse]
+	Call_GetStreamUri                               = sdk.Call[media.GetStreamUri, media.GetStreamUriResponse]
+	Call_GetVideoAnalyticsConfiguration             = sdk.Call[media.GetVideoAnalyticsConfiguration, media.GetVideoAnalyticsConfigurationResponse]
+	Call_GetVideoAnalyticsConfigurations            = sdk.Call[media.GetVideoAnalyticsConfigurations, media.GetVideoAnalyticsConfigurationsResponse]
+	Call_GetVideoEncoderConfiguration               = sdk.Call[media.GetVideoEncoderConfiguration, media.GetVideoEncoderConfigurationResponse]
+	Call_GetVideoEncoderConfigurationOptions        = sdk.Call[media.GetVideoEncoderConfigurationOptions, media.GetVideoEncoderConfigurationOptionsResponse]
+	Call_GetVideoEncoderConfigurations              = sdk.Call[media.GetVideoEncoderConfigurations, media.GetVideoEncoderConfigurationsResponse]
+	Call_GetVideoSourceConfiguration                = sdk.Call[media.GetVideoSourceConfiguration, media.GetVideoSourceConfigurationResponse]
+	Call_GetVideoSourceConfigurationOptions         = sdk.Call[media.GetVideoSourceConfigurationOptions, media.GetVideoSourceConfigurationOptionsResponse]
+	Call_GetVideoSourceConfigurations               = sdk.Call[media.GetVideoSourceConfigurations, media.GetVideoSourceConfigurationsResponse]
+	Call_GetVideoSourceModes                        = sdk.Call[media.GetVideoSourceModes, media.GetVideoSourceModesResponse]
+	Call_GetVideoSources                            = sdk.Call[media.GetVideoSources, media.GetVideoSourcesResponse]
+	Call_RemoveAudioDecoderConfiguration            = sdk.Call[media.RemoveAudioDecoderConfiguration, media.RemoveAudioDecoderConfigurationResponse]
+	Call_RemoveAudioEncoderConfiguration            = sdk.Call[media.RemoveAudioEncoderConfiguration, media.RemoveAudioEncoderConfigurationResponse]
+	Call_RemoveAudioOutputConfiguration             = sdk.Call[media.RemoveAudioOutputConfiguration, media.RemoveAudioOutputConfigurationResponse]
+	Call_RemoveAudioSourceConfiguration             = sdk.Call[media.RemoveAudioSourceConfiguration, media.RemoveAudioSourceConfigurationResponse]
+	Call_RemoveMetadataConfiguration                = sdk.Call[media.RemoveMetadataConfiguration, media.RemoveMetadataConfigurationResponse]
+	Call_RemovePTZConfiguration                     = sdk.Call[media.RemovePTZConfiguration, media.RemovePTZConfigurationResponse]
+	Call_RemoveVideoAnalyticsConfiguration          = sdk.Call[media.RemoveVideoAnalyticsConfiguration, media.RemoveVideoAnalyticsConfigurationResponse]
+	Call_RemoveVideoEncoderConfiguration            = sdk.Call[media.RemoveVideoEncoderConfiguration, media.RemoveVideoEncoderConfigurationResponse]
+	Call_RemoveVideoSourceConfiguration             = sdk.Call[media.RemoveVideoSourceConfiguration, media.RemoveVideoSourceConfigurationResponse]
+	Call_SetAudioDecoderConfiguration               = sdk.Call[media.SetAudioDecoderConfiguration, media.SetAudioDecoderConfigurationResponse]
+	Call_SetAudioEncoderConfiguration               = sdk.Call[media.SetAudioEncoderConfiguration, media.SetAudioEncoderConfigurationResponse]
+	Call_SetAudioOutputConfiguration                = sdk.Call[media.SetAudioOutputConfiguration, media.SetAudioOutputConfigurationResponse]
+	Call_SetAudioSourceConfiguration                = sdk.Call[media.SetAudioSourceConfiguration, media.SetAudioSourceConfigurationResponse]
+	Call_SetMetadataConfiguration                   = sdk.Call[media.SetMetadataConfiguration, media.SetMetadataConfigurationResponse]
+	Call_SetOSD                                     = sdk.Call[media.SetOSD, media.SetOSDResponse]
+	Call_SetSynchronizationPoint                    = sdk.Call[media.SetSynchronizationPoint, media.SetSynchronizationPointResponse]
+	Call_SetVideoAnalyticsConfiguration             = sdk.Call[media.SetVideoAnalyticsConfiguration, media.SetVideoAnalyticsConfigurationResponse]
+	Call_SetVideoEncoderConfiguration               = sdk.Call[media.SetVideoEncoderConfiguration, media.SetVideoEncoderConfigurationResponse]
+	Call_SetVideoSourceConfiguration                = sdk.Call[media.SetVideoSourceConfiguration, media.SetVideoSourceConfigurationResponse]
+	Call_SetVideoSourceMode                         = sdk.Call[media.SetVideoSourceMode, media.SetVideoSourceModeResponse]
+	Call_StartMulticastStreaming                    = sdk.Call[media.StartMulticastStreaming, media.StartMulticastStreamingResponse]
+	Call_StopMulticastStreaming                     = sdk.Call[media.StopMulticastStreaming, media.StopMulticastStreamingResponse]
+)
@@ -0,0 +1,579 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package media
+
+import (
+	"context"
+	goonvif "github.com/ritj/onvif"
+	"github.com/ritj/onvif/media"
+	"github.com/ritj/onvif/xsd"
+	"github.com/ritj/onvif/xsd/onvif"
+)
+
+// Client exposes the operations of the media service of a device.
+// It can be replaced by a fake in the tests of the code that depends on it.
+type Client interface {
+	AddAudioDecoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error
+	AddAudioEncoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error
+	AddAudioOutputConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error
+	AddAudioSourceConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error
+	AddMetadataConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error
+	AddPTZConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error
+	AddVideoAnalyticsConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error
+	AddVideoEncoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error
+	AddVideoSourceConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error
+	CreateOSD(ctx context.Context, osd onvif.OSDConfiguration) (onvif.ReferenceToken, error)
+	CreateProfile(ctx context.Context, name onvif.Name, token onvif.ReferenceToken) (onvif.Profile, error)
+	DeleteOSD(ctx context.Context, osdToken onvif.ReferenceToken) error
+	DeleteProfile(ctx context.Context, profileToken onvif.ReferenceToken) error
+	GetAudioDecoderConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.AudioDecoderConfiguration, error)
+	GetAudioDecoderConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.AudioDecoderConfigurationOptions, error)
+	GetAudioDecoderConfigurations(ctx context.Context) (onvif.AudioDecoderConfiguration, error)
+	GetAudioEncoderConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.AudioEncoderConfiguration, error)
+	GetAudioEncoderConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.AudioEncoderConfigurationOptions, error)
+	GetAudioEncoderConfigurations(ctx context.Context) (onvif.AudioEncoderConfiguration, error)
+	GetAudioOutputConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.AudioOutputConfiguration, error)
+	GetAudioOutputConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.AudioOutputConfigurationOptions, error)
+	GetAudioOutputConfigurations(ctx context.Context) (onvif.AudioOutputConfiguration, error)
+	GetAudioOutputs(ctx context.Context) (onvif.AudioOutput, error)
+	GetAudioSourceConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.AudioSourceConfiguration, error)
+	GetAudioSourceConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.AudioSourceConfigurationOptions, error)
+	GetAudioSourceConfigurations(ctx context.Context) (onvif.AudioSourceConfiguration, error)
+	GetAudioSources(ctx context.Context) (onvif.AudioSource, error)
+	GetCompatibleAudioDecoderConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.AudioDecoderConfiguration, error)
+	GetCompatibleAudioEncoderConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.AudioEncoderConfiguration, error)
+	GetCompatibleAudioOutputConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.AudioOutputConfiguration, error)
+	GetCompatibleAudioSourceConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.AudioSourceConfiguration, error)
+	GetCompatibleMetadataConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.MetadataConfiguration, error)
+	GetCompatibleVideoAnalyticsConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.VideoAnalyticsConfiguration, error)
+	GetCompatibleVideoEncoderConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.VideoEncoderConfiguration, error)
+	GetCompatibleVideoSourceConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.VideoSourceConfiguration, error)
+	GetGuaranteedNumberOfVideoEncoderInstances(ctx context.Context, configurationToken onvif.ReferenceToken) (media.GetGuaranteedNumberOfVideoEncoderInstancesResponse, error)
+	GetMetadataConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.MetadataConfiguration, error)
+	GetMetadataConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.MetadataConfigurationOptions, error)
+	GetMetadataConfigurations(ctx context.Context) (onvif.MetadataConfiguration, error)
+	GetOSD(ctx context.Context, osdToken onvif.ReferenceToken) (onvif.OSDConfiguration, error)
+	GetOSDOptions(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.OSDConfigurationOptions, error)
+	GetOSDs(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.OSDConfiguration, error)
+	GetProfile(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.Profile, error)
+	GetProfiles(ctx context.Context) ([]onvif.Profile, error)
+	GetServiceCapabilities(ctx context.Context) (media.Capabilities, error)
+	GetSnapshotUri(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.MediaUri, error)
+	GetStreamUri(ctx context.Context, streamSetup onvif.StreamSetup, profileToken onvif.ReferenceToken) (onvif.MediaUri, error)
+	GetVideoAnalyticsConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.VideoAnalyticsConfiguration, error)
+	GetVideoAnalyticsConfigurations(ctx context.Context) (onvif.VideoAnalyticsConfiguration, error)
+	GetVideoEncoderConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.VideoEncoderConfiguration, error)
+	GetVideoEncoderConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.VideoEncoderConfigurationOptions, error)
+	GetVideoEncoderConfigurations(ctx context.Context) ([]onvif.VideoEncoderConfiguration, error)
+	GetVideoSourceConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.VideoSourceConfiguration, error)
+	GetVideoSourceConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.VideoSourceConfigurationOptions, error)
+	GetVideoSourceConfigurations(ctx context.Context) (onvif.VideoSourceConfiguration, error)
+	GetVideoSourceModes(ctx context.Context, videoSourceToken onvif.ReferenceToken) (onvif.VideoSourceMode, error)
+	GetVideoSources(ctx context.Context) (onvif.VideoSource, error)
+	RemoveAudioDecoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error
+	RemoveAudioEncoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error
+	RemoveAudioOutputConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error
+	RemoveAudioSourceConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error
+	RemoveMetadataConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error
+	RemovePTZConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error
+	RemoveVideoAnalyticsConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error
+	RemoveVideoEncoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error
+	RemoveVideoSourceConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error
+	SetAudioDecoderConfiguration(ctx context.Context, configuration onvif.AudioDecoderConfiguration, forcePersistence xsd.Boolean) error
+	SetAudioEncoderConfiguration(ctx context.Context, configuration onvif.AudioEncoderConfiguration, forcePersistence xsd.Boolean) error
+	SetAudioOutputConfiguration(ctx context.Context, configuration onvif.AudioOutputConfiguration, forcePersistence bool) error
+	SetAudioSourceConfiguration(ctx context.Context, configuration onvif.AudioSourceConfiguration, forcePersistence xsd.Boolean) error
+	SetMetadataConfiguration(ctx context.Context, configuration onvif.MetadataConfiguration, forcePersistence xsd.Boolean) error
+	SetOSD(ctx context.Context, osd onvif.OSDConfiguration) error
+	SetSynchronizationPoint(ctx context.Context, profileToken onvif.ReferenceToken) error
+	SetVideoAnalyticsConfiguration(ctx context.Context, configuration onvif.VideoAnalyticsConfiguration, forcePersistence bool) error
+	SetVideoEncoderConfiguration(ctx context.Context, configuration onvif.VideoEncoderConfiguration, forcePersistence xsd.Boolean) error
+	SetVideoSourceConfiguration(ctx context.Context, configuration onvif.VideoSourceConfiguration, forcePersistence xsd.Boolean) error
+	SetVideoSourceMode(ctx context.Context, videoSourceToken onvif.ReferenceToken, videoSourceModeToken onvif.ReferenceToken) (bool, error)
+	StartMulticastStreaming(ctx context.Context, profileToken onvif.ReferenceToken) error
+	StopMulticastStreaming(ctx context.Context, profileToken onvif.ReferenceToken) error
+}
+
+// NewClient returns a Client bound to dev.
+func NewClient(dev *goonvif.Device) Client {
+	return &client{dev: dev}
+}
+
+type client struct {
+	dev *goonvif.Device
+}
+
+// AddAudioDecoderConfiguration calls the AddAudioDecoderConfiguration operation.
+func (c *client) AddAudioDecoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error {
+	_, err := Call_AddAudioDecoderConfiguration(ctx, c.dev, media.AddAudioDecoderConfiguration{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return err
+}
+
+// AddAudioEncoderConfiguration calls the AddAudioEncoderConfiguration operation.
+func (c *client) AddAudioEncoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error {
+	_, err := Call_AddAudioEncoderConfiguration(ctx, c.dev, media.AddAudioEncoderConfiguration{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return err
+}
+
+// AddAudioOutputConfiguration calls the AddAudioOutputConfiguration operation.
+func (c *client) AddAudioOutputConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error {
+	_, err := Call_AddAudioOutputConfiguration(ctx, c.dev, media.AddAudioOutputConfiguration{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return err
+}
+
+// AddAudioSourceConfiguration calls the AddAudioSourceConfiguration operation.
+func (c *client) AddAudioSourceConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error {
+	_, err := Call_AddAudioSourceConfiguration(ctx, c.dev, media.AddAudioSourceConfiguration{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return err
+}
+
+// AddMetadataConfiguration calls the AddMetadataConfiguration operation.
+func (c *client) AddMetadataConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error {
+	_, err := Call_AddMetadataConfiguration(ctx, c.dev, media.AddMetadataConfiguration{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return err
+}
+
+// AddPTZConfiguration calls the AddPTZConfiguration operation.
+func (c *client) AddPTZConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error {
+	_, err := Call_AddPTZConfiguration(ctx, c.dev, media.AddPTZConfiguration{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return err
+}
+
+// AddVideoAnalyticsConfiguration calls the AddVideoAnalyticsConfiguration operation.
+func (c *client) AddVideoAnalyticsConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error {
+	_, err := Call_AddVideoAnalyticsConfiguration(ctx, c.dev, media.AddVideoAnalyticsConfiguration{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return err
+}
+
+// AddVideoEncoderConfiguration calls the AddVideoEncoderConfiguration operation.
+func (c *client) AddVideoEncoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error {
+	_, err := Call_AddVideoEncoderConfiguration(ctx, c.dev, media.AddVideoEncoderConfiguration{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return err
+}
+
+// AddVideoSourceConfiguration calls the AddVideoSourceConfiguration operation.
+func (c *client) AddVideoSourceConfiguration(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) error {
+	_, err := Call_AddVideoSourceConfiguration(ctx, c.dev, media.AddVideoSourceConfiguration{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return err
+}
+
+// CreateOSD calls the CreateOSD operation.
+func (c *client) CreateOSD(ctx context.Context, osd onvif.OSDConfiguration) (onvif.ReferenceToken, error) {
+	reply, err := Call_CreateOSD(ctx, c.dev, media.CreateOSD{OSD: osd})
+	return reply.OSDToken, err
+}
+
+// CreateProfile calls the CreateProfile operation.
+func (c *client) CreateProfile(ctx context.Context, name onvif.Name, token onvif.ReferenceToken) (onvif.Profile, error) {
+	reply, err := Call_CreateProfile(ctx, c.dev, media.CreateProfile{Name: name, Token: token})
+	return reply.Profile, err
+}
+
+// DeleteOSD calls the DeleteOSD operation.
+func (c *client) DeleteOSD(ctx context.Context, osdToken onvif.ReferenceToken) error {
+	_, err := Call_DeleteOSD(ctx, c.dev, media.DeleteOSD{OSDToken: osdToken})
+	return err
+}
+
+// DeleteProfile calls the DeleteProfile operation.
+func (c *client) DeleteProfile(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_DeleteProfile(ctx, c.dev, media.DeleteProfile{ProfileToken: profileToken})
+	return err
+}
+
+// GetAudioDecoderConfiguration calls the GetAudioDecoderConfiguration operation.
+func (c *client) GetAudioDecoderConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.AudioDecoderConfiguration, error) {
+	reply, err := Call_GetAudioDecoderConfiguration(ctx, c.dev, media.GetAudioDecoderConfiguration{ConfigurationToken: configurationToken})
+	return reply.Configuration, err
+}
+
+// GetAudioDecoderConfigurationOptions calls the GetAudioDecoderConfigurationOptions operation.
+func (c *client) GetAudioDecoderConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.AudioDecoderConfigurationOptions, error) {
+	reply, err := Call_GetAudioDecoderConfigurationOptions(ctx, c.dev, media.GetAudioDecoderConfigurationOptions{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return reply.Options, err
+}
+
+// GetAudioDecoderConfigurations calls the GetAudioDecoderConfigurations operation.
+func (c *client) GetAudioDecoderConfigurations(ctx context.Context) (onvif.AudioDecoderConfiguration, error) {
+	reply, err := Call_GetAudioDecoderConfigurations(ctx, c.dev, media.GetAudioDecoderConfigurations{})
+	return reply.Configurations, err
+}
+
+// GetAudioEncoderConfiguration calls the GetAudioEncoderConfiguration operation.
+func (c *client) GetAudioEncoderConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.AudioEncoderConfiguration, error) {
+	reply, err := Call_GetAudioEncoderConfiguration(ctx, c.dev, media.GetAudioEncoderConfiguration{ConfigurationToken: configurationToken})
+	return reply.Configuration, err
+}
+
+// GetAudioEncoderConfigurationOptions calls the GetAudioEncoderConfigurationOptions operation.
+func (c *client) GetAudioEncoderConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.AudioEncoderConfigurationOptions, error) {
+	reply, err := Call_GetAudioEncoderConfigurationOptions(ctx, c.dev, media.GetAudioEncoderConfigurationOptions{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return reply.Options, err
+}
+
+// GetAudioEncoderConfigurations calls the GetAudioEncoderConfigurations operation.
+func (c *client) GetAudioEncoderConfigurations(ctx context.Context) (onvif.AudioEncoderConfiguration, error) {
+	reply, err := Call_GetAudioEncoderConfigurations(ctx, c.dev, media.GetAudioEncoderConfigurations{})
+	return reply.Configurations, err
+}
+
+// GetAudioOutputConfiguration calls the GetAudioOutputConfiguration operation.
+func (c *client) GetAudioOutputConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.AudioOutputConfiguration, error) {
+	reply, err := Call_GetAudioOutputConfiguration(ctx, c.dev, media.GetAudioOutputConfiguration{ConfigurationToken: configurationToken})
+	return reply.Configuration, err
+}
+
+// GetAudioOutputConfigurationOptions calls the GetAudioOutputConfigurationOptions operation.
+func (c *client) GetAudioOutputConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.AudioOutputConfigurationOptions, error) {
+	reply, err := Call_GetAudioOutputConfigurationOptions(ctx, c.dev, media.GetAudioOutputConfigurationOptions{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return reply.Options, err
+}
+
+// GetAudioOutputConfigurations calls the GetAudioOutputConfigurations operation.
+func (c *client) GetAudioOutputConfigurations(ctx context.Context) (onvif.AudioOutputConfiguration, error) {
+	reply, err := Call_GetAudioOutputConfigurations(ctx, c.dev, media.GetAudioOutputConfigurations{})
+	return reply.Configurations, err
+}
+
+// GetAudioOutputs calls the GetAudioOutputs operation.
+func (c *client) GetAudioOutputs(ctx context.Context) (onvif.AudioOutput, error) {
+	reply, err := Call_GetAudioOutputs(ctx, c.dev, media.GetAudioOutputs{})
+	return reply.AudioOutputs, err
+}
+
+// GetAudioSourceConfiguration calls the GetAudioSourceConfiguration operation.
+func (c *client) GetAudioSourceConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.AudioSourceConfiguration, error) {
+	reply, err := Call_GetAudioSourceConfiguration(ctx, c.dev, media.GetAudioSourceConfiguration{ConfigurationToken: configurationToken})
+	return reply.Configuration, err
+}
+
+// GetAudioSourceConfigurationOptions calls the GetAudioSourceConfigurationOptions operation.
+func (c *client) GetAudioSourceConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.AudioSourceConfigurationOptions, error) {
+	reply, err := Call_GetAudioSourceConfigurationOptions(ctx, c.dev, media.GetAudioSourceConfigurationOptions{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return reply.Options, err
+}
+
+// GetAudioSourceConfigurations calls the GetAudioSourceConfigurations operation.
+func (c *client) GetAudioSourceConfigurations(ctx context.Context) (onvif.AudioSourceConfiguration, error) {
+	reply, err := Call_GetAudioSourceConfigurations(ctx, c.dev, media.GetAudioSourceConfigurations{})
+	return reply.Configurations, err
+}
+
+// GetAudioSources calls the GetAudioSources operation.
+func (c *client) GetAudioSources(ctx context.Context) (onvif.AudioSource, error) {
+	reply, err := Call_GetAudioSources(ctx, c.dev, media.GetAudioSources{})
+	return reply.AudioSources, err
+}
+
+// GetCompatibleAudioDecoderConfigurations calls the GetCompatibleAudioDecoderConfigurations operation.
+func (c *client) GetCompatibleAudioDecoderConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.AudioDecoderConfiguration, error) {
+	reply, err := Call_GetCompatibleAudioDecoderConfigurations(ctx, c.dev, media.GetCompatibleAudioDecoderConfigurations{ProfileToken: profileToken})
+	return reply.Configurations, err
+}
+
+// GetCompatibleAudioEncoderConfigurations calls the GetCompatibleAudioEncoderConfigurations operation.
+func (c *client) GetCompatibleAudioEncoderConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.AudioEncoderConfiguration, error) {
+	reply, err := Call_GetCompatibleAudioEncoderConfigurations(ctx, c.dev, media.GetCompatibleAudioEncoderConfigurations{ProfileToken: profileToken})
+	return reply.Configurations, err
+}
+
+// GetCompatibleAudioOutputConfigurations calls the GetCompatibleAudioOutputConfigurations operation.
+func (c *client) GetCompatibleAudioOutputConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.AudioOutputConfiguration, error) {
+	reply, err := Call_GetCompatibleAudioOutputConfigurations(ctx, c.dev, media.GetCompatibleAudioOutputConfigurations{ProfileToken: profileToken})
+	return reply.Configurations, err
+}
+
+// GetCompatibleAudioSourceConfigurations calls the GetCompatibleAudioSourceConfigurations operation.
+func (c *client) GetCompatibleAudioSourceConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.AudioSourceConfiguration, error) {
+	reply, err := Call_GetCompatibleAudioSourceConfigurations(ctx, c.dev, media.GetCompatibleAudioSourceConfigurations{ProfileToken: profileToken})
+	return reply.Configurations, err
+}
+
+// GetCompatibleMetadataConfigurations calls the GetCompatibleMetadataConfigurations operation.
+func (c *client) GetCompatibleMetadataConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.MetadataConfiguration, error) {
+	reply, err := Call_GetCompatibleMetadataConfigurations(ctx, c.dev, media.GetCompatibleMetadataConfigurations{ProfileToken: profileToken})
+	return reply.Configurations, err
+}
+
+// GetCompatibleVideoAnalyticsConfigurations calls the GetCompatibleVideoAnalyticsConfigurations operation.
+func (c *client) GetCompatibleVideoAnalyticsConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.VideoAnalyticsConfiguration, error) {
+	reply, err := Call_GetCompatibleVideoAnalyticsConfigurations(ctx, c.dev, media.GetCompatibleVideoAnalyticsConfigurations{ProfileToken: profileToken})
+	return reply.Configurations, err
+}
+
+// GetCompatibleVideoEncoderConfigurations calls the GetCompatibleVideoEncoderConfigurations operation.
+func (c *client) GetCompatibleVideoEncoderConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.VideoEncoderConfiguration, error) {
+	reply, err := Call_GetCompatibleVideoEncoderConfigurations(ctx, c.dev, media.GetCompatibleVideoEncoderConfigurations{ProfileToken: profileToken})
+	return reply.Configurations, err
+}
+
+// GetCompatibleVideoSourceConfigurations calls the GetCompatibleVideoSourceConfigurations operation.
+func (c *client) GetCompatibleVideoSourceConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.VideoSourceConfiguration, error) {
+	reply, err := Call_GetCompatibleVideoSourceConfigurations(ctx, c.dev, media.GetCompatibleVideoSourceConfigurations{ProfileToken: profileToken})
+	return reply.Configurations, err
+}
+
+// GetGuaranteedNumberOfVideoEncoderInstances calls the GetGuaranteedNumberOfVideoEncoderInstances operation.
+func (c *client) GetGuaranteedNumberOfVideoEncoderInstances(ctx context.Context, configurationToken onvif.ReferenceToken) (media.GetGuaranteedNumberOfVideoEncoderInstancesResponse, error) {
+	return Call_GetGuaranteedNumberOfVideoEncoderInstances(ctx, c.dev, media.GetGuaranteedNumberOfVideoEncoderInstances{ConfigurationToken: configurationToken})
+}
+
+// GetMetadataConfiguration calls the GetMetadataConfiguration operation.
+func (c *client) GetMetadataConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.MetadataConfiguration, error) {
+	reply, err := Call_GetMetadataConfiguration(ctx, c.dev, media.GetMetadataConfiguration{ConfigurationToken: configurationToken})
+	return reply.Configuration, err
+}
+
+// GetMetadataConfigurationOptions calls the GetMetadataConfigurationOptions operation.
+func (c *client) GetMetadataConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.MetadataConfigurationOptions, error) {
+	reply, err := Call_GetMetadataConfigurationOptions(ctx, c.dev, media.GetMetadataConfigurationOptions{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return reply.Options, err
+}
+
+// GetMetadataConfigurations calls the GetMetadataConfigurations operation.
+func (c *client) GetMetadataConfigurations(ctx context.Context) (onvif.MetadataConfiguration, error) {
+	reply, err := Call_GetMetadataConfigurations(ctx, c.dev, media.GetMetadataConfigurations{})
+	return reply.Configurations, err
+}
+
+// GetOSD calls the GetOSD operation.
+func (c *client) GetOSD(ctx context.Context, osdToken onvif.ReferenceToken) (onvif.OSDConfiguration, error) {
+	reply, err := Call_GetOSD(ctx, c.dev, media.GetOSD{OSDToken: osdToken})
+	return reply.OSD, err
+}
+
+// GetOSDOptions calls the GetOSDOptions operation.
+func (c *client) GetOSDOptions(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.OSDConfigurationOptions, error) {
+	reply, err := Call_GetOSDOptions(ctx, c.dev, media.GetOSDOptions{ConfigurationToken: configurationToken})
+	return reply.OSDOptions, err
+}
+
+// GetOSDs calls the GetOSDs operation.
+func (c *client) GetOSDs(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.OSDConfiguration, error) {
+	reply, err := Call_GetOSDs(ctx, c.dev, media.GetOSDs{ConfigurationToken: configurationToken})
+	return reply.OSDs, err
+}
+
+// GetProfile calls the GetProfile operation.
+func (c *client) GetProfile(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.Profile, error) {
+	reply, err := Call_GetProfile(ctx, c.dev, media.GetProfile{ProfileToken: profileToken})
+	return reply.Profile, err
+}
+
+// GetProfiles calls the GetProfiles operation.
+func (c *client) GetProfiles(ctx context.Context) ([]onvif.Profile, error) {
+	reply, err := Call_GetProfiles(ctx, c.dev, media.GetProfiles{})
+	return reply.Profiles, err
+}
+
+// GetServiceCapabilities calls the GetServiceCapabilities operation.
+func (c *client) GetServiceCapabilities(ctx context.Context) (media.Capabilities, error) {
+	reply, err := Call_GetServiceCapabilities(ctx, c.dev, media.GetServiceCapabilities{})
+	return reply.Capabilities, err
+}
+
+// GetSnapshotUri calls the GetSnapshotUri operation.
+func (c *client) GetSnapshotUri(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.MediaUri, error) {
+	reply, err := Call_GetSnapshotUri(ctx, c.dev, media.GetSnapshotUri{ProfileToken: profileToken})
+	return reply.MediaUri, err
+}
+
+// GetStreamUri calls the GetStreamUri operation.
+func (c *client) GetStreamUri(ctx context.Context, streamSetup onvif.StreamSetup, profileToken onvif.ReferenceToken) (onvif.MediaUri, error) {
+	reply, err := Call_GetStreamUri(ctx, c.dev, media.GetStreamUri{StreamSetup: streamSetup, ProfileToken: profileToken})
+	return reply.MediaUri, err
+}
+
+// GetVideoAnalyticsConfiguration calls the GetVideoAnalyticsConfiguration operation.
+func (c *client) GetVideoAnalyticsConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.VideoAnalyticsConfiguration, error) {
+	reply, err := Call_GetVideoAnalyticsConfiguration(ctx, c.dev, media.GetVideoAnalyticsConfiguration{ConfigurationToken: configurationToken})
+	return reply.Configuration, err
+}
+
+// GetVideoAnalyticsConfigurations calls the GetVideoAnalyticsConfigurations operation.
+func (c *client) GetVideoAnalyticsConfigurations(ctx context.Context) (onvif.VideoAnalyticsConfiguration, error) {
+	reply, err := Call_GetVideoAnalyticsConfigurations(ctx, c.dev, media.GetVideoAnalyticsConfigurations{})
+	return reply.Configurations, err
+}
+
+// GetVideoEncoderConfiguration calls the GetVideoEncoderConfiguration operation.
+func (c *client) GetVideoEncoderConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.VideoEncoderConfiguration, error) {
+	reply, err := Call_GetVideoEncoderConfiguration(ctx, c.dev, media.GetVideoEncoderConfiguration{ConfigurationToken: configurationToken})
+	return reply.Configuration, err
+}
+
+// GetVideoEncoderConfigurationOptions calls the GetVideoEncoderConfigurationOptions operation.
+func (c *client) GetVideoEncoderConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.VideoEncoderConfigurationOptions, error) {
+	reply, err := Call_GetVideoEncoderConfigurationOptions(ctx, c.dev, media.GetVideoEncoderConfigurationOptions{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return reply.Options, err
+}
+
+// GetVideoEncoderConfigurations calls the GetVideoEncoderConfigurations operation.
+func (c *client) GetVideoEncoderConfigurations(ctx context.Context) ([]onvif.VideoEncoderConfiguration, error) {
+	reply, err := Call_GetVideoEncoderConfigurations(ctx, c.dev, media.GetVideoEncoderConfigurations{})
+	return reply.Configurations, err
+}
+
+// GetVideoSourceConfiguration calls the GetVideoSourceConfiguration operation.
+func (c *client) GetVideoSourceConfiguration(ctx context.Context, configurationToken onvif.ReferenceToken) (onvif.VideoSourceConfiguration, error) {
+	reply, err := Call_GetVideoSourceConfiguration(ctx, c.dev, media.GetVideoSourceConfiguration{ConfigurationToken: configurationToken})
+	return reply.Configuration, err
+}
+
+// GetVideoSourceConfigurationOptions calls the GetVideoSourceConfigurationOptions operation.
+func (c *client) GetVideoSourceConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken, configurationToken onvif.ReferenceToken) (onvif.VideoSourceConfigurationOptions, error) {
+	reply, err := Call_GetVideoSourceConfigurationOptions(ctx, c.dev, media.GetVideoSourceConfigurationOptions{ProfileToken: profileToken, ConfigurationToken: configurationToken})
+	return reply.Options, err
+}
+
+// GetVideoSourceConfigurations calls the GetVideoSourceConfigurations operation.
+func (c *client) GetVideoSourceConfigurations(ctx context.Context) (onvif.VideoSourceConfiguration, error) {
+	reply, err := Call_GetVideoSourceConfigurations(ctx, c.dev, media.GetVideoSourceConfigurations{})
+	return reply.Configurations, err
+}
+
+// GetVideoSourceModes calls the GetVideoSourceModes operation.
+func (c *client) GetVideoSourceModes(ctx context.Context, videoSourceToken onvif.ReferenceToken) (onvif.VideoSourceMode, error) {
+	reply, err := Call_GetVideoSourceModes(ctx, c.dev, media.GetVideoSourceModes{VideoSourceToken: videoSourceToken})
+	return reply.VideoSourceModes, err
+}
+
+// GetVideoSources calls the GetVideoSources operation.
+func (c *client) GetVideoSources(ctx context.Context) (onvif.VideoSource, error) {
+	reply, err := Call_GetVideoSources(ctx, c.dev, media.GetVideoSources{})
+	return reply.VideoSources, err
+}
+
+// RemoveAudioDecoderConfiguration calls the RemoveAudioDecoderConfiguration operation.
+func (c *client) RemoveAudioDecoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_RemoveAudioDecoderConfiguration(ctx, c.dev, media.RemoveAudioDecoderConfiguration{ProfileToken: profileToken})
+	return err
+}
+
+// RemoveAudioEncoderConfiguration calls the RemoveAudioEncoderConfiguration operation.
+func (c *client) RemoveAudioEncoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_RemoveAudioEncoderConfiguration(ctx, c.dev, media.RemoveAudioEncoderConfiguration{ProfileToken: profileToken})
+	return err
+}
+
+// RemoveAudioOutputConfiguration calls the RemoveAudioOutputConfiguration operation.
+func (c *client) RemoveAudioOutputConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_RemoveAudioOutputConfiguration(ctx, c.dev, media.RemoveAudioOutputConfiguration{ProfileToken: profileToken})
+	return err
+}
+
+// RemoveAudioSourceConfiguration calls the RemoveAudioSourceConfiguration operation.
+func (c *client) RemoveAudioSourceConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_RemoveAudioSourceConfiguration(ctx, c.dev, media.RemoveAudioSourceConfiguration{ProfileToken: profileToken})
+	return err
+}
+
+// RemoveMetadataConfiguration calls the RemoveMetadataConfiguration operation.
+func (c *client) RemoveMetadataConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_RemoveMetadataConfiguration(ctx, c.dev, media.RemoveMetadataConfiguration{ProfileToken: profileToken})
+	return err
+}
+
+// RemovePTZConfiguration calls the RemovePTZConfiguration operation.
+func (c *client) RemovePTZConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_RemovePTZConfiguration(ctx, c.dev, media.RemovePTZConfiguration{ProfileToken: profileToken})
+	return err
+}
+
+// RemoveVideoAnalyticsConfiguration calls the RemoveVideoAnalyticsConfiguration operation.
+func (c *client) RemoveVideoAnalyticsConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_RemoveVideoAnalyticsConfiguration(ctx, c.dev, media.RemoveVideoAnalyticsConfiguration{ProfileToken: profileToken})
+	return err
+}
+
+// RemoveVideoEncoderConfiguration calls the RemoveVideoEncoderConfiguration operation.
+func (c *client) RemoveVideoEncoderConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_RemoveVideoEncoderConfiguration(ctx, c.dev, media.RemoveVideoEncoderConfiguration{ProfileToken: profileToken})
+	return err
+}
+
+// RemoveVideoSourceConfiguration calls the RemoveVideoSourceConfiguration operation.
+func (c *client) RemoveVideoSourceConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_RemoveVideoSourceConfiguration(ctx, c.dev, media.RemoveVideoSourceConfiguration{ProfileToken: profileToken})
+	return err
+}
+
+// SetAudioDecoderConfiguration calls the SetAudioDecoderConfiguration operation.
+func (c *client) SetAudioDecoderConfiguration(ctx context.Context, configuration onvif.AudioDecoderConfiguration, forcePersistence xsd.Boolean) error {
+	_, err := Call_SetAudioDecoderConfiguration(ctx, c.dev, media.SetAudioDecoderConfiguration{Configuration: configuration, ForcePersistence: forcePersistence})
+	return err
+}
+
+// SetAudioEncoderConfiguration calls the SetAudioEncoderConfiguration operation.
+func (c *client) SetAudioEncoderConfiguration(ctx context.Context, configuration onvif.AudioEncoderConfiguration, forcePersistence xsd.Boolean) error {
+	_, err := Call_SetAudioEncoderConfiguration(ctx, c.dev, media.SetAudioEncoderConfiguration{Configuration: configuration, ForcePersistence: forcePersistence})
+	return err
+}
+
+// SetAudioOutputConfiguration calls the SetAudioOutputConfiguration operation.
+func (c *client) SetAudioOutputConfiguration(ctx context.Context, configuration onvif.AudioOutputConfiguration, forcePersistence bool) error {
+	_, err := Call_SetAudioOutputConfiguration(ctx, c.dev, media.SetAudioOutputConfiguration{Configuration: configuration, ForcePersistence: forcePersistence})
+	return err
+}
+
+// SetAudioSourceConfiguration calls the SetAudioSourceConfiguration operation.
+func (c *client) SetAudioSourceConfiguration(ctx context.Context, configuration onvif.AudioSourceConfiguration, forcePersistence xsd.Boolean) error {
+	_, err := Call_SetAudioSourceConfiguration(ctx, c.dev, media.SetAudioSourceConfiguration{Configuration: configuration, ForcePersistence: forcePersistence})
+	return err
+}
+
+// SetMetadataConfiguration calls the SetMetadataConfiguration operation.
+func (c *client) SetMetadataConfiguration(ctx context.Context, configuration onvif.MetadataConfiguration, forcePersistence xsd.Boolean) error {
+	_, err := Call_SetMetadataConfiguration(ctx, c.dev, media.SetMetadataConfiguration{Configuration: configuration, ForcePersistence: forcePersistence})
+	return err
+}
+
+// SetOSD calls the SetOSD operation.
+func (c *client) SetOSD(ctx context.Context, osd onvif.OSDConfiguration) error {
+	_, err := Call_SetOSD(ctx, c.dev, media.SetOSD{OSD: osd})
+	return err
+}
+
+// SetSynchronizationPoint calls the SetSynchronizationPoint operation.
+func (c *client) SetSynchronizationPoint(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_SetSynchronizationPoint(ctx, c.dev, media.SetSynchronizationPoint{ProfileToken: profileToken})
+	return err
+}
+
+// SetVideoAnalyticsConfiguration calls the SetVideoAnalyticsConfiguration operation.
+func (c *client) SetVideoAnalyticsConfiguration(ctx context.Context, configuration onvif.VideoAnalyticsConfiguration, forcePersistence bool) error {
+	_, err := Call_SetVideoAnalyticsConfiguration(ctx, c.dev, media.SetVideoAnalyticsConfiguration{Configuration: configuration, ForcePersistence: forcePersistence})
+	return err
+}
+
+// SetVideoEncoderConfiguration calls the SetVideoEncoderConfiguration operation.
+func (c *client) SetVideoEncoderConfiguration(ctx context.Context, configuration onvif.VideoEncoderConfiguration, forcePersistence xsd.Boolean) error {
+	_, err := Call_SetVideoEncoderConfiguration(ctx, c.dev, media.SetVideoEncoderConfiguration{Configuration: configuration, ForcePersistence: forcePersistence})
+	return err
+}
+
+// SetVideoSourceConfiguration calls the SetVideoSourceConfiguration operation.
+func (c *client) SetVideoSourceConfiguration(ctx context.Context, configuration onvif.VideoSourceConfiguration, forcePersistence xsd.Boolean) error {
+	_, err := Call_SetVideoSourceConfiguration(ctx, c.dev, media.SetVideoSourceConfiguration{Configuration: configuration, ForcePersistence: forcePersistence})
+	return err
+}
+
+// SetVideoSourceMode calls the SetVideoSourceMode operation.
+func (c *client) SetVideoSourceMode(ctx context.Context, videoSourceToken onvif.ReferenceToken, videoSourceModeToken onvif.ReferenceToken) (bool, error) {
+	reply, err := Call_SetVideoSourceMode(ctx, c.dev, media.SetVideoSourceMode{VideoSourceToken: videoSourceToken, VideoSourceModeToken: videoSourceModeToken})
+	return reply.Reboot, err
+}
+
+// StartMulticastStreaming calls the StartMulticastStreaming operation.
+func (c *client) StartMulticastStreaming(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_StartMulticastStreaming(ctx, c.dev, media.StartMulticastStreaming{ProfileToken: profileToken})
+	return err
+}
+
+// StopMulticastStreaming calls the StopMulticastStreaming operation.
+func (c *client) StopMulticastStreaming(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_StopMulticastStreaming(ctx, c.dev, media.StopMulticastStreaming{ProfileToken: profileToken})
+	return err
+}
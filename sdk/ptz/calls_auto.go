@@ -0,0 +1,43 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package ptz
+
+import (
+	"github.com/ritj/onvif/ptz"
+	"github.com/ritj/onvif/sdk"
+)
+
+// The Call_ functions forward the request to dev.CallMethod() then parse the payload of the reply.
+// They are instances of sdk.Call, kept under the names of the former generated wrappers.
+var (
+	Call_AbsoluteMove                = sdk.Call[ptz.AbsoluteMove, ptz.AbsoluteMoveResponse]
+	Call_ContinuousMove              = sdk.Call[ptz.ContinuousMove, ptz.ContinuousMoveResponse]
+	Call_CreatePresetTour            = sdk.Call[ptz.CreatePresetTour, ptz.CreatePresetTourResponse]
+	Call_GeoMove                     = sdk.Call[ptz.GeoMove, ptz.GeoMoveResponse]
+	Call_GetCompatibleConfigurations = sdk.Call[ptz.GetCompatibleConfigurations, ptz.GetCompatibleConfigurationsResponse]
+	Call_GetConfiguration            = sdk.Call[ptz.GetConfiguration, ptz.GetConfigurationResponse]
+	Call_GetConfigurationOptions     = sdk.Call[ptz.GetConfigurationOptions, ptz.GetConfigurationOptionsResponse]
+	Call_GetConfigurations           = sdk.Call[ptz.GetConfigurations, ptz.GetConfigurationsResponse]
+	Call_GetNode                     = sdk.Call[ptz.GetNode, ptz.GetNodeResponse]
+	Call_GetNodes                    = sdk.Call[ptz.GetNodes, ptz.GetNodesResponse]
+	Call_GetPresetTour               = sdk.Call[ptz.GetPresetTour, ptz.GetPresetTourResponse]
+	Call_GetPresetTourOptions        = sdk.Call[ptz.GetPresetTourOptions, ptz.GetPresetTourOptionsResponse]
+	Call_GetPresetTours              = sdk.Call[ptz.GetPresetTours, ptz.GetPresetToursResponse]
+	Call_GetPresets                  = sdk.Call[ptz.GetPresets, ptz.GetPresetsResponse]
+	Call_GetServiceCapabilities      = sdk.Call[ptz.GetServiceCapabilities, ptz.GetServiceCapabilitiesResponse]
+	Call_GetStatus                   = sdk.Call[ptz.GetStatus, ptz.GetStatusResponse]
+	Call_GotoHomePosition            = sdk.Call[ptz.GotoHomePosition, ptz.GotoHomePositionResponse]
+	Call_GotoPreset                  = sdk.Call[ptz.GotoPreset, ptz.GotoPresetResponse]
+	Call_ModifyPresetTour            = sdk.Call[ptz.ModifyPresetTour, ptz.ModifyPresetTourResponse]
+	Call_OperatePresetTour           = sdk.Call[ptz.OperatePresetTour, ptz.OperatePresetTourResponse]
+	Call_RelativeMove                = sdk.Call[ptz.RelativeMove, ptz.RelativeMoveResponse]
+	Call_RemovePreset                = sdk.Call[ptz.RemovePreset, ptz.RemovePresetResponse]
+	Call_RemovePresetTour            = sdk.Call[ptz.RemovePresetTour, ptz.RemovePresetTourResponse]
+	Call_SendAuxiliaryCommand        = sdk.Call[ptz.SendAuxiliaryCommand, ptz.SendAuxiliaryCommandResponse]
+	Call_SetConfiguration            = sdk.Call[ptz.SetConfiguration, ptz.SetConfigurationResponse]
+	Call_SetHomePosition             = sdk.Call[ptz.SetHomePosition, ptz.SetHomePositionResponse]
+	Call_SetPreset                   = sdk.Call[ptz.SetPreset, ptz.SetPresetResponse]
+	Call_Stop                        = sdk.Call[ptz.Stop, ptz.StopResponse]
+)
@@ -0,0 +1,223 @@
+// Code generated : DO NOT EDIT.
+// Copyright (c) 2022 Jean-Francois SMIGIELSKI
+// Distributed under the MIT License
+
+package ptz
+
+import (
+	"context"
+	goonvif "github.com/ritj/onvif"
+	"github.com/ritj/onvif/ptz"
+	"github.com/ritj/onvif/xsd"
+	"github.com/ritj/onvif/xsd/onvif"
+)
+
+// Client exposes the operations of the ptz service of a device.
+// It can be replaced by a fake in the tests of the code that depends on it.
+type Client interface {
+	AbsoluteMove(ctx context.Context, profileToken onvif.ReferenceToken, position onvif.PTZVector, speed onvif.PTZSpeed) error
+	ContinuousMove(ctx context.Context, profileToken onvif.ReferenceToken, velocity onvif.PTZSpeed, timeout xsd.Duration) error
+	CreatePresetTour(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.ReferenceToken, error)
+	GeoMove(ctx context.Context, profileToken onvif.ReferenceToken, target onvif.GeoLocation, speed onvif.PTZSpeed, areaHeight xsd.Float, areaWidth xsd.Float) error
+	GetCompatibleConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.PTZConfiguration, error)
+	GetConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.PTZConfiguration, error)
+	GetConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.PTZConfigurationOptions, error)
+	GetConfigurations(ctx context.Context) (onvif.PTZConfiguration, error)
+	GetNode(ctx context.Context, nodeToken onvif.ReferenceToken) (onvif.PTZNode, error)
+	GetNodes(ctx context.Context) (onvif.PTZNode, error)
+	GetPresetTour(ctx context.Context, profileToken onvif.ReferenceToken, presetTourToken onvif.ReferenceToken) (onvif.PresetTour, error)
+	GetPresetTourOptions(ctx context.Context, profileToken onvif.ReferenceToken, presetTourToken onvif.ReferenceToken) (onvif.PTZPresetTourOptions, error)
+	GetPresetTours(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.PresetTour, error)
+	GetPresets(ctx context.Context, profileToken onvif.ReferenceToken) ([]onvif.PTZPreset, error)
+	GetServiceCapabilities(ctx context.Context) (ptz.Capabilities, error)
+	GetStatus(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.PTZStatus, error)
+	GotoHomePosition(ctx context.Context, profileToken onvif.ReferenceToken, speed onvif.PTZSpeed) error
+	GotoPreset(ctx context.Context, profileToken onvif.ReferenceToken, presetToken onvif.ReferenceToken, speed onvif.PTZSpeed) error
+	ModifyPresetTour(ctx context.Context, profileToken onvif.ReferenceToken, presetTour onvif.PresetTour) error
+	OperatePresetTour(ctx context.Context, profileToken onvif.ReferenceToken, presetTourToken onvif.ReferenceToken, operation onvif.PTZPresetTourOperation) error
+	RelativeMove(ctx context.Context, profileToken onvif.ReferenceToken, translation onvif.PTZVector, speed onvif.PTZSpeed) error
+	RemovePreset(ctx context.Context, profileToken onvif.ReferenceToken, presetToken onvif.ReferenceToken) error
+	RemovePresetTour(ctx context.Context, profileToken onvif.ReferenceToken, presetTourToken onvif.ReferenceToken) error
+	SendAuxiliaryCommand(ctx context.Context, profileToken onvif.ReferenceToken, auxiliaryData onvif.AuxiliaryData) (onvif.AuxiliaryData, error)
+	SetConfiguration(ctx context.Context, ptzConfiguration onvif.PTZConfiguration, forcePersistence xsd.Boolean) error
+	SetHomePosition(ctx context.Context, profileToken onvif.ReferenceToken) error
+	SetPreset(ctx context.Context, profileToken onvif.ReferenceToken, presetName xsd.String, presetToken onvif.ReferenceToken) (onvif.ReferenceToken, error)
+	Stop(ctx context.Context, profileToken onvif.ReferenceToken, panTilt xsd.Boolean, zoom xsd.Boolean) error
+}
+
+// NewClient returns a Client bound to dev.
+func NewClient(dev *goonvif.Device) Client {
+	return &client{dev: dev}
+}
+
+type client struct {
+	dev *goonvif.Device
+}
+
+// AbsoluteMove calls the AbsoluteMove operation.
+func (c *client) AbsoluteMove(ctx context.Context, profileToken onvif.ReferenceToken, position onvif.PTZVector, speed onvif.PTZSpeed) error {
+	_, err := Call_AbsoluteMove(ctx, c.dev, ptz.AbsoluteMove{ProfileToken: profileToken, Position: position, Speed: speed})
+	return err
+}
+
+// ContinuousMove calls the ContinuousMove operation.
+func (c *client) ContinuousMove(ctx context.Context, profileToken onvif.ReferenceToken, velocity onvif.PTZSpeed, timeout xsd.Duration) error {
+	_, err := Call_ContinuousMove(ctx, c.dev, ptz.ContinuousMove{ProfileToken: profileToken, Velocity: velocity, Timeout: timeout})
+	return err
+}
+
+// CreatePresetTour calls the CreatePresetTour operation.
+func (c *client) CreatePresetTour(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.ReferenceToken, error) {
+	reply, err := Call_CreatePresetTour(ctx, c.dev, ptz.CreatePresetTour{ProfileToken: profileToken})
+	return reply.PresetTourToken, err
+}
+
+// GeoMove calls the GeoMove operation.
+func (c *client) GeoMove(ctx context.Context, profileToken onvif.ReferenceToken, target onvif.GeoLocation, speed onvif.PTZSpeed, areaHeight xsd.Float, areaWidth xsd.Float) error {
+	_, err := Call_GeoMove(ctx, c.dev, ptz.GeoMove{ProfileToken: profileToken, Target: target, Speed: speed, AreaHeight: areaHeight, AreaWidth: areaWidth})
+	return err
+}
+
+// GetCompatibleConfigurations calls the GetCompatibleConfigurations operation.
+func (c *client) GetCompatibleConfigurations(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.PTZConfiguration, error) {
+	reply, err := Call_GetCompatibleConfigurations(ctx, c.dev, ptz.GetCompatibleConfigurations{ProfileToken: profileToken})
+	return reply.PTZConfiguration, err
+}
+
+// GetConfiguration calls the GetConfiguration operation.
+func (c *client) GetConfiguration(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.PTZConfiguration, error) {
+	reply, err := Call_GetConfiguration(ctx, c.dev, ptz.GetConfiguration{ProfileToken: profileToken})
+	return reply.PTZConfiguration, err
+}
+
+// GetConfigurationOptions calls the GetConfigurationOptions operation.
+func (c *client) GetConfigurationOptions(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.PTZConfigurationOptions, error) {
+	reply, err := Call_GetConfigurationOptions(ctx, c.dev, ptz.GetConfigurationOptions{ProfileToken: profileToken})
+	return reply.PTZConfigurationOptions, err
+}
+
+// GetConfigurations calls the GetConfigurations operation.
+func (c *client) GetConfigurations(ctx context.Context) (onvif.PTZConfiguration, error) {
+	reply, err := Call_GetConfigurations(ctx, c.dev, ptz.GetConfigurations{})
+	return reply.PTZConfiguration, err
+}
+
+// GetNode calls the GetNode operation.
+func (c *client) GetNode(ctx context.Context, nodeToken onvif.ReferenceToken) (onvif.PTZNode, error) {
+	reply, err := Call_GetNode(ctx, c.dev, ptz.GetNode{NodeToken: nodeToken})
+	return reply.PTZNode, err
+}
+
+// GetNodes calls the GetNodes operation.
+func (c *client) GetNodes(ctx context.Context) (onvif.PTZNode, error) {
+	reply, err := Call_GetNodes(ctx, c.dev, ptz.GetNodes{})
+	return reply.PTZNode, err
+}
+
+// GetPresetTour calls the GetPresetTour operation.
+func (c *client) GetPresetTour(ctx context.Context, profileToken onvif.ReferenceToken, presetTourToken onvif.ReferenceToken) (onvif.PresetTour, error) {
+	reply, err := Call_GetPresetTour(ctx, c.dev, ptz.GetPresetTour{ProfileToken: profileToken, PresetTourToken: presetTourToken})
+	return reply.PresetTour, err
+}
+
+// GetPresetTourOptions calls the GetPresetTourOptions operation.
+func (c *client) GetPresetTourOptions(ctx context.Context, profileToken onvif.ReferenceToken, presetTourToken onvif.ReferenceToken) (onvif.PTZPresetTourOptions, error) {
+	reply, err := Call_GetPresetTourOptions(ctx, c.dev, ptz.GetPresetTourOptions{ProfileToken: profileToken, PresetTourToken: presetTourToken})
+	return reply.Options, err
+}
+
+// GetPresetTours calls the GetPresetTours operation.
+func (c *client) GetPresetTours(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.PresetTour, error) {
+	reply, err := Call_GetPresetTours(ctx, c.dev, ptz.GetPresetTours{ProfileToken: profileToken})
+	return reply.PresetTour, err
+}
+
+// GetPresets calls the GetPresets operation.
+func (c *client) GetPresets(ctx context.Context, profileToken onvif.ReferenceToken) ([]onvif.PTZPreset, error) {
+	reply, err := Call_GetPresets(ctx, c.dev, ptz.GetPresets{ProfileToken: profileToken})
+	return reply.Preset, err
+}
+
+// GetServiceCapabilities calls the GetServiceCapabilities operation.
+func (c *client) GetServiceCapabilities(ctx context.Context) (ptz.Capabilities, error) {
+	reply, err := Call_GetServiceCapabilities(ctx, c.dev, ptz.GetServiceCapabilities{})
+	return reply.Capabilities, err
+}
+
+// GetStatus calls the GetStatus operation.
+func (c *client) GetStatus(ctx context.Context, profileToken onvif.ReferenceToken) (onvif.PTZStatus, error) {
+	reply, err := Call_GetStatus(ctx, c.dev, ptz.GetStatus{ProfileToken: profileToken})
+	return reply.PTZStatus, err
+}
+
+// GotoHomePosition calls the GotoHomePosition operation.
+func (c *client) GotoHomePosition(ctx context.Context, profileToken onvif.ReferenceToken, speed onvif.PTZSpeed) error {
+	_, err := Call_GotoHomePosition(ctx, c.dev, ptz.GotoHomePosition{ProfileToken: profileToken, Speed: speed})
+	return err
+}
+
+// GotoPreset calls the GotoPreset operation.
+func (c *client) GotoPreset(ctx context.Context, profileToken onvif.ReferenceToken, presetToken onvif.ReferenceToken, speed onvif.PTZSpeed) error {
+	_, err := Call_GotoPreset(ctx, c.dev, ptz.GotoPreset{ProfileToken: profileToken, PresetToken: presetToken, Speed: speed})
+	return err
+}
+
+// ModifyPresetTour calls the ModifyPresetTour operation.
+func (c *client) ModifyPresetTour(ctx context.Context, profileToken onvif.ReferenceToken, presetTour onvif.PresetTour) error {
+	_, err := Call_ModifyPresetTour(ctx, c.dev, ptz.ModifyPresetTour{ProfileToken: profileToken, PresetTour: presetTour})
+	return err
+}
+
+// OperatePresetTour calls the OperatePresetTour operation.
+func (c *client) OperatePresetTour(ctx context.Context, profileToken onvif.ReferenceToken, presetTourToken onvif.ReferenceToken, operation onvif.PTZPresetTourOperation) error {
+	_, err := Call_OperatePresetTour(ctx, c.dev, ptz.OperatePresetTour{ProfileToken: profileToken, PresetTourToken: presetTourToken, Operation: operation})
+	return err
+}
+
+// RelativeMove calls the RelativeMove operation.
+func (c *client) RelativeMove(ctx context.Context, profileToken onvif.ReferenceToken, translation onvif.PTZVector, speed onvif.PTZSpeed) error {
+	_, err := Call_RelativeMove(ctx, c.dev, ptz.RelativeMove{ProfileToken: profileToken, Translation: translation, Speed: speed})
+	return err
+}
+
+// RemovePreset calls the RemovePreset operation.
+func (c *client) RemovePreset(ctx context.Context, profileToken onvif.ReferenceToken, presetToken onvif.ReferenceToken) error {
+	_, err := Call_RemovePreset(ctx, c.dev, ptz.RemovePreset{ProfileToken: profileToken, PresetToken: presetToken})
+	return err
+}
+
+// RemovePresetTour calls the RemovePresetTour operation.
+func (c *client) RemovePresetTour(ctx context.Context, profileToken onvif.ReferenceToken, presetTourToken onvif.ReferenceToken) error {
+	_, err := Call_RemovePresetTour(ctx, c.dev, ptz.RemovePresetTour{ProfileToken: profileToken, PresetTourToken: presetTourToken})
+	return err
+}
+
+// SendAuxiliaryCommand calls the SendAuxiliaryCommand operation.
+func (c *client) SendAuxiliaryCommand(ctx context.Context, profileToken onvif.ReferenceToken, auxiliaryData onvif.AuxiliaryData) (onvif.AuxiliaryData, error) {
+	reply, err := Call_SendAuxiliaryCommand(ctx, c.dev, ptz.SendAuxiliaryCommand{ProfileToken: profileToken, AuxiliaryData: auxiliaryData})
+	return reply.AuxiliaryResponse, err
+}
+
+// SetConfiguration calls the SetConfiguration operation.
+func (c *client) SetConfiguration(ctx context.Context, ptzConfiguration onvif.PTZConfiguration, forcePersistence xsd.Boolean) error {
+	_, err := Call_SetConfiguration(ctx, c.dev, ptz.SetConfiguration{PTZConfiguration: ptzConfiguration, ForcePersistence: forcePersistence})
+	return err
+}
+
+// SetHomePosition calls the SetHomePosition operation.
+func (c *client) SetHomePosition(ctx context.Context, profileToken onvif.ReferenceToken) error {
+	_, err := Call_SetHomePosition(ctx, c.dev, ptz.SetHomePosition{ProfileToken: profileToken})
+	return err
+}
+
+// SetPreset calls the SetPreset operation.
+func (c *client) SetPreset(ctx context.Context, profileToken onvif.ReferenceToken, presetName xsd.String, presetToken onvif.ReferenceToken) (onvif.ReferenceToken, error) {
+	reply, err := Call_SetPreset(ctx, c.dev, ptz.SetPreset{ProfileToken: profileToken, PresetName: presetName, PresetToken: presetToken})
+	return reply.PresetToken, err
+}
+
+// Stop calls the Stop operation.
+func (c *client) Stop(ctx context.Context, profileToken onvif.ReferenceToken, panTilt xsd.Boolean, zoom xsd.Boolean) error {
+	_, err := Call_Stop(ctx, c.dev, ptz.Stop{ProfileToken: profileToken, PanTilt: panTilt, Zoom: zoom})
+	return err
+}
@@ -1,14 +1,18 @@
 package sdk
 
 import (
+	"bytes"
 	"context"
 	"encoding/xml"
 	"io"
 	"net/http"
 	"os"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/ritj/onvif"
 	"github.com/rs/zerolog"
 )
 
@@ -38,6 +42,200 @@ func ReadAndParse(ctx context.Context, httpReply *http.Response, reply interface
 
 	httpReply.Body.Close()
 
-	err = xml.Unmarshal(b, reply)
-	return errors.Annotate(err, "decode")
+	if err = xml.Unmarshal(b, reply); err != nil {
+		return errors.Annotate(err, "decode")
+	}
+	InheritNamespaces(reply, b)
+	return nil
+}
+
+// namespaceInheritor is a part of a reply that needs the namespaces declared
+// on its ancestors, e.g. onvif.Config whose Type is a qualified name.
+type namespaceInheritor interface {
+	InheritNamespaces(namespaces map[string]string)
+}
+
+// InheritNamespaces gives the namespaces declared in the document of a reply
+// to its parts that need them, e.g. the Topic of a notification. A prefix
+// bound to several namespaces is taken as the first. ReadAndParse calls it,
+// the documents decoded otherwise need it too, e.g. the Notify messages.
+func InheritNamespaces(reply interface{}, doc []byte) {
+	var parts []namespaceInheritor
+	findInheritors(reflect.ValueOf(reply), &parts)
+	if len(parts) == 0 {
+		return
+	}
+	namespaces := map[string]string{}
+	d := xml.NewDecoder(bytes.NewReader(doc))
+	for {
+		tok, err := d.RawToken()
+		if err != nil {
+			break
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			for _, a := range start.Attr {
+				if _, bound := namespaces[a.Name.Local]; a.Name.Space == "xmlns" && !bound {
+					namespaces[a.Name.Local] = a.Value
+				}
+			}
+		}
+	}
+	for _, part := range parts {
+		part.InheritNamespaces(namespaces)
+	}
+}
+
+// findInheritors walks the exported fields of v for its namespaceInheritor.
+func findInheritors(v reflect.Value, parts *[]namespaceInheritor) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			findInheritors(v.Elem(), parts)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			findInheritors(v.Index(i), parts)
+		}
+	case reflect.Struct:
+		if v.CanAddr() {
+			if part, ok := v.Addr().Interface().(namespaceInheritor); ok {
+				*parts = append(*parts, part)
+				return
+			}
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				findInheritors(v.Field(i), parts)
+			}
+		}
+	}
+}
+
+// Call forwards the request to dev.CallMethodContext() then parses the payload of the reply as a Resp.
+// The request is routed to the service known from the namespace of its XMLName, so that any
+// request type can be sent, including those without a Call_ wrapper:
+//
+//	reply, err := sdk.Call[media.GetProfiles, media.GetProfilesResponse](ctx, dev, media.GetProfiles{})
+func Call[Req, Resp any](ctx context.Context, dev *onvif.Device, request Req) (Resp, error) {
+	httpReply, err := dev.CallMethodContext(ctx, request)
+	return parsePayload[Resp](ctx, httpReply, err, reflect.TypeOf(request).Name())
+}
+
+// CallAt is Call for the requests sent to a given endpoint, with the given SOAP headers,
+// see onvif.Device.CallMethodAtContext().
+func CallAt[Req, Resp any](ctx context.Context, dev *onvif.Device, endpoint string, request Req, headers ...string) (Resp, error) {
+	httpReply, err := dev.CallMethodAtContext(ctx, endpoint, request, headers...)
+	return parsePayload[Resp](ctx, httpReply, err, reflect.TypeOf(request).Name())
+}
+
+func parsePayload[Resp any](ctx context.Context, httpReply *http.Response, err error, tag string) (Resp, error) {
+	var reply struct {
+		Header struct{}
+		Body   struct {
+			Fault   *soapFault `xml:"Fault"`
+			Payload Resp       `xml:",any"`
+		}
+	}
+	if err != nil {
+		return reply.Body.Payload, errors.Annotate(err, "call")
+	}
+	status := httpReply.StatusCode
+	if err = ReadAndParse(ctx, httpReply, &reply, tag); err != nil {
+		return reply.Body.Payload, errors.Annotate(err, "reply")
+	}
+	if reply.Body.Fault != nil {
+		return reply.Body.Payload, errors.Trace(reply.Body.Fault.fault())
+	}
+	if status >= http.StatusBadRequest {
+		return reply.Body.Payload, errors.Errorf("reply: HTTP status %d", status)
+	}
+	return reply.Body.Payload, nil
+}
+
+// Fault is the SOAP fault a device replied with, see errors.Cause().
+type Fault struct {
+	// Code is the code of the fault, e.g. env:Sender.
+	Code string
+	// Subcodes are its subcodes, from the outermost, e.g. ter:InvalidArgVal.
+	Subcodes []string
+	Reason   string
+	// Detail is the raw XML of the detail of the fault.
+	Detail string
+}
+
+func (f *Fault) Error() string {
+	msg := "SOAP fault " + f.Code
+	if len(f.Subcodes) > 0 {
+		msg += " " + strings.Join(f.Subcodes, " ")
+	}
+	if f.Reason != "" {
+		msg += ": " + f.Reason
+	}
+	return msg
+}
+
+// Has tells if the last subcode or an element of the detail of the fault is
+// called name, whatever its namespace, e.g. ResourceUnknownFault.
+func (f *Fault) Has(name string) bool {
+	if n := len(f.Subcodes); n > 0 && localName(f.Subcodes[n-1]) == name {
+		return true
+	}
+	d := xml.NewDecoder(strings.NewReader(f.Detail))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return false
+		}
+		if t, ok := tok.(xml.StartElement); ok && t.Name.Local == name {
+			return true
+		}
+	}
+}
+
+// DecodeDetail decodes the element called name of the detail of the fault into v.
+func (f *Fault) DecodeDetail(name string, v interface{}) error {
+	d := xml.NewDecoder(strings.NewReader(f.Detail))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return errors.NotFoundf("%s in the fault detail", name)
+		}
+		if t, ok := tok.(xml.StartElement); ok && t.Name.Local == name {
+			return errors.Annotate(d.DecodeElement(v, &t), "decode")
+		}
+	}
+}
+
+type soapFault struct {
+	Code   soapFaultCode
+	Reason []string `xml:"Reason>Text"`
+	Detail struct {
+		Content string `xml:",innerxml"`
+	}
+	// SOAP 1.1
+	FaultCode   string `xml:"faultcode"`
+	FaultString string `xml:"faultstring"`
+}
+
+type soapFaultCode struct {
+	Value   string
+	Subcode *soapFaultCode
+}
+
+func (sf *soapFault) fault() *Fault {
+	f := &Fault{Code: strings.TrimSpace(sf.Code.Value), Detail: sf.Detail.Content}
+	for c := sf.Code.Subcode; c != nil; c = c.Subcode {
+		f.Subcodes = append(f.Subcodes, strings.TrimSpace(c.Value))
+	}
+	if len(sf.Reason) > 0 {
+		f.Reason = strings.TrimSpace(sf.Reason[0])
+	}
+	if f.Code == "" {
+		f.Code, f.Reason = strings.TrimSpace(sf.FaultCode), strings.TrimSpace(sf.FaultString)
+	}
+	return f
+}
+
+func localName(qname string) string {
+	return qname[strings.LastIndexByte(qname, ':')+1:]
 }
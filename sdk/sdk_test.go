@@ -0,0 +1,86 @@
+package sdk
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ritj/onvif"
+	"github.com/ritj/onvif/analytics"
+	"github.com/ritj/onvif/media"
+)
+
+const envelope = `<?xml version="1.0" encoding="UTF-8"?>
+<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:tt="http://www.onvif.org/ver10/schema" xmlns:trt="http://www.onvif.org/ver10/media/wsdl" xmlns:tds="http://www.onvif.org/ver10/device/wsdl">
+<SOAP-ENV:Body>%s</SOAP-ENV:Body>
+</SOAP-ENV:Envelope>`
+
+func TestCall(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case strings.Contains(string(body), "GetCapabilities"):
+			fmt.Fprintf(w, envelope, `<tds:GetCapabilitiesResponse><tds:Capabilities>
+				<tt:Media><tt:XAddr>`+srv.URL+`/onvif/media</tt:XAddr></tt:Media>
+			</tds:Capabilities></tds:GetCapabilitiesResponse>`)
+		case r.URL.Path == "/onvif/media" && strings.Contains(string(body), "trt:GetProfiles"):
+			fmt.Fprintf(w, envelope, `<trt:GetProfilesResponse>
+				<trt:Profiles token="main"><tt:Name>Main</tt:Name></trt:Profiles>
+				<trt:Profiles token="sub"><tt:Name>Sub</tt:Name></trt:Profiles>
+			</trt:GetProfilesResponse>`)
+		default:
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	dev, err := onvif.NewDevice(onvif.DeviceParams{Xaddr: strings.TrimPrefix(srv.URL, "http://")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := Call[media.GetProfiles, media.GetProfilesResponse](context.Background(), dev, media.GetProfiles{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Profiles) != 2 || reply.Profiles[0].Token != "main" || reply.Profiles[1].Name != "Sub" {
+		t.Errorf("unexpected reply %+v", reply)
+	}
+}
+
+func TestReadAndParseNamespaces(t *testing.T) {
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:tt="http://www.onvif.org/ver10/schema" xmlns:tan="http://www.onvif.org/ver20/analytics/wsdl" xmlns:axis="http://www.axis.com/vapix/ws/analytics">
+<SOAP-ENV:Body><tan:GetRulesResponse>
+	<tan:Rule Name="Line1" Type="axis:LineDetector">
+		<tt:Parameters><tt:ElementItem Name="Segments"><axis:Polyline><tt:Point x="0" y="0"/></axis:Polyline></tt:ElementItem></tt:Parameters>
+	</tan:Rule>
+</tan:GetRulesResponse></SOAP-ENV:Body>
+</SOAP-ENV:Envelope>`
+	var reply struct {
+		Body struct {
+			Payload analytics.GetRulesResponse `xml:",any"`
+		}
+	}
+	httpReply := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+	if err := ReadAndParse(context.Background(), httpReply, &reply, "GetRules"); err != nil {
+		t.Fatal(err)
+	}
+	rules := reply.Body.Payload.Rule
+	if len(rules) != 1 || rules[0].Namespaces["axis"] != "http://www.axis.com/vapix/ws/analytics" {
+		t.Fatalf("unexpected rules %+v", rules)
+	}
+	out, err := xml.Marshal(analytics.ModifyRules{ConfigurationToken: "va", Rule: rules})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `xmlns:axis="http://www.axis.com/vapix/ws/analytics"`) {
+		t.Errorf("missing the axis namespace in %s", out)
+	}
+}
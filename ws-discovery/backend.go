@@ -0,0 +1,240 @@
+package wsdiscovery
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Backend is a way of finding the devices of the network, e.g. WS-Discovery,
+// multicast DNS or SSDP, which delivers them as ProbeMatch.
+type Backend interface {
+	// Name names the backend in the Sources of the devices, e.g. mdns.
+	Name() string
+	// Discover returns the channel of the devices found, closed at the end
+	// of the discovery, with the settings of Discover.
+	Discover(ctx context.Context, opts DiscoverOptions) (<-chan ProbeMatch, error)
+}
+
+// WSDiscovery is the Backend of Discover.
+type WSDiscovery struct{}
+
+// Name returns ws-discovery.
+func (WSDiscovery) Name() string { return "ws-discovery" }
+
+// Discover calls Discover.
+func (WSDiscovery) Discover(ctx context.Context, opts DiscoverOptions) (<-chan ProbeMatch, error) {
+	return Discover(ctx, opts)
+}
+
+// DiscoverWith runs the backends at once, WSDiscovery, MDNS and SSDP when
+// none is given, and returns the devices they found merged by IP and MAC, see
+// Merge. It returns at the end of the discovery, after opts.Timeout or when
+// ctx is done. It fails when no backend could start.
+func DiscoverWith(ctx context.Context, opts DiscoverOptions, backends ...Backend) ([]ProbeMatch, error) {
+	if len(backends) == 0 {
+		backends = []Backend{WSDiscovery{}, MDNS{}, SSDP{}}
+	}
+	var mu sync.Mutex
+	var matches []ProbeMatch
+	var errs []error
+	var wg sync.WaitGroup
+	for _, b := range backends {
+		found, err := b.Discover(ctx, opts)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for m := range found {
+				if len(m.Sources) == 0 {
+					m.Sources = []string{name}
+				}
+				mu.Lock()
+				matches = append(matches, m)
+				mu.Unlock()
+			}
+		}(b.Name())
+	}
+	wg.Wait()
+	if len(errs) == len(backends) {
+		return nil, errors.Join(errs...)
+	}
+
+	arp := arpTable()
+	for i, m := range matches {
+		if m.MAC == nil && m.From != nil {
+			matches[i].MAC = arp[m.From.String()]
+		}
+	}
+	return Merge(matches), nil
+}
+
+// Merge joins the matches of the same device, which share a MAC or an IP
+// with another of its matches, in the order of their first match. The
+// description of a WS-Discovery reply, with an endpoint reference, is kept
+// over the ones of the other backends, whose scopes, XAddrs and Sources are
+// added to it.
+func Merge(matches []ProbeMatch) []ProbeMatch {
+	var out []ProbeMatch
+	// The IPs and MACs of every match of out.
+	var addrs []map[string]bool
+	for _, m := range matches {
+		keys := matchKeys(m)
+		first := -1
+		for i := 0; i < len(out); i++ {
+			shared := false
+			for _, k := range keys {
+				shared = shared || addrs[i][k]
+			}
+			if !shared {
+				continue
+			}
+			if first < 0 {
+				first = i
+				continue
+			}
+			// m joins two devices seen so far apart.
+			out[first] = merge(out[first], out[i])
+			for k := range addrs[i] {
+				addrs[first][k] = true
+			}
+			out = append(out[:i], out[i+1:]...)
+			addrs = append(addrs[:i], addrs[i+1:]...)
+			i--
+		}
+		if first < 0 {
+			out = append(out, m)
+			addrs = append(addrs, map[string]bool{})
+			first = len(out) - 1
+		} else {
+			out[first] = merge(out[first], m)
+		}
+		for _, k := range keys {
+			addrs[first][k] = true
+		}
+	}
+	return out
+}
+
+// matchKeys returns the IP and the MAC of a match, when known.
+func matchKeys(m ProbeMatch) []string {
+	var keys []string
+	if m.From != nil {
+		keys = append(keys, "ip "+m.From.String())
+	}
+	if m.MAC != nil {
+		keys = append(keys, "mac "+m.MAC.String())
+	}
+	return keys
+}
+
+// merge adds m to the match of the same device o.
+func merge(o, m ProbeMatch) ProbeMatch {
+	if o.Address == "" && m.Address != "" {
+		o, m = m, o
+	}
+	scopes := o.Scopes
+	for _, scope := range m.Scopes {
+		if !containsScope(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	o.Scopes = scopes
+	xaddrs := o.XAddrs
+	for _, xaddr := range m.XAddrs {
+		found := false
+		for _, x := range xaddrs {
+			found = found || x.String() == xaddr.String()
+		}
+		if !found {
+			xaddrs = append(xaddrs, xaddr)
+		}
+	}
+	o.XAddrs = xaddrs
+	if o.From == nil {
+		o.From = m.From
+	}
+	if o.MAC == nil {
+		o.MAC = m.MAC
+	}
+	sources := o.Sources
+	for _, source := range m.Sources {
+		found := false
+		for _, s := range sources {
+			found = found || s == source
+		}
+		if !found {
+			sources = append(sources, source)
+		}
+	}
+	o.Sources = sources
+	return o
+}
+
+func containsScope(scopes []Scope, scope Scope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMAC parses a hardware address written with separators, e.g.
+// 00:40:8c:12:34:56, as net.ParseMAC also takes 12 hexadecimal digits alone.
+func parseMAC(s string) net.HardwareAddr {
+	s = strings.TrimSpace(s)
+	if !strings.ContainsAny(s, ":-.") {
+		return nil
+	}
+	if mac, err := net.ParseMAC(s); err == nil && len(mac) == 6 {
+		return mac
+	}
+	return nil
+}
+
+// parseHexMAC parses a hardware address also written as 12 hexadecimal
+// digits, e.g. 00408C123456. It is only called on the values known to be
+// one, as any serial number of 12 hexadecimal digits is not.
+func parseHexMAC(s string) net.HardwareAddr {
+	if s = strings.TrimSpace(s); len(s) == 12 {
+		if mac, err := hex.DecodeString(s); err == nil {
+			return net.HardwareAddr(mac)
+		}
+	}
+	return parseMAC(s)
+}
+
+// arpTable returns the hardware addresses of the IPs of the ARP table of
+// Linux, none on the other systems.
+func arpTable() map[string]net.HardwareAddr {
+	table := map[string]net.HardwareAddr{}
+	f, err := os.Open("/proc/net/arp")
+	if err != nil {
+		return table
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	// The first line names the columns: IP address, HW type, Flags, HW
+	// address, Mask and Device.
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// The incomplete entries have no flags.
+		if len(fields) < 4 || fields[2] == "0x0" {
+			continue
+		}
+		if mac := parseMAC(fields[3]); mac != nil && mac.String() != "00:00:00:00:00:00" {
+			table[fields[0]] = mac
+		}
+	}
+	return table
+}
@@ -0,0 +1,79 @@
+package wsdiscovery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// fakeBackend delivers its matches, or fails.
+type fakeBackend struct {
+	name    string
+	matches []ProbeMatch
+	err     error
+}
+
+func (b fakeBackend) Name() string { return b.name }
+
+func (b fakeBackend) Discover(ctx context.Context, opts DiscoverOptions) (<-chan ProbeMatch, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	out := make(chan ProbeMatch, len(b.matches))
+	for _, m := range b.matches {
+		out <- m
+	}
+	close(out)
+	return out, nil
+}
+
+func TestDiscoverWith(t *testing.T) {
+	mac := parseHexMAC("00408C123456")
+	xaddr := url.URL{Scheme: "http", Host: "192.168.1.10", Path: "/onvif/device_service"}
+	ws := ProbeMatch{
+		Endpoint: Endpoint{Address: "urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f", XAddrs: []url.URL{xaddr}},
+		From:     net.IPv4(192, 168, 1, 10),
+	}
+	mdns := ProbeMatch{
+		Endpoint: Endpoint{Scopes: []Scope{NewScope(ScopeName, "AXIS M3045")}, XAddrs: []url.URL{xaddr}},
+		From:     net.IPv4(192, 168, 1, 10),
+		MAC:      mac,
+	}
+	// The same camera, seen on its other address.
+	ssdp := ProbeMatch{From: net.IPv4(10, 0, 0, 10), MAC: mac}
+	other := ProbeMatch{From: net.IPv4(192, 168, 1, 20)}
+
+	matches, err := DiscoverWith(context.Background(), DiscoverOptions{},
+		fakeBackend{name: "mdns", matches: []ProbeMatch{mdns, other}},
+		fakeBackend{name: "ws-discovery", matches: []ProbeMatch{ws}},
+		fakeBackend{name: "ssdp", matches: []ProbeMatch{ssdp}},
+		fakeBackend{name: "broken", err: errors.New("no multicast interface")})
+	if err != nil || len(matches) != 2 {
+		t.Fatalf("unexpected %+v, %v", matches, err)
+	}
+	m := matches[0]
+	if m.Address == "" {
+		m = matches[1]
+	}
+	sort.Strings(m.Sources)
+	if m.Address != ws.Address || len(m.XAddrs) != 1 || m.ScopeValues(ScopeName)[0] != "AXIS M3045" ||
+		m.MAC.String() != mac.String() || !m.From.Equal(ws.From) {
+		t.Errorf("unexpected %+v", m)
+	}
+	if !reflect.DeepEqual(m.Sources, []string{"mdns", "ssdp", "ws-discovery"}) {
+		t.Errorf("unexpected sources %v", m.Sources)
+	}
+
+	// mdns joins the two devices seen so far apart.
+	if merged := Merge([]ProbeMatch{ssdp, ws, other, mdns}); len(merged) != 2 || merged[0].Address != ws.Address || !merged[0].From.Equal(ws.From) {
+		t.Errorf("unexpected %+v", merged)
+	}
+
+	if _, err := DiscoverWith(context.Background(), DiscoverOptions{}, fakeBackend{err: errors.New("failed")}); err == nil {
+		t.Error("expected an error when no backend starts")
+	}
+}
@@ -0,0 +1,266 @@
+package wsdiscovery
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"golang.org/x/net/ipv4"
+)
+
+// The retransmission of the multicast messages, see SOAP-over-UDP
+const (
+	multicastUDPRepeat = 1
+	udpMinDelay        = 50 * time.Millisecond
+	udpMaxDelay        = 250 * time.Millisecond
+	udpUpperDelay      = 500 * time.Millisecond
+)
+
+// DiscoverOptions are the settings of Discover, the zero values select the defaults.
+type DiscoverOptions struct {
+	// Interfaces are the names of the interfaces probed, all the interfaces
+	// up with multicast and IPv4 when empty.
+	Interfaces []string
+	// Types, Scopes and Namespaces are the content of the Probe, the
+	// NetworkVideoTransmitter of ONVIF when Types is empty.
+	Types      []string
+	Scopes     []string
+	Namespaces map[string]string
+	// Repeat is the number of retransmissions of the Probe, 1 when zero and
+	// none when negative.
+	Repeat int
+	// TTL is the time to live of the Probe, 1 when zero.
+	TTL int
+	// Timeout ends the discovery, which otherwise lasts until ctx is done.
+	Timeout time.Duration
+	// Versions are the versions of WS-Discovery of the messages sent, all
+	// when empty. The replies of every version are received.
+	Versions []Version
+}
+
+// request is a message of a client, of MessageID uuid:id.
+type request struct {
+	id, body string
+}
+
+// Discover multicasts a Probe on every interface at once and returns the
+// channel of the replies, closed at the end of the discovery. A target service
+// is delivered once per endpoint reference, or again when its MetadataVersion
+// is greater, whatever the version of WS-Discovery it replied in. A discovery
+// proxy replies with its Hello, delivered as a ProbeMatch whose
+// IsDiscoveryProxy is true, see Client for the managed mode.
+func Discover(ctx context.Context, opts DiscoverOptions) (<-chan ProbeMatch, error) {
+	if len(opts.Types) == 0 {
+		opts.Types = []string{"dn:NetworkVideoTransmitter"}
+		opts.Namespaces = map[string]string{"dn": "http://www.onvif.org/ver10/network/wsdl"}
+	}
+	var probes []request
+	for _, v := range versionsOf(opts.Versions) {
+		id := uuid.Must(uuid.NewV4()).String()
+		probes = append(probes, request{id, buildProbeMessageTo(v, id, "", opts.Scopes, opts.Types, opts.Namespaces).String()})
+	}
+	return discover(ctx, probes, opts)
+}
+
+// discover multicasts the requests and returns the replies.
+func discover(ctx context.Context, requests []request, opts DiscoverOptions) (<-chan ProbeMatch, error) {
+	cancel := func() {}
+	if opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+	}
+	ids := make([]string, len(requests))
+	messages := make([][]byte, len(requests))
+	for i, r := range requests {
+		ids[i] = "uuid:" + r.id
+		messages[i] = []byte(r.body)
+	}
+	found, err := multicast(ctx, multicastAddr, messages, opts, func(p net.PacketConn, found chan<- ProbeMatch) {
+		receiveMatches(ctx, p, ids, found)
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	out := make(chan ProbeMatch, 16)
+	go func() {
+		defer cancel()
+		defer close(out)
+		versions := map[string]uint{}
+		for m := range found {
+			key := m.Address
+			if key == "" && len(m.XAddrs) > 0 {
+				key = m.XAddrs[0].String()
+			}
+			if v, ok := versions[key]; ok && m.MetadataVersion <= v {
+				continue
+			}
+			versions[key] = m.MetadataVersion
+			select {
+			case out <- m:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return out, nil
+}
+
+// multicast sends the messages to the group of dst on every interface of
+// opts, repeated as opts tells, and calls receive with the connection of
+// every interface, which is closed when ctx is done. The channel receive
+// sends on is closed once they all returned.
+func multicast(ctx context.Context, dst *net.UDPAddr, messages [][]byte, opts DiscoverOptions,
+	receive func(p net.PacketConn, found chan<- ProbeMatch)) (<-chan ProbeMatch, error) {
+	if opts.Repeat == 0 {
+		opts.Repeat = multicastUDPRepeat
+	}
+	if opts.TTL == 0 {
+		opts.TTL = 1
+	}
+	ifaces, err := multicastInterfaces(opts.Interfaces)
+	if err != nil {
+		return nil, err
+	}
+
+	var conns []*ipv4.PacketConn
+	closeAll := func() {
+		for _, p := range conns {
+			p.Close()
+		}
+	}
+	for _, iface := range ifaces {
+		c, err := net.ListenPacket("udp4", "0.0.0.0:0")
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		p := ipv4.NewPacketConn(c)
+		conns = append(conns, p)
+		if err := p.SetMulticastInterface(iface); err != nil {
+			closeAll()
+			return nil, err
+		}
+		if err := p.SetMulticastTTL(opts.TTL); err != nil {
+			closeAll()
+			return nil, err
+		}
+	}
+	go func() {
+		<-ctx.Done()
+		closeAll()
+	}()
+
+	found := make(chan ProbeMatch)
+	var wg sync.WaitGroup
+	for _, p := range conns {
+		wg.Add(1)
+		go func(p *ipv4.PacketConn) {
+			defer wg.Done()
+			for _, m := range messages {
+				go repeat(ctx, opts.Repeat, func(body []byte) func() error {
+					return func() error {
+						_, err := p.WriteTo(body, nil, dst)
+						return err
+					}
+				}(m))
+			}
+			receive(p.PacketConn, found)
+		}(p)
+	}
+	go func() {
+		wg.Wait()
+		close(found)
+	}()
+	return found, nil
+}
+
+// multicastInterfaces returns the interfaces of names, or all the interfaces
+// up with multicast and IPv4.
+func multicastInterfaces(names []string) ([]*net.Interface, error) {
+	var ifaces []*net.Interface
+	for _, name := range names {
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			return nil, err
+		}
+		ifaces = append(ifaces, iface)
+	}
+	if len(names) > 0 {
+		return ifaces, nil
+	}
+	all, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	for i := range all {
+		iface := &all[i]
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagMulticast == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipnet, ok := addr.(*net.IPNet); ok && ipnet.IP.To4() != nil {
+				ifaces = append(ifaces, iface)
+				break
+			}
+		}
+	}
+	if len(ifaces) == 0 {
+		return nil, errors.New("no multicast interface")
+	}
+	return ifaces, nil
+}
+
+// repeat calls send, then n times again after a random delay doubled at
+// every retransmission, until an error.
+func repeat(ctx context.Context, n int, send func() error) {
+	delay := udpMinDelay + time.Duration(rand.Int63n(int64(udpMaxDelay-udpMinDelay)))
+	for i := 0; ; i++ {
+		if send() != nil || i >= n {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > udpUpperDelay {
+			delay = udpUpperDelay
+		}
+	}
+}
+
+// receiveMatches sends the ProbeMatch of the replies to the messages of ids
+// on found, until the connection is closed.
+func receiveMatches(ctx context.Context, p net.PacketConn, ids []string, found chan<- ProbeMatch) {
+	// The largest UDP datagram, as the replies listing many scopes exceed 8KiB.
+	buf := make([]byte, 65535)
+	for {
+		n, src, err := p.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		var from net.IP
+		if addr, ok := src.(*net.UDPAddr); ok {
+			from = addr.IP
+		}
+		env, err := parseEnvelope(buf[:n])
+		if err != nil {
+			continue
+		}
+		for _, m := range env.probeMatches(from, ids...) {
+			select {
+			case found <- m:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
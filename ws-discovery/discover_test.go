@@ -0,0 +1,68 @@
+package wsdiscovery
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiscover(t *testing.T) {
+	if _, err := multicastInterfaces(nil); err != nil {
+		t.Skip(err)
+	}
+	conn, err := net.ListenMulticastUDP("udp4", nil, multicastAddr)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer conn.Close()
+
+	// A reply beyond 8KiB, as sent by the devices with many scopes.
+	scopes := strings.Repeat("onvif://www.onvif.org/location/x ", 300)
+	var probes int32
+	go func() {
+		buf := make([]byte, 65535)
+		for {
+			n, from, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			env, err := parseEnvelope(buf[:n])
+			if err != nil || !strings.HasSuffix(env.Header.Action, "/Probe") {
+				continue
+			}
+			atomic.AddInt32(&probes, 1)
+			reply := strings.NewReplacer(
+				"%d", "1",
+				"uuid:00000000-0000-4000-8000-0000000000aa", env.Header.MessageID,
+				"onvif://www.onvif.org/location/city/Paris", scopes,
+			).Replace(probeMatches)
+			conn.WriteToUDP([]byte(reply), from)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	matches, err := Discover(ctx, DiscoverOptions{Timeout: 1500 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []ProbeMatch
+	for m := range matches {
+		got = append(got, m)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("Timeout not applied")
+	}
+	if atomic.LoadInt32(&probes) == 0 {
+		t.Skip("multicast not looped back")
+	}
+	if atomic.LoadInt32(&probes) < 2 {
+		t.Errorf("the probe was not repeated")
+	}
+	if len(got) != 1 || len(got[0].Scopes) != 301 {
+		t.Errorf("unexpected matches %d", len(got))
+	}
+}
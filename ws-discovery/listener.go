@@ -0,0 +1,182 @@
+package wsdiscovery
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/ipv4"
+)
+
+// The multicast group of WS-Discovery
+var (
+	multicastGroup = net.IPv4(239, 255, 255, 250)
+	multicastAddr  = &net.UDPAddr{IP: multicastGroup, Port: 3702}
+)
+
+// AnnouncementType tells if a target service joins or leaves the network.
+type AnnouncementType int
+
+// The announcements of the target services
+const (
+	// Hello is sent when the service starts, or its metadata changes.
+	Hello AnnouncementType = iota
+	// Bye is sent when the service stops, its Endpoint only holds its Address.
+	Bye
+)
+
+func (t AnnouncementType) String() string {
+	if t == Bye {
+		return "Bye"
+	}
+	return "Hello"
+}
+
+// Announcement is a Hello or a Bye of a target service.
+type Announcement struct {
+	Type AnnouncementType
+	Endpoint
+	// From is the IP the announcement was sent from.
+	From net.IP
+}
+
+// Listener receives the Hello and Bye multicast by the target services, e.g.
+// the cameras when they boot or leave the network.
+type Listener struct {
+	// Interfaces are the names of the interfaces the group is joined on, the
+	// default multicast interface when empty.
+	Interfaces []string
+	// Buffer is the capacity of the channel of announcements.
+	Buffer int
+	// OnError is called with the errors of the reception, e.g. an invalid
+	// message. They are ignored when it is nil.
+	OnError func(error)
+}
+
+// NewListener returns a Listener on the interfaces given.
+func NewListener(interfaces ...string) *Listener {
+	return &Listener{Interfaces: interfaces, Buffer: 16}
+}
+
+// Listen joins the multicast group and returns the channel of the
+// announcements, closed when ctx is done. An announcement repeated by the
+// service, with the same MessageID, is delivered once.
+func (l *Listener) Listen(ctx context.Context) (<-chan Announcement, error) {
+	conn, err := listenGroup(l.Interfaces)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan Announcement, l.Buffer)
+	var once sync.Once
+	stop := func() { once.Do(func() { conn.Close() }) }
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	go func() {
+		defer close(out)
+		defer stop()
+		seen := map[string]time.Time{}
+		buf := make([]byte, 65536)
+		for {
+			n, from, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				if ctx.Err() == nil {
+					l.fail(err)
+				}
+				return
+			}
+			a, id, err := parseAnnouncement(buf[:n])
+			if err != nil {
+				l.fail(err)
+				continue
+			}
+			if a == nil || duplicate(seen, id) {
+				continue
+			}
+			a.From = from.IP
+			select {
+			case out <- *a:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// listenGroup joins the multicast group on the interfaces of names, or the
+// default multicast interface when empty.
+func listenGroup(names []string) (*net.UDPConn, error) {
+	var ifaces []*net.Interface
+	for _, name := range names {
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			return nil, err
+		}
+		ifaces = append(ifaces, iface)
+	}
+	var first *net.Interface
+	if len(ifaces) > 0 {
+		first = ifaces[0]
+	}
+	// ListenMulticastUDP allows other listeners on the port.
+	conn, err := net.ListenMulticastUDP("udp4", first, multicastAddr)
+	if err != nil {
+		return nil, err
+	}
+	if len(ifaces) > 1 {
+		p := ipv4.NewPacketConn(conn)
+		for _, iface := range ifaces[1:] {
+			if err := p.JoinGroup(iface, &net.UDPAddr{IP: multicastGroup}); err != nil {
+				conn.Close()
+				return nil, err
+			}
+		}
+	}
+	return conn, nil
+}
+
+// parseAnnouncement returns the Hello or Bye of a message, nil for the other
+// messages, and the MessageID of the message.
+func parseAnnouncement(data []byte) (*Announcement, string, error) {
+	env, err := parseEnvelope(data)
+	if err != nil {
+		return nil, "", err
+	}
+	id := env.Header.MessageID
+	switch {
+	case env.Body.Hello != nil:
+		return &Announcement{Type: Hello, Endpoint: env.Body.Hello.endpoint()}, id, nil
+	case env.Body.Bye != nil:
+		return &Announcement{Type: Bye, Endpoint: env.Body.Bye.endpoint()}, id, nil
+	}
+	return nil, id, nil
+}
+
+// duplicate tells if the message id was seen in the last minute, and
+// forgets the older ones.
+func duplicate(seen map[string]time.Time, id string) bool {
+	if id == "" {
+		return false
+	}
+	now := time.Now()
+	for k, t := range seen {
+		if now.Sub(t) > time.Minute {
+			delete(seen, k)
+		}
+	}
+	if _, ok := seen[id]; ok {
+		return true
+	}
+	seen[id] = now
+	return false
+}
+
+func (l *Listener) fail(err error) {
+	if l.OnError != nil {
+		l.OnError(err)
+	}
+}
@@ -0,0 +1,52 @@
+package wsdiscovery
+
+import (
+	"testing"
+	"time"
+)
+
+const hello = `<?xml version="1.0" encoding="UTF-8"?>
+<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:wsa="http://schemas.xmlsoap.org/ws/2004/08/addressing" xmlns:d="http://schemas.xmlsoap.org/ws/2005/04/discovery" xmlns:dn="http://www.onvif.org/ver10/network/wsdl">
+<SOAP-ENV:Header>
+	<wsa:MessageID>uuid:1f6a3c4e-0000-4000-8000-000000000001</wsa:MessageID>
+	<wsa:To>urn:schemas-xmlsoap-org:ws:2005:04:discovery</wsa:To>
+	<wsa:Action>http://schemas.xmlsoap.org/ws/2005/04/discovery/Hello</wsa:Action>
+	<d:AppSequence InstanceId="1" MessageNumber="1"/>
+</SOAP-ENV:Header>
+<SOAP-ENV:Body><d:Hello>
+	<wsa:EndpointReference><wsa:Address>urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f</wsa:Address></wsa:EndpointReference>
+	<d:Types>dn:NetworkVideoTransmitter tds:Device</d:Types>
+	<d:Scopes>onvif://www.onvif.org/type/video_encoder onvif://www.onvif.org/name/Camera%201</d:Scopes>
+	<d:XAddrs>http://192.168.1.10/onvif/device_service http://[fe80::1]/onvif/device_service</d:XAddrs>
+	<d:MetadataVersion>3</d:MetadataVersion>
+</d:Hello></SOAP-ENV:Body>
+</SOAP-ENV:Envelope>`
+
+const bye = `<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:wsa="http://schemas.xmlsoap.org/ws/2004/08/addressing" xmlns:d="http://schemas.xmlsoap.org/ws/2005/04/discovery">
+<SOAP-ENV:Header><wsa:MessageID>uuid:1f6a3c4e-0000-4000-8000-000000000002</wsa:MessageID></SOAP-ENV:Header>
+<SOAP-ENV:Body><d:Bye>
+	<wsa:EndpointReference><wsa:Address>urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f</wsa:Address></wsa:EndpointReference>
+</d:Bye></SOAP-ENV:Body>
+</SOAP-ENV:Envelope>`
+
+func TestParseAnnouncement(t *testing.T) {
+	a, id, err := parseAnnouncement([]byte(hello))
+	if err != nil || a == nil {
+		t.Fatalf("unexpected %+v, %v", a, err)
+	}
+	if a.Type != Hello || id != "uuid:1f6a3c4e-0000-4000-8000-000000000001" ||
+		a.Address != "urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f" || a.MetadataVersion != 3 ||
+		len(a.Types) != 2 || len(a.Scopes) != 2 || len(a.XAddrs) != 2 {
+		t.Errorf("unexpected %+v", a)
+	}
+
+	a, _, err = parseAnnouncement([]byte(bye))
+	if err != nil || a == nil || a.Type != Bye || a.Address != "urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f" {
+		t.Errorf("unexpected %+v, %v", a, err)
+	}
+
+	seen := map[string]time.Time{}
+	if duplicate(seen, id) || !duplicate(seen, id) {
+		t.Error("a repeated message was not detected")
+	}
+}
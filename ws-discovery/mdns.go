@@ -0,0 +1,309 @@
+package wsdiscovery
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// mdnsAddr is the group and port of multicast DNS.
+var mdnsAddr = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+// The types of the DNS records of DNS-SD
+const (
+	dnsTypeA   = 1
+	dnsTypePTR = 12
+	dnsTypeTXT = 16
+	dnsTypeSRV = 33
+)
+
+// MDNS is the Backend of DNS-SD over multicast DNS, the devices that
+// advertise their services, e.g. the Axis cameras with WS-Discovery disabled.
+// The devices are delivered with the instance name of their service as
+// ScopeName, the MAC of their TXT record, if any, and as XAddrs the device
+// service at its usual path on the port of their service.
+type MDNS struct {
+	// Services are the DNS-SD services queried, _onvif._tcp and
+	// _axis-video._tcp when empty.
+	Services []string
+}
+
+// Name returns mdns.
+func (MDNS) Name() string { return "mdns" }
+
+// Discover multicasts the queries of the services on the interfaces of opts,
+// whose Types and Scopes are ignored, and returns the instances of the
+// services that replied, until opts.Timeout or until ctx is done.
+func (b MDNS) Discover(ctx context.Context, opts DiscoverOptions) (<-chan ProbeMatch, error) {
+	services := b.Services
+	if len(services) == 0 {
+		services = []string{"_onvif._tcp", "_axis-video._tcp"}
+	}
+	cancel := func() {}
+	if opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+	}
+	cache := &mdnsCache{services: services}
+	found, err := multicast(ctx, mdnsAddr, [][]byte{mdnsQuery(services)}, opts, func(p net.PacketConn, found chan<- ProbeMatch) {
+		buf := make([]byte, 65535)
+		for {
+			n, src, err := p.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var from net.IP
+			if addr, ok := src.(*net.UDPAddr); ok {
+				from = addr.IP
+			}
+			records, err := parseDNS(buf[:n])
+			if err != nil {
+				continue
+			}
+			for _, m := range cache.add(records, from) {
+				select {
+				case found <- m:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	out := make(chan ProbeMatch, 16)
+	go func() {
+		defer cancel()
+		defer close(out)
+		for m := range found {
+			select {
+			case out <- m:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return out, nil
+}
+
+// mdnsQuery returns a query of the PTR records of the services in the local
+// domain. Its source port is not 5353, so it is answered by unicast as a
+// legacy query.
+func mdnsQuery(services []string) []byte {
+	msg := make([]byte, 12)
+	binary.BigEndian.PutUint16(msg[4:], uint16(len(services)))
+	for _, service := range services {
+		msg = appendDNSName(msg, service+".local")
+		msg = binary.BigEndian.AppendUint16(msg, dnsTypePTR)
+		msg = binary.BigEndian.AppendUint16(msg, 1)
+	}
+	return msg
+}
+
+// appendDNSName appends a name of dot separated labels.
+func appendDNSName(msg []byte, name string) []byte {
+	for _, label := range strings.Split(strings.Trim(name, "."), ".") {
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	return append(msg, 0)
+}
+
+// dnsRecord is a resource record of the answers and additional records of a
+// response, whose data is decoded according to its type.
+type dnsRecord struct {
+	name, target string
+	typ, port    uint16
+	ip           net.IP
+	txt          []string
+}
+
+// parseDNS returns the records of the A, PTR, SRV and TXT types of a DNS
+// message.
+func parseDNS(msg []byte) ([]dnsRecord, error) {
+	if len(msg) < 12 {
+		return nil, errors.New("short DNS message")
+	}
+	questions := int(binary.BigEndian.Uint16(msg[4:]))
+	count := int(binary.BigEndian.Uint16(msg[6:])) + int(binary.BigEndian.Uint16(msg[8:])) + int(binary.BigEndian.Uint16(msg[10:]))
+	off := 12
+	for i := 0; i < questions; i++ {
+		_, n, err := readDNSName(msg, off)
+		if err != nil {
+			return nil, err
+		}
+		off = n + 4
+	}
+	var records []dnsRecord
+	for i := 0; i < count; i++ {
+		name, n, err := readDNSName(msg, off)
+		if err != nil {
+			return nil, err
+		}
+		if n+10 > len(msg) {
+			return nil, errors.New("short DNS record")
+		}
+		r := dnsRecord{name: name, typ: binary.BigEndian.Uint16(msg[n:])}
+		length := int(binary.BigEndian.Uint16(msg[n+8:]))
+		data := n + 10
+		off = data + length
+		if off > len(msg) {
+			return nil, errors.New("short DNS record")
+		}
+		switch r.typ {
+		case dnsTypeA:
+			if length != 4 {
+				continue
+			}
+			r.ip = net.IP(append([]byte(nil), msg[data:off]...))
+		case dnsTypePTR:
+			if r.target, _, err = readDNSName(msg, data); err != nil {
+				return nil, err
+			}
+		case dnsTypeSRV:
+			if length < 7 {
+				continue
+			}
+			r.port = binary.BigEndian.Uint16(msg[data+4:])
+			if r.target, _, err = readDNSName(msg, data+6); err != nil {
+				return nil, err
+			}
+		case dnsTypeTXT:
+			for j := data; j < off; j += 1 + int(msg[j]) {
+				if end := j + 1 + int(msg[j]); end <= off {
+					r.txt = append(r.txt, string(msg[j+1:end]))
+				}
+			}
+		default:
+			continue
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// readDNSName returns the name at off, without its final dot, and the offset
+// after it, following the compression pointers.
+func readDNSName(msg []byte, off int) (string, int, error) {
+	var labels []string
+	end := -1
+	for jumps := 0; ; {
+		if off >= len(msg) {
+			return "", 0, errors.New("short DNS name")
+		}
+		length := int(msg[off])
+		switch {
+		case length == 0:
+			if end < 0 {
+				end = off + 1
+			}
+			return strings.Join(labels, "."), end, nil
+		case length&0xc0 == 0xc0:
+			if off+1 >= len(msg) || jumps > 16 {
+				return "", 0, errors.New("invalid DNS name")
+			}
+			if end < 0 {
+				end = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3fff)
+			jumps++
+		default:
+			if off+1+length > len(msg) {
+				return "", 0, errors.New("short DNS name")
+			}
+			labels = append(labels, string(msg[off+1:off+1+length]))
+			off += 1 + length
+		}
+	}
+}
+
+// mdnsCache gathers the records of the replies, which may come in several
+// messages, and tells the instances of the services once they are known.
+type mdnsCache struct {
+	services []string
+
+	mu        sync.Mutex
+	instances []string
+	srv       map[string]dnsRecord
+	txt       map[string][]string
+	ips       map[string]net.IP
+	// sent tells the instances known, true once delivered.
+	sent map[string]bool
+}
+
+// add adds the records of a reply from an IP and returns the instances newly
+// known, whose SRV record was received.
+func (c *mdnsCache) add(records []dnsRecord, from net.IP) []ProbeMatch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.srv == nil {
+		c.srv, c.txt, c.ips = map[string]dnsRecord{}, map[string][]string{}, map[string]net.IP{}
+		c.sent = map[string]bool{}
+	}
+	for _, r := range records {
+		name := strings.ToLower(r.name)
+		switch r.typ {
+		case dnsTypePTR:
+			if _, ok := c.sent[strings.ToLower(r.target)]; !ok && c.service(name) != "" {
+				c.sent[strings.ToLower(r.target)] = false
+				c.instances = append(c.instances, r.target)
+			}
+		case dnsTypeSRV:
+			c.srv[name] = r
+		case dnsTypeTXT:
+			c.txt[name] = r.txt
+		case dnsTypeA:
+			c.ips[name] = r.ip
+		}
+	}
+
+	var out []ProbeMatch
+	for _, instance := range c.instances {
+		key := strings.ToLower(instance)
+		srv, ok := c.srv[key]
+		if !ok || c.sent[key] {
+			continue
+		}
+		ip := c.ips[strings.ToLower(srv.target)]
+		if ip == nil {
+			ip = from
+		}
+		c.sent[key] = true
+		out = append(out, mdnsMatch(instance, c.service(key), srv.port, ip, c.txt[key]))
+	}
+	return out
+}
+
+// service returns the service queried of a name of service or of instance.
+func (c *mdnsCache) service(name string) string {
+	for _, service := range c.services {
+		if suffix := strings.ToLower(service) + ".local"; name == suffix || strings.HasSuffix(name, "."+suffix) {
+			return service
+		}
+	}
+	return ""
+}
+
+// mdnsMatch returns the ProbeMatch of an instance of a service.
+func mdnsMatch(instance, service string, port uint16, ip net.IP, txt []string) ProbeMatch {
+	m := ProbeMatch{From: ip, Sources: []string{"mdns"}}
+	if n := len(instance) - len(service+".local") - 1; n > 0 {
+		m.Scopes = []Scope{NewScope(ScopeName, instance[:n])}
+	}
+	u := url.URL{Scheme: "http", Host: net.JoinHostPort(ip.String(), strconv.Itoa(int(port))), Path: "/onvif/device_service"}
+	if port == 443 {
+		u.Scheme = "https"
+	}
+	m.XAddrs = []url.URL{u}
+	for _, kv := range txt {
+		if k, v, ok := strings.Cut(kv, "="); ok && (strings.EqualFold(k, "macaddress") || strings.EqualFold(k, "mac")) {
+			m.MAC = parseHexMAC(v)
+		}
+	}
+	return m
+}
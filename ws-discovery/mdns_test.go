@@ -0,0 +1,64 @@
+package wsdiscovery
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+)
+
+// mdnsResponse returns a response of an Axis camera, whose names after the
+// first are compressed.
+func mdnsResponse() []byte {
+	msg := make([]byte, 12)
+	binary.BigEndian.PutUint16(msg[2:], 0x8400)
+	binary.BigEndian.PutUint16(msg[6:], 1)
+	binary.BigEndian.PutUint16(msg[10:], 3)
+	// record appends a record and returns the offset of its data.
+	record := func(name []byte, typ uint16, data []byte) int {
+		msg = append(msg, name...)
+		msg = binary.BigEndian.AppendUint16(msg, typ)
+		msg = binary.BigEndian.AppendUint16(msg, 1)
+		msg = binary.BigEndian.AppendUint32(msg, 120)
+		msg = binary.BigEndian.AppendUint16(msg, uint16(len(data)))
+		msg = append(msg, data...)
+		return len(msg) - len(data)
+	}
+	service := []byte{0xc0, 12}
+	ptr := record(appendDNSName(nil, "_axis-video._tcp.local"), dnsTypePTR, append([]byte{10}, append([]byte("AXIS M3045"), service...)...))
+	instance := []byte{0xc0, byte(ptr)}
+	srv := []byte{0, 0, 0, 0, 0, 80}
+	record(instance, dnsTypeSRV, append(srv, appendDNSName(nil, "axis-00408c123456.local")...))
+	record(instance, dnsTypeTXT, append([]byte{23}, "macaddress=00408C123456"...))
+	record(appendDNSName(nil, "axis-00408c123456.local"), dnsTypeA, []byte{192, 168, 1, 10})
+	return msg
+}
+
+func TestMDNS(t *testing.T) {
+	if q := mdnsQuery([]string{"_onvif._tcp"}); len(q) != 12+len("_onvif._tcp.local")+2+4 || q[5] != 1 {
+		t.Errorf("unexpected query %v", q)
+	}
+	records, err := parseDNS(mdnsResponse())
+	if err != nil || len(records) != 4 {
+		t.Fatalf("unexpected %+v, %v", records, err)
+	}
+	if records[1].name != "AXIS M3045._axis-video._tcp.local" || records[1].port != 80 || records[1].target != "axis-00408c123456.local" {
+		t.Errorf("unexpected SRV %+v", records[1])
+	}
+
+	cache := &mdnsCache{services: []string{"_onvif._tcp", "_axis-video._tcp"}}
+	matches := cache.add(records, net.IPv4(192, 168, 1, 11))
+	if len(matches) != 1 {
+		t.Fatalf("unexpected %+v", matches)
+	}
+	m := matches[0]
+	if !m.From.Equal(net.IPv4(192, 168, 1, 10)) || m.XAddrs[0].String() != "http://192.168.1.10:80/onvif/device_service" ||
+		m.ScopeValues(ScopeName)[0] != "AXIS M3045" || m.MAC.String() != "00:40:8c:12:34:56" {
+		t.Errorf("unexpected %+v", m)
+	}
+	if again := cache.add(records, nil); len(again) != 0 {
+		t.Errorf("unexpected again %+v", again)
+	}
+	if _, err := parseDNS(mdnsResponse()[:40]); err == nil {
+		t.Error("expected an error for a truncated message")
+	}
+}
@@ -0,0 +1,227 @@
+package wsdiscovery
+
+import (
+	"encoding/xml"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// envelope is a WS-Discovery message, its elements are matched by their local
+// name whatever the version of the namespaces.
+type envelope struct {
+	// Attrs hold the namespaces declared on the envelope.
+	Attrs  []xml.Attr `xml:",any,attr"`
+	Header struct {
+		Action    string `xml:"Action"`
+		MessageID string `xml:"MessageID"`
+		RelatesTo string `xml:"RelatesTo"`
+	} `xml:"Header"`
+	Body struct {
+		Hello        *endpointElement `xml:"Hello"`
+		Bye          *endpointElement `xml:"Bye"`
+		ProbeMatches *struct {
+			ProbeMatch []endpointElement `xml:"ProbeMatch"`
+		} `xml:"ProbeMatches"`
+		ResolveMatches *struct {
+			ResolveMatch []endpointElement `xml:"ResolveMatch"`
+		} `xml:"ResolveMatches"`
+		Probe   *probeElement    `xml:"Probe"`
+		Resolve *endpointElement `xml:"Resolve"`
+	} `xml:"Body"`
+}
+
+// probeElement is a Probe, whose types are qualified names resolved with the
+// namespaces declared on the elements.
+type probeElement struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Types   struct {
+		Attrs []xml.Attr `xml:",any,attr"`
+		Value string     `xml:",chardata"`
+	} `xml:"Types"`
+	Scopes struct {
+		MatchBy string `xml:"MatchBy,attr"`
+		Value   string `xml:",chardata"`
+	} `xml:"Scopes"`
+}
+
+// endpointElement is the description of a target service in a Hello, a Bye
+// or a ProbeMatch.
+type endpointElement struct {
+	// XMLName tells the version of the message.
+	XMLName           xml.Name
+	EndpointReference struct {
+		Address string `xml:"Address"`
+	} `xml:"EndpointReference"`
+	Types           string `xml:"Types"`
+	Scopes          string `xml:"Scopes"`
+	XAddrs          string `xml:"XAddrs"`
+	MetadataVersion string `xml:"MetadataVersion"`
+}
+
+func (e endpointElement) endpoint() Endpoint {
+	ep := Endpoint{
+		Address: strings.TrimSpace(e.EndpointReference.Address),
+		Types:   strings.Fields(e.Types),
+		Version: versionOf(e.XMLName.Space),
+	}
+	// A missing or invalid version is taken as 0.
+	if v, err := strconv.ParseUint(strings.TrimSpace(e.MetadataVersion), 10, 32); err == nil {
+		ep.MetadataVersion = uint(v)
+	}
+	for _, scope := range strings.Fields(e.Scopes) {
+		ep.Scopes = append(ep.Scopes, Scope(scope))
+	}
+	for _, xaddr := range strings.Fields(e.XAddrs) {
+		// The relative or invalid addresses some devices send are of no use.
+		if u, err := url.Parse(xaddr); err == nil && u.IsAbs() && u.Host != "" {
+			ep.XAddrs = append(ep.XAddrs, *u)
+		}
+	}
+	return ep
+}
+
+// Endpoint is a target service, e.g. a camera, as described in its
+// announcements and in its replies to a Probe.
+type Endpoint struct {
+	// Address is the address of its endpoint reference, which identifies it
+	// whatever its IP, e.g. urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f
+	Address string
+	// Types are qualified names, e.g. dn:NetworkVideoTransmitter
+	Types  []string
+	Scopes []Scope
+	// XAddrs are the URLs of its device service.
+	XAddrs []url.URL
+	// MetadataVersion changes when the types, scopes or XAddrs change.
+	MetadataVersion uint
+	// Version is the version of WS-Discovery of its messages.
+	Version Version
+}
+
+// UUID returns the UUID of Address, when it is a urn:uuid: or uuid: URI.
+func (e Endpoint) UUID() (uuid.UUID, bool) {
+	address := strings.TrimPrefix(strings.TrimPrefix(e.Address, "urn:"), "uuid:")
+	if address == e.Address {
+		return uuid.Nil, false
+	}
+	id, err := uuid.FromString(address)
+	return id, err == nil
+}
+
+// ScopeValues returns the values of the ONVIF scopes of a category, e.g. the
+// names of the device for "name".
+func (e Endpoint) ScopeValues(category string) []string {
+	var out []string
+	for _, scope := range e.Scopes {
+		if c, v, ok := scope.ONVIF(); ok && c == category {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// IsDiscoveryProxy tells if the endpoint is a discovery proxy, of type
+// d:DiscoveryProxy.
+func (e Endpoint) IsDiscoveryProxy() bool {
+	for _, t := range e.Types {
+		if t[strings.LastIndex(t, ":")+1:] == "DiscoveryProxy" {
+			return true
+		}
+	}
+	return false
+}
+
+// ProbeMatch is the reply of a target service to a Probe, or a device found
+// by another Backend.
+type ProbeMatch struct {
+	Endpoint
+	// From is the IP the reply was sent from.
+	From net.IP
+	// MAC is the hardware address of the device, when a Backend or the ARP
+	// table tells it.
+	MAC net.HardwareAddr
+	// Sources are the names of the backends that found it, set by
+	// DiscoverWith.
+	Sources []string
+}
+
+func parseEnvelope(data []byte) (*envelope, error) {
+	env := &envelope{}
+	if err := xml.Unmarshal(data, env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// parseProbeMatches returns the ProbeMatch of a reply to the Probe of
+// MessageID probeID, or nil for another message. The Hello of a discovery
+// proxy replying to a multicast Probe, and the ResolveMatch of a reply to a
+// Resolve, are returned as ProbeMatch too.
+func parseProbeMatches(data []byte, probeID string, from net.IP) ([]ProbeMatch, error) {
+	env, err := parseEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	if probeID == "" {
+		return env.probeMatches(from), nil
+	}
+	return env.probeMatches(from, probeID), nil
+}
+
+// probeMatches returns the ProbeMatch of a reply to one of the messages of
+// MessageID ids, to any message when there is none, see parseProbeMatches.
+func (env *envelope) probeMatches(from net.IP, ids ...string) []ProbeMatch {
+	relatesTo := strings.TrimSpace(env.Header.RelatesTo)
+	related := false
+	for _, id := range ids {
+		related = related || relatesTo == id
+	}
+	if env.Body.Hello != nil && related {
+		if ep := env.Body.Hello.endpoint(); ep.IsDiscoveryProxy() {
+			return []ProbeMatch{{Endpoint: ep, From: from}}
+		}
+	}
+	if len(ids) > 0 && relatesTo != "" && !related {
+		return nil
+	}
+	var elements []endpointElement
+	switch {
+	case env.Body.ProbeMatches != nil:
+		elements = env.Body.ProbeMatches.ProbeMatch
+	case env.Body.ResolveMatches != nil:
+		elements = env.Body.ResolveMatches.ResolveMatch
+	default:
+		return nil
+	}
+	var out []ProbeMatch
+	for _, m := range elements {
+		out = append(out, ProbeMatch{Endpoint: m.endpoint(), From: from})
+	}
+	return out
+}
+
+// dedupe keeps a ProbeMatch per endpoint reference, the one of the latest
+// MetadataVersion, in the order of their first reply.
+func dedupe(matches []ProbeMatch) []ProbeMatch {
+	index := map[string]int{}
+	var out []ProbeMatch
+	for _, m := range matches {
+		key := m.Address
+		if key == "" && len(m.XAddrs) > 0 {
+			key = m.XAddrs[0].String()
+		}
+		if i, ok := index[key]; ok {
+			if m.MetadataVersion > out[i].MetadataVersion {
+				out[i] = m
+			}
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, m)
+	}
+	return out
+}
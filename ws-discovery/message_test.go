@@ -0,0 +1,83 @@
+package wsdiscovery
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+const probeMatches = `<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:wsa="http://schemas.xmlsoap.org/ws/2004/08/addressing" xmlns:d="http://schemas.xmlsoap.org/ws/2005/04/discovery" xmlns:dn="http://www.onvif.org/ver10/network/wsdl">
+<SOAP-ENV:Header>
+	<wsa:MessageID>uuid:00000000-0000-4000-8000-000000000003</wsa:MessageID>
+	<wsa:RelatesTo>uuid:00000000-0000-4000-8000-0000000000aa</wsa:RelatesTo>
+</SOAP-ENV:Header>
+<SOAP-ENV:Body><d:ProbeMatches><d:ProbeMatch>
+	<wsa:EndpointReference><wsa:Address>urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f</wsa:Address></wsa:EndpointReference>
+	<d:Types>dn:NetworkVideoTransmitter</d:Types>
+	<d:Scopes>onvif://www.onvif.org/name/Camera%201 onvif://www.onvif.org/location/city/Paris</d:Scopes>
+	<d:XAddrs>http://192.168.1.10/onvif/device_service /relative http://[fe80::1%25eth0]:8080/onvif/device_service</d:XAddrs>
+	<d:MetadataVersion>%d</d:MetadataVersion>
+</d:ProbeMatch></d:ProbeMatches></SOAP-ENV:Body>
+</SOAP-ENV:Envelope>`
+
+func TestParseProbeMatches(t *testing.T) {
+	from := net.IPv4(192, 168, 1, 10)
+	matches, err := parseProbeMatches([]byte(strings.Replace(probeMatches, "%d", "2", 1)), "uuid:00000000-0000-4000-8000-0000000000aa", from)
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("unexpected %+v, %v", matches, err)
+	}
+	m := matches[0]
+	if id, ok := m.UUID(); !ok || id.String() != "4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f" {
+		t.Errorf("unexpected UUID %v", id)
+	}
+	if len(m.XAddrs) != 2 || m.XAddrs[0].Host != "192.168.1.10" || m.XAddrs[1].Port() != "8080" {
+		t.Errorf("unexpected XAddrs %v", m.XAddrs)
+	}
+	if names := m.ScopeValues("name"); len(names) != 1 || names[0] != "Camera 1" {
+		t.Errorf("unexpected names %q", names)
+	}
+	if locations := m.ScopeValues("location"); len(locations) != 1 || locations[0] != "city/Paris" {
+		t.Errorf("unexpected locations %q", locations)
+	}
+	if !m.From.Equal(from) || m.MetadataVersion != 2 {
+		t.Errorf("unexpected %+v", m)
+	}
+
+	if other, err := parseProbeMatches([]byte(strings.Replace(probeMatches, "%d", "2", 1)), "uuid:other", from); err != nil || other != nil {
+		t.Errorf("unexpected reply to another probe %+v, %v", other, err)
+	}
+
+	newer, _ := parseProbeMatches([]byte(strings.Replace(probeMatches, "%d", "3", 1)), "", from)
+	deduped := dedupe(append(matches, newer...))
+	if len(deduped) != 1 || deduped[0].MetadataVersion != 3 {
+		t.Errorf("unexpected %+v", deduped)
+	}
+}
+
+func TestVersion11(t *testing.T) {
+	matches11 := strings.NewReplacer(
+		"http://schemas.xmlsoap.org/ws/2004/08/addressing", addressingNamespace11,
+		"http://schemas.xmlsoap.org/ws/2005/04/discovery", discoveryNamespace11,
+		"%d", "1").Replace(probeMatches)
+	matches, err := parseProbeMatches([]byte(matches11), "uuid:00000000-0000-4000-8000-0000000000aa", nil)
+	if err != nil || len(matches) != 1 || matches[0].Version != Version11 || len(matches[0].XAddrs) != 2 {
+		t.Fatalf("unexpected %+v, %v", matches, err)
+	}
+
+	probe := buildProbeMessageTo(Version11, "00000000-0000-4000-8000-0000000000aa", "", []string{"onvif://www.onvif.org/name"},
+		[]string{"dn:NetworkVideoTransmitter"}, map[string]string{"dn": "http://www.onvif.org/ver10/network/wsdl"}).String()
+	if !strings.Contains(probe, discoveryNamespace11) || strings.Contains(probe, discoveryNamespace+`"`) ||
+		!strings.Contains(probe, "urn:docs-oasis-open-org:ws-dd:ns:discovery:2009:01") {
+		t.Errorf("unexpected probe %s", probe)
+	}
+
+	r := NewResponder("urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f", nil, "onvif://www.onvif.org/name/Camera%201")
+	reply, err := r.handle([]byte(probe), false)
+	if err != nil || !strings.Contains(string(reply), discoveryNamespace11+"/ProbeMatches") ||
+		!strings.Contains(string(reply), addressingNamespace11+"/anonymous") {
+		t.Fatalf("unexpected reply %s, %v", reply, err)
+	}
+	if matches, _ := parseProbeMatches(reply, "uuid:00000000-0000-4000-8000-0000000000aa", nil); len(matches) != 1 || matches[0].Version != Version11 {
+		t.Errorf("unexpected %+v", matches)
+	}
+}
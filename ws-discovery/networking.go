@@ -10,19 +10,16 @@ package wsdiscovery
  *******************************************************/
 
 import (
-	"errors"
-	"net"
-	"os"
+	"context"
 	"time"
 
 	"github.com/gofrs/uuid"
-	"golang.org/x/net/ipv4"
 )
 
-const bufSize = 8192
-
-// SendProbe to device
-func SendProbe(interfaceName string, scopes, types []string, namespaces map[string]string) ([]string, error) {
+// SendProbe to device, it returns the replies received within a second,
+// one per endpoint reference but the discovery proxies. See Discover for more
+// control.
+func SendProbe(interfaceName string, scopes, types []string, namespaces map[string]string) ([]ProbeMatch, error) {
 	// Creating UUID Version 4
 	uuidV4 := uuid.Must(uuid.NewV4())
 	//fmt.Printf("UUIDv4: %s\n", uuidV4)
@@ -42,56 +39,17 @@ func SendProbe(interfaceName string, scopes, types []string, namespaces map[stri
 	//</Body>
 	//</Envelope>`
 
-	return sendUDPMulticast(probeSOAP.String(), interfaceName)
-}
-
-func sendUDPMulticast(msg string, interfaceName string) ([]string, error) {
-	c, err := net.ListenPacket("udp4", "0.0.0.0:0")
-	if err != nil {
-		return nil, err
-	}
-	defer c.Close()
-
-	iface, err := net.InterfaceByName(interfaceName)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	found, err := discover(ctx, []request{{uuidV4.String(), probeSOAP.String()}}, DiscoverOptions{Interfaces: []string{interfaceName}, TTL: 2})
 	if err != nil {
 		return nil, err
 	}
-
-	p := ipv4.NewPacketConn(c)
-	group := net.IPv4(239, 255, 255, 250)
-	if err := p.JoinGroup(iface, &net.UDPAddr{IP: group}); err != nil {
-		return nil, err
-	}
-
-	dst := &net.UDPAddr{IP: group, Port: 3702}
-	data := []byte(msg)
-	for _, ifi := range []*net.Interface{iface} {
-		if err := p.SetMulticastInterface(ifi); err != nil {
-			return nil, err
-		}
-		if err := p.SetMulticastTTL(2); err != nil {
-			return nil, err
-		}
-		if _, err := p.WriteTo(data, nil, dst); err != nil {
-			return nil, err
-		}
-	}
-
-	if err := p.SetReadDeadline(time.Now().Add(time.Second * 1)); err != nil {
-		return nil, err
-	}
-
-	var result []string
-	for {
-		b := make([]byte, bufSize)
-		n, _, _, err := p.ReadFrom(b)
-		if err != nil {
-			if !errors.Is(err, os.ErrDeadlineExceeded) {
-				return nil, err
-			}
-			break
+	var matches []ProbeMatch
+	for m := range found {
+		if !m.IsDiscoveryProxy() {
+			matches = append(matches, m)
 		}
-		result = append(result, string(b[0:n]))
 	}
-	return result, nil
+	return dedupe(matches), nil
 }
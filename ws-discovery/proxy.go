@@ -0,0 +1,210 @@
+package wsdiscovery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// ProbeProxy sends a Probe to a discovery proxy and returns the matches it
+// knows of. The XAddrs of the proxy are tried in turn: the http and https
+// ones with SOAP over HTTP, the soap.udp ones, e.g. soap.udp://10.1.2.3:3702,
+// with unicast SOAP over UDP. The Probe is of the version of the proxy.
+func ProbeProxy(ctx context.Context, proxy Endpoint, opts DiscoverOptions) ([]ProbeMatch, error) {
+	if len(opts.Types) == 0 {
+		opts.Types = []string{"dn:NetworkVideoTransmitter"}
+		opts.Namespaces = map[string]string{"dn": "http://www.onvif.org/ver10/network/wsdl"}
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = time.Second
+	}
+	if len(proxy.XAddrs) == 0 {
+		return nil, errors.New("discovery proxy without address")
+	}
+	err := errors.New("no address of the discovery proxy is supported")
+	for _, xaddr := range proxy.XAddrs {
+		// The messages are sent to the endpoint reference of the proxy, or to
+		// its address when it is unknown, e.g. from device.GetDPAddresses.
+		destination := proxy.Address
+		if destination == "" {
+			destination = xaddr.String()
+		}
+		id := uuid.Must(uuid.NewV4()).String()
+		probe := buildProbeMessageTo(proxy.Version, id, destination, opts.Scopes, opts.Types, opts.Namespaces).String()
+		var matches []ProbeMatch
+		switch xaddr.Scheme {
+		case "http", "https":
+			matches, err = probeHTTP(ctx, xaddr, id, probe, opts.Timeout)
+		case "soap.udp":
+			var dst *net.UDPAddr
+			if dst, err = proxyUDPAddr(xaddr); err == nil {
+				matches, err = probeUnicast(ctx, dst, []request{{id, probe}}, opts)
+			}
+		default:
+			continue
+		}
+		if err == nil {
+			return matches, nil
+		}
+	}
+	return nil, err
+}
+
+// probeHTTP posts the Probe of MessageID uuid:id to a proxy and returns the
+// matches of its response.
+func probeHTTP(ctx context.Context, xaddr url.URL, id, probe string, timeout time.Duration) ([]ProbeMatch, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, xaddr.String(), bytes.NewBufferString(probe))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/soap+xml; charset=utf-8")
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discovery proxy %s: %s", xaddr.Host, resp.Status)
+	}
+	var from net.IP
+	if ips, err := net.DefaultResolver.LookupIP(ctx, "ip", xaddr.Hostname()); err == nil && len(ips) > 0 {
+		from = ips[0]
+	}
+	matches, err := parseProbeMatches(body, "uuid:"+id, from)
+	if err != nil {
+		return nil, err
+	}
+	return dedupe(matches), nil
+}
+
+// proxyUDPAddr returns the address of a soap.udp URL, on port 3702 when it has none.
+func proxyUDPAddr(xaddr url.URL) (*net.UDPAddr, error) {
+	port := multicastAddr.Port
+	if p := xaddr.Port(); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, err
+		}
+		port = n
+	}
+	return net.ResolveUDPAddr("udp", net.JoinHostPort(xaddr.Hostname(), strconv.Itoa(port)))
+}
+
+// Client probes the network in ad-hoc mode, by multicast, until a discovery
+// proxy shows up, and then in managed mode, through the proxy. It switches to
+// the managed mode when a proxy replies to its probes with its Hello, or when
+// it is given the Hello of a proxy, and back to the ad-hoc mode when the proxy
+// says Bye or fails.
+type Client struct {
+	// DiscoverOptions are the settings of the probes, whose Timeout is a
+	// second when zero.
+	DiscoverOptions
+
+	mu    sync.Mutex
+	proxy *Endpoint
+}
+
+// NewClient returns a Client in ad-hoc mode.
+func NewClient(opts DiscoverOptions) *Client {
+	return &Client{DiscoverOptions: opts}
+}
+
+// Proxy returns the discovery proxy of the managed mode, false in ad-hoc mode.
+func (c *Client) Proxy() (Endpoint, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.proxy == nil {
+		return Endpoint{}, false
+	}
+	return *c.proxy, true
+}
+
+// SetProxy switches to the managed mode with proxy, e.g. an Endpoint whose
+// XAddrs are the addresses of device.GetDPAddresses, or back to the ad-hoc
+// mode when it is nil.
+func (c *Client) SetProxy(proxy *Endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.proxy = proxy
+}
+
+// Handle switches the mode on the announcements of the discovery proxies, e.g.
+// the ones of a Listener. The other announcements are ignored.
+func (c *Client) Handle(a Announcement) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case a.Type == Hello && a.IsDiscoveryProxy():
+		ep := a.Endpoint
+		c.proxy = &ep
+	case a.Type == Bye && c.proxy != nil && a.Address != "" && a.Address == c.proxy.Address:
+		c.proxy = nil
+	}
+}
+
+// Probe returns the target services found, one per endpoint reference. In
+// managed mode it probes the proxy, and multicasts the Probe when the proxy
+// fails. In ad-hoc mode it multicasts the Probe, and then probes the proxy
+// that replied with its Hello, if any.
+func (c *Client) Probe(ctx context.Context) ([]ProbeMatch, error) {
+	opts := c.DiscoverOptions
+	if opts.Timeout == 0 {
+		opts.Timeout = time.Second
+	}
+	if proxy, ok := c.Proxy(); ok {
+		matches, err := ProbeProxy(ctx, proxy, opts)
+		if err == nil {
+			return matches, nil
+		}
+		c.forget(proxy)
+	}
+
+	found, err := Discover(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	var matches []ProbeMatch
+	var proxy *Endpoint
+	for m := range found {
+		if !m.IsDiscoveryProxy() {
+			matches = append(matches, m)
+		} else if proxy == nil {
+			ep := m.Endpoint
+			proxy = &ep
+		}
+	}
+	if proxy == nil {
+		return dedupe(matches), nil
+	}
+	c.SetProxy(proxy)
+	// The services that replied anyway are kept along the ones of the proxy.
+	managed, err := ProbeProxy(ctx, *proxy, opts)
+	if err != nil {
+		c.forget(*proxy)
+		return dedupe(matches), nil
+	}
+	return dedupe(append(managed, matches...)), nil
+}
+
+// forget switches back to the ad-hoc mode, unless another proxy was set since.
+func (c *Client) forget(proxy Endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.proxy != nil && c.proxy.Address == proxy.Address {
+		c.proxy = nil
+	}
+}
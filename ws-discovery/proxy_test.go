@@ -0,0 +1,78 @@
+package wsdiscovery
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const proxyHello = `<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://www.w3.org/2003/05/soap-envelope" xmlns:wsa="http://schemas.xmlsoap.org/ws/2004/08/addressing" xmlns:d="http://schemas.xmlsoap.org/ws/2005/04/discovery">
+<SOAP-ENV:Header>
+	<wsa:MessageID>uuid:00000000-0000-4000-8000-000000000004</wsa:MessageID>
+	<wsa:RelatesTo RelationshipType="d:Suppression">uuid:00000000-0000-4000-8000-0000000000aa</wsa:RelatesTo>
+	<wsa:Action>http://schemas.xmlsoap.org/ws/2005/04/discovery/Hello</wsa:Action>
+</SOAP-ENV:Header>
+<SOAP-ENV:Body><d:Hello>
+	<wsa:EndpointReference><wsa:Address>urn:uuid:9f0c2a7e-5d1b-4c3a-8e6f-1a2b3c4d5e6f</wsa:Address></wsa:EndpointReference>
+	<d:Types>d:DiscoveryProxy</d:Types>
+	<d:XAddrs>%s</d:XAddrs>
+</d:Hello></SOAP-ENV:Body>
+</SOAP-ENV:Envelope>`
+
+func TestProxy(t *testing.T) {
+	var to string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		env, err := parseEnvelope(b)
+		if err != nil || !strings.Contains(string(b), "/discovery/Probe") {
+			http.Error(w, "not a probe", http.StatusBadRequest)
+			return
+		}
+		to = string(b)
+		w.Write([]byte(strings.NewReplacer("%d", "1", "uuid:00000000-0000-4000-8000-0000000000aa", env.Header.MessageID).Replace(probeMatches)))
+	}))
+	defer srv.Close()
+
+	// The Hello of the proxy replying to a multicast Probe.
+	hello := strings.Replace(proxyHello, "%s", srv.URL, 1)
+	matches, err := parseProbeMatches([]byte(hello), "uuid:00000000-0000-4000-8000-0000000000aa", net.IPv4(127, 0, 0, 1))
+	if err != nil || len(matches) != 1 || !matches[0].IsDiscoveryProxy() || len(matches[0].XAddrs) != 1 {
+		t.Fatalf("unexpected %+v, %v", matches, err)
+	}
+	if other, _ := parseProbeMatches([]byte(hello), "uuid:other", nil); other != nil {
+		t.Errorf("unexpected Hello of another probe %+v", other)
+	}
+
+	c := NewClient(DiscoverOptions{})
+	c.Handle(Announcement{Type: Hello, Endpoint: matches[0].Endpoint})
+	if proxy, ok := c.Proxy(); !ok || proxy.Address != "urn:uuid:9f0c2a7e-5d1b-4c3a-8e6f-1a2b3c4d5e6f" {
+		t.Fatalf("unexpected proxy %+v", proxy)
+	}
+	found, err := c.Probe(context.Background())
+	if err != nil || len(found) != 1 || found[0].Address != "urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f" {
+		t.Fatalf("unexpected %+v, %v", found, err)
+	}
+	if !strings.Contains(to, ">urn:uuid:9f0c2a7e-5d1b-4c3a-8e6f-1a2b3c4d5e6f</a:To>") {
+		t.Errorf("Probe not sent to the proxy %s", to)
+	}
+
+	// A proxy known by its address only, e.g. from device.GetDPAddresses.
+	u, _ := url.Parse(srv.URL + "/discovery")
+	if found, err := ProbeProxy(context.Background(), Endpoint{XAddrs: []url.URL{*u}}, DiscoverOptions{}); err != nil || len(found) != 1 {
+		t.Errorf("unexpected %+v, %v", found, err)
+	}
+
+	c.Handle(Announcement{Type: Bye, Endpoint: Endpoint{Address: "urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f"}})
+	if _, ok := c.Proxy(); !ok {
+		t.Error("Bye of a device ended the managed mode")
+	}
+	c.Handle(Announcement{Type: Bye, Endpoint: Endpoint{Address: "urn:uuid:9f0c2a7e-5d1b-4c3a-8e6f-1a2b3c4d5e6f"}})
+	if _, ok := c.Proxy(); ok {
+		t.Error("Bye of the proxy did not end the managed mode")
+	}
+}
@@ -0,0 +1,51 @@
+package wsdiscovery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// EndpointAddress returns the address of an endpoint reference given as a
+// bare UUID, e.g. by device.GetEndpointReference, as a urn:uuid: URI. The
+// other addresses are returned as they are.
+func EndpointAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if id, err := uuid.FromString(address); err == nil && !strings.Contains(address, ":") {
+		return "urn:uuid:" + id.String()
+	}
+	return address
+}
+
+// Resolve multicasts a Resolve of the target service of an endpoint
+// reference, e.g. urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f, and returns
+// its description, with its current XAddrs. It fails when the service did not
+// reply within opts.Timeout, a second when zero, or before ctx is done. The
+// Types and Scopes of opts are ignored.
+func Resolve(ctx context.Context, address string, opts DiscoverOptions) (Endpoint, error) {
+	address = EndpointAddress(address)
+	if opts.Timeout == 0 {
+		opts.Timeout = time.Second
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var resolves []request
+	for _, v := range versionsOf(opts.Versions) {
+		id := uuid.Must(uuid.NewV4()).String()
+		resolves = append(resolves, request{id, buildResolveMessage(v, id, address).String()})
+	}
+	found, err := discover(ctx, resolves, opts)
+	if err != nil {
+		return Endpoint{}, err
+	}
+	for m := range found {
+		// A service without XAddrs has to be probed, the next reply may be better.
+		if m.Address == address && len(m.XAddrs) > 0 {
+			return m.Endpoint, nil
+		}
+	}
+	return Endpoint{}, fmt.Errorf("no reply to the Resolve of %s", address)
+}
@@ -0,0 +1,449 @@
+package wsdiscovery
+
+import (
+	"context"
+	"encoding/xml"
+	"math/rand"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/gofrs/uuid"
+	"github.com/ritj/onvif/gosoap"
+	"github.com/ritj/onvif/xsd/onvif"
+	"golang.org/x/net/ipv4"
+)
+
+// appMaxDelay bounds the random delay of the replies to the multicast
+// messages, see WS-Discovery.
+const appMaxDelay = 500 * time.Millisecond
+
+// The discovery modes of a target service, see device.SetDiscoveryMode
+const (
+	Discoverable    onvif.DiscoveryMode = "Discoverable"
+	NonDiscoverable onvif.DiscoveryMode = "NonDiscoverable"
+)
+
+// Responder is a target service, e.g. a device implemented in Go, that
+// announces itself with Hello and Bye and answers the Probe and Resolve of
+// the clients.
+type Responder struct {
+	// Address is its endpoint reference, e.g. urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f
+	Address string
+	// Types are qualified names, e.g. dn:NetworkVideoTransmitter, whose
+	// prefixes are declared in Namespaces.
+	Types      []string
+	Namespaces map[string]string
+	// XAddrs are the URLs of its device service.
+	XAddrs []url.URL
+	// Interfaces are the names of the interfaces it answers on, the default
+	// multicast interface when empty.
+	Interfaces []string
+	// OnError is called with the errors of the reception and of the
+	// announcements. They are ignored when it is nil.
+	OnError func(error)
+	// Versions are the versions of WS-Discovery of its Hello and Bye,
+	// Version2005 when empty. The clients are replied in their version.
+	Versions []Version
+
+	mu            sync.Mutex
+	scopes        []Scope
+	version       uint
+	mode          onvif.DiscoveryMode
+	instanceID    uint
+	messageNumber uint
+	conn          *ipv4.PacketConn
+	ifaces        []*net.Interface
+	// stopAnnounce stops the retransmissions of the last announcement,
+	// superseded by the next one.
+	stopAnnounce context.CancelFunc
+	// announcing counts the announcements being retransmitted.
+	announcing sync.WaitGroup
+	// sendMu serializes the choice of an interface and the writes to it.
+	sendMu sync.Mutex
+}
+
+// NewResponder returns a discoverable NetworkVideoTransmitter, identified by
+// a new urn:uuid: when address is empty.
+func NewResponder(address string, xaddrs []url.URL, scopes ...Scope) *Responder {
+	if address == "" {
+		address = "urn:uuid:" + uuid.Must(uuid.NewV4()).String()
+	}
+	return &Responder{
+		Address: address,
+		Types:   []string{"dn:NetworkVideoTransmitter", "tds:Device"},
+		Namespaces: map[string]string{
+			"dn":  "http://www.onvif.org/ver10/network/wsdl",
+			"tds": "http://www.onvif.org/ver10/device/wsdl",
+		},
+		XAddrs:  xaddrs,
+		scopes:  scopes,
+		version: 1,
+		mode:    Discoverable,
+	}
+}
+
+// Scopes returns the scopes of the service.
+func (r *Responder) Scopes() []Scope {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Scope(nil), r.scopes...)
+}
+
+// SetScopes replaces the scopes of the service, e.g. after device.SetScopes,
+// increments its MetadataVersion and announces them with a Hello.
+func (r *Responder) SetScopes(scopes []Scope) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scopes = append([]Scope(nil), scopes...)
+	r.version++
+	if r.mode != NonDiscoverable {
+		r.announce(Hello)
+	}
+}
+
+// MetadataVersion returns the version of the types, scopes and XAddrs.
+func (r *Responder) MetadataVersion() uint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.version
+}
+
+// DiscoveryMode returns the discovery mode of the service.
+func (r *Responder) DiscoveryMode() onvif.DiscoveryMode {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.mode
+}
+
+// SetDiscoveryMode applies device.SetDiscoveryMode. A NonDiscoverable service
+// says Bye and then ignores the multicast messages, only a Probe sent to its
+// IP is answered. A service made Discoverable again says Hello.
+func (r *Responder) SetDiscoveryMode(mode onvif.DiscoveryMode) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if mode == r.mode {
+		return
+	}
+	if mode == NonDiscoverable {
+		r.announce(Bye)
+	}
+	r.mode = mode
+	if mode != NonDiscoverable {
+		r.announce(Hello)
+	}
+}
+
+// Run joins the multicast group, says Hello and answers the clients until ctx
+// is done, then it says Bye.
+func (r *Responder) Run(ctx context.Context) error {
+	conn, err := listenGroup(r.Interfaces)
+	if err != nil {
+		return err
+	}
+	var ifaces []*net.Interface
+	for _, name := range r.Interfaces {
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			conn.Close()
+			return err
+		}
+		ifaces = append(ifaces, iface)
+	}
+	p := ipv4.NewPacketConn(conn)
+	// The destination tells the multicast messages from the unicast ones,
+	// without it all are taken as multicast.
+	p.SetControlMessage(ipv4.FlagDst, true)
+	// ListenMulticastUDP disables the loopback, the clients of the host would
+	// miss the announcements.
+	if err := p.SetMulticastLoopback(true); err != nil {
+		p.Close()
+		return err
+	}
+
+	r.mu.Lock()
+	r.conn, r.ifaces = p, ifaces
+	r.instanceID = uint(time.Now().Unix())
+	r.messageNumber = 0
+	if r.mode != NonDiscoverable {
+		r.announce(Hello)
+	}
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		r.mu.Lock()
+		r.conn = nil
+		if r.stopAnnounce != nil {
+			r.stopAnnounce()
+		}
+		r.mu.Unlock()
+		r.announcing.Wait()
+		p.Close()
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+		r.mu.Lock()
+		if r.mode != NonDiscoverable {
+			r.announce(Bye)
+		}
+		r.conn = nil
+		r.mu.Unlock()
+		// The Bye is retransmitted before the connection is closed.
+		r.announcing.Wait()
+		p.Close()
+	}()
+
+	buf := make([]byte, 65535)
+	for {
+		n, cm, src, err := p.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			r.fail(err)
+			return err
+		}
+		multicast := cm == nil || cm.Dst == nil || cm.Dst.IsMulticast()
+		reply, err := r.handle(buf[:n], multicast)
+		if err != nil {
+			r.fail(err)
+			continue
+		}
+		if reply == nil {
+			continue
+		}
+		go func(src net.Addr) {
+			// The replies to a multicast message are delayed, not to flood
+			// the client with the replies of all the services at once.
+			if multicast {
+				select {
+				case <-time.After(time.Duration(rand.Int63n(int64(appMaxDelay)))):
+				case <-ctx.Done():
+					return
+				}
+			}
+			if _, err := p.WriteTo(reply, nil, src); err != nil && ctx.Err() == nil {
+				r.fail(err)
+			}
+		}(src)
+	}
+}
+
+// handle returns the reply to a message, nil when it is not answered.
+func (r *Responder) handle(data []byte, multicast bool) ([]byte, error) {
+	env, err := parseEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if multicast && r.mode == NonDiscoverable {
+		return nil, nil
+	}
+	relatesTo := strings.TrimSpace(env.Header.MessageID)
+	switch {
+	case env.Body.Probe != nil:
+		if !r.matchProbe(env, env.Body.Probe) {
+			return nil, nil
+		}
+		matches := etree.NewElement("d:ProbeMatches")
+		matches.AddChild(r.endpointElement("d:ProbeMatch", true))
+		return []byte(r.message(versionOf(env.Body.Probe.XMLName.Space), "ProbeMatches", relatesTo, matches)), nil
+	case env.Body.Resolve != nil:
+		if strings.TrimSpace(env.Body.Resolve.EndpointReference.Address) != r.Address {
+			return nil, nil
+		}
+		matches := etree.NewElement("d:ResolveMatches")
+		matches.AddChild(r.endpointElement("d:ResolveMatch", true))
+		return []byte(r.message(versionOf(env.Body.Resolve.XMLName.Space), "ResolveMatches", relatesTo, matches)), nil
+	}
+	return nil, nil
+}
+
+// matchProbe tells if the service has all the types and scopes of a Probe.
+func (r *Responder) matchProbe(env *envelope, probe *probeElement) bool {
+	for _, t := range strings.Fields(probe.Types.Value) {
+		prefix, local := splitQName(t)
+		namespace := lookupNamespace(prefix, probe.Types.Attrs, probe.Attrs, env.Attrs)
+		found := false
+		for _, own := range r.Types {
+			ownPrefix, ownLocal := splitQName(own)
+			ownNamespace := r.Namespaces[ownPrefix]
+			// The namespaces are compared when both are known.
+			if local == ownLocal && (namespace == "" || ownNamespace == "" || namespace == ownNamespace) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return matchScopes(strings.Fields(probe.Scopes.Value), probe.Scopes.MatchBy, r.scopes)
+}
+
+// splitQName returns the prefix and the local name of a qualified name.
+func splitQName(name string) (prefix, local string) {
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// lookupNamespace returns the namespace of a prefix in the declarations of the
+// elements, the innermost first.
+func lookupNamespace(prefix string, scopes ...[]xml.Attr) string {
+	for _, attrs := range scopes {
+		for _, attr := range attrs {
+			if (prefix == "" && attr.Name.Space == "" && attr.Name.Local == "xmlns") ||
+				(prefix != "" && attr.Name.Space == "xmlns" && attr.Name.Local == prefix) {
+				return attr.Value
+			}
+		}
+	}
+	return ""
+}
+
+// announce multicasts a Hello or a Bye on the interfaces, when running, and
+// retransmits it as the other multicast messages, see repeat, until the next
+// announcement. It is called with the lock held.
+func (r *Responder) announce(t AnnouncementType) {
+	if r.conn == nil {
+		return
+	}
+	if r.stopAnnounce != nil {
+		r.stopAnnounce()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.stopAnnounce = cancel
+	versions := r.Versions
+	if len(versions) == 0 {
+		versions = []Version{Version2005}
+	}
+	var msgs []string
+	for _, v := range versions {
+		if t == Bye {
+			msgs = append(msgs, r.message(v, "Bye", "", r.endpointElement("d:Bye", false)))
+		} else {
+			msgs = append(msgs, r.message(v, "Hello", "", r.endpointElement("d:Hello", true)))
+		}
+	}
+	conn, ifaces := r.conn, r.ifaces
+	if len(ifaces) == 0 {
+		ifaces = []*net.Interface{nil}
+	}
+	send := func() error {
+		r.sendMu.Lock()
+		defer r.sendMu.Unlock()
+		// A superseded announcement is not sent after the next one.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for _, iface := range ifaces {
+			if iface != nil {
+				if err := conn.SetMulticastInterface(iface); err != nil {
+					r.fail(err)
+					continue
+				}
+			}
+			for _, msg := range msgs {
+				if _, err := conn.WriteTo([]byte(msg), nil, multicastAddr); err != nil {
+					r.fail(err)
+				}
+			}
+		}
+		return nil
+	}
+	r.announcing.Add(1)
+	go func() {
+		defer r.announcing.Done()
+		defer cancel()
+		repeat(ctx, multicastUDPRepeat, send)
+	}()
+}
+
+// message builds a message of a version of the service, a reply to the
+// message relatesTo, or else multicast. It is called with the lock held.
+func (r *Responder) message(version Version, action, relatesTo string, body *etree.Element) string {
+	ns := version.namespaces()
+	destination := ns.adHoc
+	if relatesTo != "" {
+		destination = ns.anonymous
+	}
+	msg := gosoap.NewEmptySOAP()
+	namespaces := map[string]string{"a": ns.addressing, "d": ns.discovery}
+	for prefix, namespace := range r.Namespaces {
+		namespaces[prefix] = namespace
+	}
+	msg.AddRootNamespaces(namespaces)
+
+	var header []*etree.Element
+	actionTag := etree.NewElement("a:Action")
+	actionTag.SetText(ns.discovery + "/" + action)
+	header = append(header, actionTag)
+	msgID := etree.NewElement("a:MessageID")
+	msgID.SetText("uuid:" + uuid.Must(uuid.NewV4()).String())
+	header = append(header, msgID)
+	if relatesTo != "" {
+		relatesToTag := etree.NewElement("a:RelatesTo")
+		relatesToTag.SetText(relatesTo)
+		header = append(header, relatesToTag)
+	}
+	to := etree.NewElement("a:To")
+	to.SetText(destination)
+	header = append(header, to)
+	r.messageNumber++
+	sequence := etree.NewElement("d:AppSequence")
+	sequence.CreateAttr("InstanceId", strconv.FormatUint(uint64(r.instanceID), 10))
+	sequence.CreateAttr("MessageNumber", strconv.FormatUint(uint64(r.messageNumber), 10))
+	header = append(header, sequence)
+	msg.AddHeaderContents(header)
+
+	msg.AddBodyContent(body)
+	return msg.String()
+}
+
+// endpointElement describes the service, by its endpoint reference only when
+// full is false. It is called with the lock held.
+func (r *Responder) endpointElement(name string, full bool) *etree.Element {
+	e := etree.NewElement(name)
+	e.CreateElement("a:EndpointReference").CreateElement("a:Address").SetText(r.Address)
+	if !full {
+		return e
+	}
+	if len(r.Types) > 0 {
+		e.CreateElement("d:Types").SetText(strings.Join(r.Types, " "))
+	}
+	if len(r.scopes) > 0 {
+		scopes := make([]string, len(r.scopes))
+		for i, scope := range r.scopes {
+			scopes[i] = string(scope)
+		}
+		e.CreateElement("d:Scopes").SetText(strings.Join(scopes, " "))
+	}
+	if len(r.XAddrs) > 0 {
+		xaddrs := make([]string, len(r.XAddrs))
+		for i, xaddr := range r.XAddrs {
+			xaddrs[i] = xaddr.String()
+		}
+		e.CreateElement("d:XAddrs").SetText(strings.Join(xaddrs, " "))
+	}
+	e.CreateElement("d:MetadataVersion").SetText(strconv.FormatUint(uint64(r.version), 10))
+	return e
+}
+
+func (r *Responder) fail(err error) {
+	if r.OnError != nil {
+		r.OnError(err)
+	}
+}
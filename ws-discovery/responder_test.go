@@ -0,0 +1,135 @@
+package wsdiscovery
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResponder(t *testing.T) {
+	xaddr, _ := url.Parse("http://192.168.1.10/onvif/device_service")
+	r := NewResponder("urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f", []url.URL{*xaddr},
+		"onvif://www.onvif.org/name/Camera%201", "onvif://www.onvif.org/location/city/Paris")
+
+	probe := func(types, scopes []string) []byte {
+		return []byte(buildProbeMessage("00000000-0000-4000-8000-0000000000aa", scopes, types,
+			map[string]string{"dn": "http://www.onvif.org/ver10/network/wsdl"}).String())
+	}
+	for _, test := range []struct {
+		types, scopes []string
+		match         bool
+	}{
+		{nil, nil, true},
+		{[]string{"dn:NetworkVideoTransmitter"}, []string{"onvif://www.onvif.org/location"}, true},
+		{[]string{"dn:NetworkVideoTransmitter"}, []string{"ONVIF://WWW.ONVIF.ORG/location/city/"}, true},
+		{[]string{"dn:NetworkVideoTransmitter"}, []string{"onvif://www.onvif.org/location/cit"}, false},
+		{[]string{"dn:Other"}, nil, false},
+		{[]string{"dn:NetworkVideoTransmitter"}, []string{"onvif://www.onvif.org/name/Camera%201", "onvif://www.onvif.org/type"}, false},
+	} {
+		reply, err := r.handle(probe(test.types, test.scopes), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		matches, err := parseProbeMatches(reply, "uuid:00000000-0000-4000-8000-0000000000aa", nil)
+		if reply != nil && err != nil {
+			t.Fatal(err)
+		}
+		if (len(matches) == 1) != test.match {
+			t.Errorf("%v %v: unexpected %+v", test.types, test.scopes, matches)
+		}
+	}
+
+	reply, _ := r.handle(probe(nil, nil), true)
+	matches, _ := parseProbeMatches(reply, "", nil)
+	if len(matches) != 1 || matches[0].Address != r.Address || matches[0].MetadataVersion != 1 ||
+		len(matches[0].XAddrs) != 1 || len(matches[0].Scopes) != 2 || len(matches[0].Types) != 2 {
+		t.Fatalf("unexpected %+v", matches)
+	}
+	r.SetScopes([]Scope{"onvif://www.onvif.org/name/Gateway"})
+	reply, _ = r.handle(probe(nil, nil), true)
+	if matches, _ = parseProbeMatches(reply, "", nil); len(matches) != 1 || matches[0].MetadataVersion != 2 ||
+		matches[0].ScopeValues("name")[0] != "Gateway" {
+		t.Errorf("unexpected %+v", matches)
+	}
+
+	resolve := `<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope" xmlns:a="http://schemas.xmlsoap.org/ws/2004/08/addressing" xmlns:d="http://schemas.xmlsoap.org/ws/2005/04/discovery">
+<s:Header><a:MessageID>uuid:00000000-0000-4000-8000-0000000000bb</a:MessageID></s:Header>
+<s:Body><d:Resolve><a:EndpointReference><a:Address>%s</a:Address></a:EndpointReference></d:Resolve></s:Body></s:Envelope>`
+	if reply, _ := r.handle([]byte(strings.Replace(resolve, "%s", r.Address, 1)), true); !strings.Contains(string(reply), "ResolveMatch") ||
+		!strings.Contains(string(reply), "uuid:00000000-0000-4000-8000-0000000000bb") {
+		t.Errorf("unexpected reply to Resolve %s", reply)
+	}
+	if reply, _ := r.handle([]byte(strings.Replace(resolve, "%s", "urn:uuid:other", 1)), true); reply != nil {
+		t.Errorf("unexpected reply to the Resolve of another service %s", reply)
+	}
+
+	r.SetDiscoveryMode(NonDiscoverable)
+	if reply, _ := r.handle(probe(nil, nil), true); reply != nil {
+		t.Error("NonDiscoverable replied to a multicast Probe")
+	}
+	if reply, _ := r.handle(probe(nil, nil), false); reply == nil {
+		t.Error("NonDiscoverable ignored a unicast Probe")
+	}
+}
+
+func TestResponderAnnouncements(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	announcements, err := NewListener().Listen(ctx)
+	if err != nil {
+		t.Skip(err)
+	}
+
+	r := NewResponder("", nil, "onvif://www.onvif.org/name/Gateway")
+	run, stop := context.WithCancel(ctx)
+	defer stop()
+	done := make(chan error)
+	go func() { done <- r.Run(run) }()
+
+	var got []AnnouncementType
+	for a := range announcements {
+		if a.Address != r.Address {
+			continue
+		}
+		got = append(got, a.Type)
+		if a.Type == Hello {
+			stop()
+		} else {
+			break
+		}
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if len(got) == 0 {
+		t.Skip("multicast not looped back")
+	}
+	if len(got) != 2 || got[0] != Hello || got[1] != Bye {
+		t.Errorf("unexpected announcements %v", got)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	xaddr, _ := url.Parse("http://192.168.1.10/onvif/device_service")
+	r := NewResponder("", []url.URL{*xaddr})
+	// Not to answer the Probe of the other tests.
+	r.Types = []string{"test:Resolved"}
+	go r.Run(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	// A bare UUID, as returned by device.GetEndpointReference.
+	endpoint, err := Resolve(ctx, strings.TrimPrefix(r.Address, "urn:uuid:"), DiscoverOptions{})
+	if err != nil {
+		t.Skip("multicast not looped back: ", err)
+	}
+	if endpoint.Address != r.Address || len(endpoint.XAddrs) != 1 || endpoint.XAddrs[0] != *xaddr {
+		t.Errorf("unexpected %+v", endpoint)
+	}
+	if _, err := Resolve(ctx, "urn:uuid:00000000-0000-4000-8000-000000000000", DiscoverOptions{Timeout: 200 * time.Millisecond}); err == nil {
+		t.Error("resolved an unknown endpoint reference")
+	}
+}
@@ -0,0 +1,206 @@
+package wsdiscovery
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/ritj/onvif/device"
+	"github.com/ritj/onvif/xsd"
+	"github.com/ritj/onvif/xsd/onvif"
+)
+
+// onvifScopePrefix starts the ONVIF scopes, followed by their category and value.
+const onvifScopePrefix = "onvif://www.onvif.org/"
+
+// The categories of the ONVIF scopes
+const (
+	// ScopeName is the name of the device, e.g. Camera 1.
+	ScopeName = "name"
+	// ScopeHardware is its model, e.g. M3045.
+	ScopeHardware = "hardware"
+	// ScopeLocation is where it is, its values are hierarchical, e.g. city/Paris.
+	ScopeLocation = "location"
+	// ScopeProfile is a profile it supports, e.g. Streaming or T.
+	ScopeProfile = "Profile"
+	// ScopeType is a service it provides, e.g. video_encoder or ptz.
+	ScopeType = "type"
+)
+
+// The rules matching the scopes of a Probe with the ones of a target service,
+// the rules of WS-Discovery 1.1 are the same in its namespace.
+const (
+	// MatchByRFC3986 matches the same scheme and authority and a path whose
+	// segments are the first ones of the path of the service. It is the
+	// default rule.
+	MatchByRFC3986 = discoveryNamespace + "/rfc3986"
+	// MatchByStrcmp0 matches the same string.
+	MatchByStrcmp0 = discoveryNamespace + "/strcmp0"
+)
+
+// Scope is a scope of a target service, e.g. onvif://www.onvif.org/name/Camera%201
+type Scope string
+
+// NewScope returns the ONVIF scope of a category and a value, whose segments
+// are escaped, e.g. onvif://www.onvif.org/location/city/Saint%20Denis for
+// location and city/Saint Denis.
+func NewScope(category, value string) Scope {
+	segments := strings.Split(value, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return Scope(onvifScopePrefix + category + "/" + strings.Join(segments, "/"))
+}
+
+// ONVIF returns the category and the unescaped value of an ONVIF scope, e.g.
+// name and Camera 1, or location and city/Paris.
+func (s Scope) ONVIF() (category, value string, ok bool) {
+	rest := strings.TrimPrefix(string(s), onvifScopePrefix)
+	if rest == string(s) {
+		return "", "", false
+	}
+	category, value, _ = strings.Cut(rest, "/")
+	if v, err := url.PathUnescape(value); err == nil {
+		value = v
+	}
+	return category, value, true
+}
+
+// Match tells if s, a scope of a Probe, matches scope, a scope of a target
+// service, with the rule matchBy, MatchByRFC3986 when empty. Nothing matches
+// the other rules.
+func (s Scope) Match(scope Scope, matchBy string) bool {
+	switch strings.TrimSpace(matchBy) {
+	case "", MatchByRFC3986, discoveryNamespace11 + "/rfc3986":
+		return matchRFC3986(string(s), string(scope))
+	case MatchByStrcmp0, discoveryNamespace11 + "/strcmp0":
+		return s == scope
+	}
+	return false
+}
+
+// matchRFC3986 compares the canonical forms of the scopes: same scheme and
+// authority, case insensitive, and the segments of the path of the Probe are
+// the first ones of the path of the service. The scopes with a . or ..
+// segment match nothing.
+func matchRFC3986(probe, scope string) bool {
+	p, err := url.Parse(probe)
+	if err != nil {
+		return false
+	}
+	s, err := url.Parse(scope)
+	if err != nil {
+		return false
+	}
+	if !strings.EqualFold(p.Scheme, s.Scheme) || !strings.EqualFold(p.Host, s.Host) {
+		return false
+	}
+	probeSegments, ok := segments(p)
+	if !ok {
+		return false
+	}
+	scopeSegments, ok := segments(s)
+	if !ok || len(probeSegments) > len(scopeSegments) {
+		return false
+	}
+	for i, segment := range probeSegments {
+		if segment != scopeSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// segments returns the unescaped segments of the path of u, false when one of
+// them is . or ..
+func segments(u *url.URL) ([]string, bool) {
+	path := strings.Trim(u.EscapedPath(), "/")
+	if path == "" {
+		return nil, true
+	}
+	out := strings.Split(path, "/")
+	for i, segment := range out {
+		if v, err := url.PathUnescape(segment); err == nil {
+			out[i] = v
+		}
+		if out[i] == "." || out[i] == ".." {
+			return nil, false
+		}
+	}
+	return out, true
+}
+
+// matchScopes tells if every scope of a Probe matches one of scopes.
+func matchScopes(probe []string, matchBy string, scopes []Scope) bool {
+	for _, p := range probe {
+		found := false
+		for _, scope := range scopes {
+			if Scope(p).Match(scope, matchBy) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// ScopeChanges are the requests of the device service that turn the
+// configurable scopes of a device into the ones wanted. Add and Remove, or
+// else Set alone, are sent.
+type ScopeChanges struct {
+	// Add and Remove are nil when there is nothing to add or to remove.
+	Add    *device.AddScopes
+	Remove *device.RemoveScopes
+	// Set replaces all the configurable scopes at once, it is nil when there
+	// is no change.
+	Set *device.SetScopes
+}
+
+// DiffScopes returns the changes of the scopes of a device, as returned by
+// device.GetScopes, into the ones wanted. The fixed scopes are never removed,
+// the ones wanted are kept as they are.
+func DiffScopes(current []onvif.Scope, wanted []Scope) ScopeChanges {
+	fixed := map[Scope]bool{}
+	configurable := map[Scope]bool{}
+	for _, scope := range current {
+		item := Scope(strings.TrimSpace(string(scope.ScopeItem)))
+		if scope.ScopeDef == "Fixed" {
+			fixed[item] = true
+		} else {
+			configurable[item] = true
+		}
+	}
+
+	var changes ScopeChanges
+	want := map[Scope]bool{}
+	var set []xsd.AnyURI
+	for _, scope := range wanted {
+		if want[scope] || fixed[scope] {
+			continue
+		}
+		want[scope] = true
+		set = append(set, xsd.AnyURI(scope))
+		if !configurable[scope] {
+			if changes.Add == nil {
+				changes.Add = &device.AddScopes{}
+			}
+			changes.Add.ScopeItem = append(changes.Add.ScopeItem, xsd.AnyURI(scope))
+		}
+	}
+	for _, scope := range current {
+		item := Scope(strings.TrimSpace(string(scope.ScopeItem)))
+		if scope.ScopeDef == "Fixed" || want[item] {
+			continue
+		}
+		if changes.Remove == nil {
+			changes.Remove = &device.RemoveScopes{}
+		}
+		changes.Remove.ScopeItem = append(changes.Remove.ScopeItem, xsd.AnyURI(item))
+	}
+	if changes.Add != nil || changes.Remove != nil {
+		changes.Set = &device.SetScopes{Scopes: set}
+	}
+	return changes
+}
@@ -0,0 +1,68 @@
+package wsdiscovery
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ritj/onvif/xsd"
+	"github.com/ritj/onvif/xsd/onvif"
+)
+
+func TestScope(t *testing.T) {
+	s := NewScope(ScopeLocation, "city/Saint Denis")
+	if s != "onvif://www.onvif.org/location/city/Saint%20Denis" {
+		t.Errorf("unexpected scope %s", s)
+	}
+	if category, value, ok := s.ONVIF(); !ok || category != ScopeLocation || value != "city/Saint Denis" {
+		t.Errorf("unexpected %s %s %v", category, value, ok)
+	}
+	if _, _, ok := Scope("http://example.com/name/x").ONVIF(); ok {
+		t.Error("unexpected ONVIF scope")
+	}
+
+	for _, test := range []struct {
+		probe, scope Scope
+		matchBy      string
+		match        bool
+	}{
+		{"onvif://www.onvif.org/location", s, "", true},
+		{"ONVIF://WWW.ONVIF.ORG/location/city/", s, "", true},
+		{"onvif://www.onvif.org/location/city/Saint Denis", s, MatchByRFC3986, true},
+		{"onvif://www.onvif.org/location/cit", s, "", false},
+		{"onvif://www.onvif.org/location/city/Saint%20Denis/north", s, "", false},
+		{"onvif://www.onvif.org/location/../location", s, "", false},
+		{"onvif://www.onvif.org/location/city/Saint%20Denis", s, MatchByStrcmp0, true},
+		{"onvif://www.onvif.org/location/city", s, MatchByStrcmp0, false},
+		{"onvif://www.onvif.org/location", s, "http://example.com/other", false},
+	} {
+		if test.probe.Match(test.scope, test.matchBy) != test.match {
+			t.Errorf("%s %s %s: expected %v", test.probe, test.scope, test.matchBy, test.match)
+		}
+	}
+}
+
+func TestDiffScopes(t *testing.T) {
+	current := []onvif.Scope{
+		{ScopeDef: "Fixed", ScopeItem: "onvif://www.onvif.org/hardware/M3045"},
+		{ScopeDef: "Configurable", ScopeItem: "onvif://www.onvif.org/name/Camera%201"},
+		{ScopeDef: "Configurable", ScopeItem: "onvif://www.onvif.org/location/city/Paris"},
+	}
+	changes := DiffScopes(current, []Scope{
+		"onvif://www.onvif.org/hardware/M3045",
+		NewScope(ScopeName, "Camera 1"),
+		NewScope(ScopeLocation, "city/Lyon"),
+	})
+	if changes.Add == nil || !reflect.DeepEqual(changes.Add.ScopeItem, []xsd.AnyURI{"onvif://www.onvif.org/location/city/Lyon"}) {
+		t.Errorf("unexpected AddScopes %+v", changes.Add)
+	}
+	if changes.Remove == nil || !reflect.DeepEqual(changes.Remove.ScopeItem, []xsd.AnyURI{"onvif://www.onvif.org/location/city/Paris"}) {
+		t.Errorf("unexpected RemoveScopes %+v", changes.Remove)
+	}
+	if changes.Set == nil || !reflect.DeepEqual(changes.Set.Scopes, []xsd.AnyURI{"onvif://www.onvif.org/name/Camera%201", "onvif://www.onvif.org/location/city/Lyon"}) {
+		t.Errorf("unexpected SetScopes %+v", changes.Set)
+	}
+
+	if changes := DiffScopes(current, []Scope{"onvif://www.onvif.org/name/Camera%201", "onvif://www.onvif.org/location/city/Paris"}); changes != (ScopeChanges{}) {
+		t.Errorf("unexpected changes %+v", changes)
+	}
+}
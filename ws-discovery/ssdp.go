@@ -0,0 +1,167 @@
+package wsdiscovery
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ssdpAddr is the group and port of SSDP, the group of WS-Discovery.
+var ssdpAddr = &net.UDPAddr{IP: multicastGroup, Port: 1900}
+
+// SSDP is the Backend of the Simple Service Discovery Protocol of UPnP. The
+// devices are delivered with the friendly name and the model of their
+// description as ScopeName and ScopeHardware, the serial number of the Axis
+// devices, which is their MAC, as MAC, and as XAddrs the device service at its
+// usual path on the host of their presentation page. As many devices that are
+// not cameras answer SSDP, e.g. routers and TVs, only the ones whose device
+// service answers are delivered.
+type SSDP struct {
+	// Target is the search target of the M-SEARCH, upnp:rootdevice when empty.
+	Target string
+}
+
+// Name returns ssdp.
+func (SSDP) Name() string { return "ssdp" }
+
+// Discover multicasts an M-SEARCH on the interfaces of opts, whose Types and
+// Scopes are ignored, and returns the devices that replied, described by the
+// description at their LOCATION, until opts.Timeout or until ctx is done. A
+// device whose description fails is delivered with its IP alone, when its
+// device service answers on port 80.
+func (b SSDP) Discover(ctx context.Context, opts DiscoverOptions) (<-chan ProbeMatch, error) {
+	target := b.Target
+	if target == "" {
+		target = "upnp:rootdevice"
+	}
+	cancel := func() {}
+	if opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+	}
+	search := fmt.Sprintf("M-SEARCH * HTTP/1.1\r\nHOST: %s\r\nMAN: \"ssdp:discover\"\r\nMX: 1\r\nST: %s\r\n\r\n", ssdpAddr, target)
+	client := checkClient(time.Second)
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	found, err := multicast(ctx, ssdpAddr, [][]byte{[]byte(search)}, opts, func(p net.PacketConn, found chan<- ProbeMatch) {
+		// The devices are checked at once, the replies are sent on found
+		// before it is closed.
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		buf := make([]byte, 65535)
+		for {
+			n, src, err := p.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			addr, ok := src.(*net.UDPAddr)
+			if !ok {
+				continue
+			}
+			resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+			if err != nil || resp.StatusCode != http.StatusOK {
+				continue
+			}
+			mu.Lock()
+			known := seen[addr.IP.String()]
+			seen[addr.IP.String()] = true
+			mu.Unlock()
+			if known {
+				continue
+			}
+			wg.Add(1)
+			go func(ip net.IP, location string) {
+				defer wg.Done()
+				m, ok := ssdpMatch(ctx, client, ip, location)
+				if !ok {
+					return
+				}
+				select {
+				case found <- m:
+				case <-ctx.Done():
+				}
+			}(addr.IP, resp.Header.Get("Location"))
+		}
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	out := make(chan ProbeMatch, 16)
+	go func() {
+		defer cancel()
+		defer close(out)
+		// The connections kept alive by the checks are closed once they end.
+		defer client.CloseIdleConnections()
+		for m := range found {
+			select {
+			case out <- m:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return out, nil
+}
+
+// upnpDescription is the description of a root device of UPnP.
+type upnpDescription struct {
+	Device struct {
+		FriendlyName    string `xml:"friendlyName"`
+		Manufacturer    string `xml:"manufacturer"`
+		ModelName       string `xml:"modelName"`
+		SerialNumber    string `xml:"serialNumber"`
+		PresentationURL string `xml:"presentationURL"`
+	} `xml:"device"`
+}
+
+// ssdpMatch returns the ProbeMatch of a device that replied from ip, with
+// the description at location. It returns false when no ONVIF device service
+// answers at the XAddrs.
+func ssdpMatch(ctx context.Context, client *http.Client, ip net.IP, location string) (ProbeMatch, bool) {
+	m := ProbeMatch{From: ip, Sources: []string{"ssdp"}}
+	m.XAddrs = []url.URL{{Scheme: "http", Host: ip.String(), Path: "/onvif/device_service"}}
+	var desc upnpDescription
+	if loc, err := url.Parse(location); err == nil && loc.IsAbs() && fetchDescription(ctx, client, loc, &desc) == nil {
+		if desc.Device.FriendlyName != "" {
+			m.Scopes = append(m.Scopes, NewScope(ScopeName, strings.TrimSpace(desc.Device.FriendlyName)))
+		}
+		if desc.Device.ModelName != "" {
+			m.Scopes = append(m.Scopes, NewScope(ScopeHardware, strings.TrimSpace(desc.Device.ModelName)))
+		}
+		// The serial numbers of the other brands are not MACs, even when
+		// written as 12 hexadecimal digits.
+		if strings.Contains(strings.ToLower(desc.Device.Manufacturer), "axis") {
+			m.MAC = parseHexMAC(desc.Device.SerialNumber)
+		}
+		if p, err := loc.Parse(strings.TrimSpace(desc.Device.PresentationURL)); err == nil && desc.Device.PresentationURL != "" &&
+			(p.Scheme == "http" || p.Scheme == "https") {
+			m.XAddrs = []url.URL{{Scheme: p.Scheme, Host: p.Host, Path: "/onvif/device_service"}}
+		}
+	}
+	return m, checkXAddr(ctx, client, m.XAddrs[0])
+}
+
+// fetchDescription reads the description of a device.
+func fetchDescription(ctx context.Context, client *http.Client, location *url.URL, desc *upnpDescription) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("description %s: %s", location, resp.Status)
+	}
+	return xml.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(desc)
+}
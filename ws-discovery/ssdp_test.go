@@ -0,0 +1,64 @@
+package wsdiscovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSSDPMatch(t *testing.T) {
+	manufacturer, onvif := "Axis Communications AB", true
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/onvif/device_service" {
+			if !onvif {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope"><s:Body><tds:GetSystemDateAndTimeResponse xmlns:tds="http://www.onvif.org/ver10/device/wsdl"/></s:Body></s:Envelope>`))
+			return
+		}
+		fmt.Fprintf(w, `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0"><device>
+	<friendlyName>AXIS M3045 - 00408C123456</friendlyName>
+	<manufacturer>%s</manufacturer>
+	<modelName>AXIS M3045</modelName>
+	<serialNumber>00408C123456</serialNumber>
+	<presentationURL>%s/</presentationURL>
+</device></root>`, manufacturer, srv.URL)
+	}))
+	defer srv.Close()
+
+	ip := net.IPv4(127, 0, 0, 1)
+	m, ok := ssdpMatch(context.Background(), srv.Client(), ip, srv.URL+"/rootdesc.xml")
+	if !ok || m.XAddrs[0].String() != srv.URL+"/onvif/device_service" || m.MAC.String() != "00:40:8c:12:34:56" ||
+		m.ScopeValues(ScopeHardware)[0] != "AXIS M3045" || m.ScopeValues(ScopeName)[0] != "AXIS M3045 - 00408C123456" {
+		t.Errorf("unexpected %+v", m)
+	}
+
+	// The serial numbers of the other brands are not MACs.
+	manufacturer = "ACME"
+	if m, ok := ssdpMatch(context.Background(), srv.Client(), ip, srv.URL+"/rootdesc.xml"); !ok || m.MAC != nil {
+		t.Errorf("unexpected %+v", m)
+	}
+
+	// A device without an ONVIF device service, e.g. a TV.
+	onvif = false
+	if m, ok := ssdpMatch(context.Background(), srv.Client(), ip, srv.URL+"/rootdesc.xml"); ok {
+		t.Errorf("unexpected %+v", m)
+	}
+}
+
+func TestParseMAC(t *testing.T) {
+	if mac := parseMAC("00408C123456"); mac != nil {
+		t.Errorf("serial number taken as the MAC %s", mac)
+	}
+	for _, s := range []string{"00408C123456", "00:40:8c:12:34:56", " 00-40-8C-12-34-56 "} {
+		if mac := parseHexMAC(s); mac.String() != "00:40:8c:12:34:56" {
+			t.Errorf("parseHexMAC(%q) = %s", s, mac)
+		}
+	}
+}
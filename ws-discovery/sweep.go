@@ -0,0 +1,259 @@
+package wsdiscovery
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// getSystemDateAndTime is the request answered without authentication by
+// every ONVIF device.
+const getSystemDateAndTime = `<?xml version="1.0" encoding="UTF-8"?>
+<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope"><s:Body><GetSystemDateAndTime xmlns="http://www.onvif.org/ver10/device/wsdl"/></s:Body></s:Envelope>`
+
+// ProbeUnicast sends a Probe to port 3702 of ip, for the devices out of reach
+// of multicast, and returns its replies. It waits for opts.Timeout, a second
+// when zero, or until ctx is done.
+func ProbeUnicast(ctx context.Context, ip net.IP, opts DiscoverOptions) ([]ProbeMatch, error) {
+	if len(opts.Types) == 0 {
+		opts.Types = []string{"dn:NetworkVideoTransmitter"}
+		opts.Namespaces = map[string]string{"dn": "http://www.onvif.org/ver10/network/wsdl"}
+	}
+	var probes []request
+	for _, v := range versionsOf(opts.Versions) {
+		id := uuid.Must(uuid.NewV4()).String()
+		probes = append(probes, request{id, buildProbeMessageTo(v, id, "", opts.Scopes, opts.Types, opts.Namespaces).String()})
+	}
+	return probeUnicast(ctx, &net.UDPAddr{IP: ip, Port: multicastAddr.Port}, probes, opts)
+}
+
+// probeUnicast sends the probes to dst and returns the replies.
+func probeUnicast(ctx context.Context, dst *net.UDPAddr, probes []request, opts DiscoverOptions) ([]ProbeMatch, error) {
+	if opts.Repeat == 0 {
+		opts.Repeat = multicastUDPRepeat
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	conn, err := net.ListenPacket("udp", ":0")
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	ids := make([]string, len(probes))
+	for i, probe := range probes {
+		ids[i] = "uuid:" + probe.id
+		go repeat(ctx, opts.Repeat, func(body []byte) func() error {
+			return func() error {
+				_, err := conn.WriteTo(body, dst)
+				return err
+			}
+		}([]byte(probe.body)))
+	}
+
+	found := make(chan ProbeMatch)
+	go func() {
+		receiveMatches(ctx, conn, ids, found)
+		close(found)
+	}()
+	var matches []ProbeMatch
+	for m := range found {
+		matches = append(matches, m)
+	}
+	return dedupe(matches), nil
+}
+
+// SweepOptions are the settings of Sweep, the zero values select the defaults.
+type SweepOptions struct {
+	// DiscoverOptions are the settings of the unicast Probe of every host,
+	// whose Timeout is also the one of its HTTP requests.
+	DiscoverOptions
+	// Ports are the ports of the device service checked, 80, 8000, 8080
+	// and 443 when empty. 443 is reached with HTTPS, the others with HTTP.
+	Ports []int
+	// Rate is the number of hosts checked per second, 20 when zero, at most
+	// 1e9.
+	Rate int
+	// Concurrency is the number of hosts checked at once, 64 when zero.
+	Concurrency int
+}
+
+// Sweep looks for the devices of a network, e.g. 10.1.2.0/24, that multicast
+// does not reach. Every host is sent a unicast Probe and an unauthenticated
+// GetSystemDateAndTime on the ports of the device service. The hosts that
+// reply are delivered on the channel, closed at the end of the sweep, as the
+// ProbeMatch they replied or else as a ProbeMatch without endpoint reference
+// whose XAddrs are the device services that answered.
+func Sweep(ctx context.Context, cidr string, opts SweepOptions) (<-chan ProbeMatch, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	ones, bits := network.Mask.Size()
+	if network.IP.To4() == nil || bits-ones > 16 {
+		return nil, fmt.Errorf("%s is not an IPv4 network of at most 65536 addresses", cidr)
+	}
+	if opts.Rate < 0 || opts.Rate > int(time.Second) {
+		return nil, fmt.Errorf("rate %d is negative or above %d hosts per second", opts.Rate, int(time.Second))
+	}
+	if opts.Concurrency < 0 {
+		return nil, fmt.Errorf("negative concurrency %d", opts.Concurrency)
+	}
+	if len(opts.Ports) == 0 {
+		opts.Ports = []int{80, 8000, 8080, 443}
+	}
+	if opts.Rate == 0 {
+		opts.Rate = 20
+	}
+	if opts.Concurrency == 0 {
+		opts.Concurrency = 64
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = time.Second
+	}
+	client := checkClient(opts.Timeout)
+
+	out := make(chan ProbeMatch, 16)
+	go func() {
+		defer close(out)
+		tick := time.NewTicker(time.Second / time.Duration(opts.Rate))
+		defer tick.Stop()
+		slots := make(chan struct{}, opts.Concurrency)
+		// The connections kept alive by the checks are closed once they end.
+		defer client.CloseIdleConnections()
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		for _, ip := range hosts(network) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick.C:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case slots <- struct{}{}:
+			}
+			wg.Add(1)
+			go func(ip net.IP) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				for _, m := range checkHost(ctx, client, ip, opts) {
+					select {
+					case out <- m:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}(ip)
+		}
+	}()
+	return out, nil
+}
+
+// checkClient returns the client of the checks of the devices, whose
+// requests time out after timeout.
+func checkClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// The devices have self-signed certificates, and nothing is
+			// sent but a request any client may send.
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+}
+
+// checkHost probes ip and checks its device service at once.
+func checkHost(ctx context.Context, client *http.Client, ip net.IP, opts SweepOptions) []ProbeMatch {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var matches []ProbeMatch
+	var xaddrs []url.URL
+	wg.Add(1 + len(opts.Ports))
+	go func() {
+		defer wg.Done()
+		m, _ := ProbeUnicast(ctx, ip, opts.DiscoverOptions)
+		mu.Lock()
+		matches = m
+		mu.Unlock()
+	}()
+	for _, port := range opts.Ports {
+		go func(port int) {
+			defer wg.Done()
+			if u, ok := checkDeviceService(ctx, client, ip, port); ok {
+				mu.Lock()
+				xaddrs = append(xaddrs, u)
+				mu.Unlock()
+			}
+		}(port)
+	}
+	wg.Wait()
+	if len(matches) > 0 || len(xaddrs) == 0 {
+		return matches
+	}
+	return []ProbeMatch{{Endpoint: Endpoint{XAddrs: xaddrs}, From: ip}}
+}
+
+// checkDeviceService tells if an ONVIF device service answers on a port.
+func checkDeviceService(ctx context.Context, client *http.Client, ip net.IP, port int) (url.URL, bool) {
+	u := url.URL{Scheme: "http", Host: net.JoinHostPort(ip.String(), strconv.Itoa(port)), Path: "/onvif/device_service"}
+	if port == 443 {
+		u.Scheme = "https"
+	}
+	return u, checkXAddr(ctx, client, u)
+}
+
+// checkXAddr tells if an ONVIF device service answers at a URL.
+func checkXAddr(ctx context.Context, client *http.Client, u url.URL) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewBufferString(getSystemDateAndTime))
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Content-Type", "application/soap+xml; charset=utf-8")
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	// A fault, e.g. of a device that requires authentication anyway, also
+	// shows a SOAP service.
+	return bytes.Contains(body, []byte("GetSystemDateAndTimeResponse")) ||
+		bytes.Contains(body, []byte("http://www.w3.org/2003/05/soap-envelope"))
+}
+
+// hosts returns the addresses of an IPv4 network, but its network and
+// broadcast addresses when it has more than two.
+func hosts(network *net.IPNet) []net.IP {
+	ones, bits := network.Mask.Size()
+	first := binary.BigEndian.Uint32(network.IP.To4())
+	size := uint32(1) << uint(bits-ones)
+	var out []net.IP
+	for i := uint32(0); i < size; i++ {
+		if size > 2 && (i == 0 || i == size-1) {
+			continue
+		}
+		ip := make(net.IP, 4)
+		binary.BigEndian.PutUint32(ip, first+i)
+		out = append(out, ip)
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package wsdiscovery
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSweep(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if r.URL.Path != "/onvif/device_service" || !strings.Contains(string(b), "GetSystemDateAndTime") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope"><s:Body><tds:GetSystemDateAndTimeResponse xmlns:tds="http://www.onvif.org/ver10/device/wsdl"/></s:Body></s:Envelope>`))
+	}))
+	defer srv.Close()
+	_, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+
+	// A unicast responder, when the port is free.
+	conn, err := net.ListenPacket("udp4", "127.0.0.2:3702")
+	responder := err == nil
+	if responder {
+		defer conn.Close()
+		go func() {
+			buf := make([]byte, 65535)
+			for {
+				n, from, err := conn.ReadFrom(buf)
+				if err != nil {
+					return
+				}
+				env, err := parseEnvelope(buf[:n])
+				if err != nil {
+					continue
+				}
+				conn.WriteTo([]byte(strings.NewReplacer("%d", "1", "uuid:00000000-0000-4000-8000-0000000000aa", env.Header.MessageID).Replace(probeMatches)), from)
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	results, err := Sweep(ctx, "127.0.0.0/30", SweepOptions{
+		DiscoverOptions: DiscoverOptions{Timeout: 300 * time.Millisecond},
+		Ports:           []int{p},
+		Rate:            100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byIP := map[string]ProbeMatch{}
+	for m := range results {
+		byIP[m.From.String()] = m
+	}
+	if m, ok := byIP["127.0.0.1"]; !ok || m.Address != "" || len(m.XAddrs) != 1 || m.XAddrs[0].Port() != port {
+		t.Errorf("unexpected result for the device service %+v", m)
+	}
+	if m, ok := byIP["127.0.0.2"]; responder && (!ok || m.Address != "urn:uuid:4b3ad94a-1f84-4b7e-a0f3-2d3c1b1e1a4f") {
+		t.Errorf("unexpected result for the unicast probe %+v", m)
+	}
+
+	if _, err := Sweep(ctx, "10.0.0.0/8", SweepOptions{}); err == nil {
+		t.Error("expected an error for a too large network")
+	}
+	for _, rate := range []int{-1, int(time.Second) + 1} {
+		if _, err := Sweep(ctx, "127.0.0.0/30", SweepOptions{Rate: rate}); err == nil {
+			t.Errorf("expected an error for the rate %d", rate)
+		}
+	}
+}
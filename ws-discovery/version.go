@@ -0,0 +1,75 @@
+package wsdiscovery
+
+// Version is a version of WS-Discovery, whose messages differ by their
+// namespaces.
+type Version int
+
+// The versions of WS-Discovery
+const (
+	// Version2005 is the draft of April 2005, the one of ONVIF.
+	Version2005 Version = iota
+	// Version11 is WS-Discovery 1.1, the OASIS standard of 2009.
+	Version11
+)
+
+// The namespaces of the versions
+const (
+	discoveryNamespace    = "http://schemas.xmlsoap.org/ws/2005/04/discovery"
+	addressingNamespace   = "http://schemas.xmlsoap.org/ws/2004/08/addressing"
+	discoveryNamespace11  = "http://docs.oasis-open.org/ws-dd/ns/discovery/2009/01"
+	addressingNamespace11 = "http://www.w3.org/2005/08/addressing"
+)
+
+// allVersions are the versions of the messages sent when none is given, the
+// one of ONVIF first.
+var allVersions = []Version{Version2005, Version11}
+
+func (v Version) String() string {
+	if v == Version11 {
+		return "1.1"
+	}
+	return "2005/04"
+}
+
+// versionNamespaces are the namespaces and the URIs of a version.
+type versionNamespaces struct {
+	discovery, addressing string
+	// adHoc is the To of the multicast messages.
+	adHoc string
+	// anonymous is the address of the clients replied to on their connection.
+	anonymous string
+}
+
+func (v Version) namespaces() versionNamespaces {
+	if v == Version11 {
+		return versionNamespaces{
+			discovery:  discoveryNamespace11,
+			addressing: addressingNamespace11,
+			adHoc:      "urn:docs-oasis-open-org:ws-dd:ns:discovery:2009:01",
+			anonymous:  addressingNamespace11 + "/anonymous",
+		}
+	}
+	return versionNamespaces{
+		discovery:  discoveryNamespace,
+		addressing: addressingNamespace,
+		adHoc:      "urn:schemas-xmlsoap-org:ws:2005:04:discovery",
+		anonymous:  addressingNamespace + "/role/anonymous",
+	}
+}
+
+// versionOf returns the version of a namespace of discovery, Version2005 when
+// it is unknown.
+func versionOf(namespace string) Version {
+	if namespace == discoveryNamespace11 {
+		return Version11
+	}
+	return Version2005
+}
+
+// versionsOf returns versions, or all of them when empty.
+func versionsOf(versions []Version) []Version {
+	if len(versions) == 0 {
+		return allVersions
+	}
+	return versions
+}
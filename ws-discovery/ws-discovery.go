@@ -8,10 +8,22 @@ import (
 )
 
 func buildProbeMessage(uuidV4 string, scopes, types []string, nmsp map[string]string) gosoap.SoapMessage {
+	return buildProbeMessageTo(Version2005, uuidV4, "", scopes, types, nmsp)
+}
+
+// buildProbeMessageTo builds a Probe of a version sent to the destination to,
+// e.g. the address of a discovery proxy, or multicast when it is empty.
+func buildProbeMessageTo(version Version, uuidV4, destination string, scopes, types []string, nmsp map[string]string) gosoap.SoapMessage {
+	ns := version.namespaces()
+	if destination == "" {
+		destination = ns.adHoc
+	}
+
 	//Список namespace
 	namespaces := make(map[string]string)
-	namespaces["a"] = "http://schemas.xmlsoap.org/ws/2004/08/addressing"
-	//namespaces["d"] = "http://schemas.xmlsoap.org/ws/2005/04/discovery"
+	namespaces["a"] = ns.addressing
+	// The prefix of Types and Scopes.
+	namespaces["d"] = ns.discovery
 
 	probeMessage := gosoap.NewEmptySOAP()
 
@@ -26,17 +38,17 @@ func buildProbeMessage(uuidV4 string, scopes, types []string, nmsp map[string]st
 	var headerContent []*etree.Element
 
 	action := etree.NewElement("a:Action")
-	action.SetText("http://schemas.xmlsoap.org/ws/2005/04/discovery/Probe")
+	action.SetText(ns.discovery + "/Probe")
 	action.CreateAttr("mustUnderstand", "1")
 
 	msgID := etree.NewElement("a:MessageID")
 	msgID.SetText("uuid:" + uuidV4)
 
 	replyTo := etree.NewElement("a:ReplyTo")
-	replyTo.CreateElement("a:Address").SetText("http://schemas.xmlsoap.org/ws/2004/08/addressing/role/anonymous")
+	replyTo.CreateElement("a:Address").SetText(ns.anonymous)
 
 	to := etree.NewElement("a:To")
-	to.SetText("urn:schemas-xmlsoap-org:ws:2005:04:discovery")
+	to.SetText(destination)
 	to.CreateAttr("mustUnderstand", "1")
 
 	headerContent = append(headerContent, action, msgID, replyTo, to)
@@ -44,7 +56,7 @@ func buildProbeMessage(uuidV4 string, scopes, types []string, nmsp map[string]st
 
 	//Содержимое Body
 	probe := etree.NewElement("Probe")
-	probe.CreateAttr("xmlns", "http://schemas.xmlsoap.org/ws/2005/04/discovery")
+	probe.CreateAttr("xmlns", ns.discovery)
 
 	if len(types) != 0 {
 		typesTag := etree.NewElement("d:Types")
@@ -53,7 +65,6 @@ func buildProbeMessage(uuidV4 string, scopes, types []string, nmsp map[string]st
 				typesTag.CreateAttr("xmlns:"+key, value)
 			}
 		}
-		typesTag.CreateAttr("xmlns:d", "http://schemas.xmlsoap.org/ws/2005/04/discovery")
 		//typesTag.CreateAttr("xmlns:dp0", "http://www.onvif.org/ver10/network/wsdl")
 		var typesString string
 		for _, j := range types {
@@ -82,3 +93,33 @@ func buildProbeMessage(uuidV4 string, scopes, types []string, nmsp map[string]st
 
 	return probeMessage
 }
+
+// buildResolveMessage builds the Resolve of a version of the target service
+// of an endpoint reference.
+func buildResolveMessage(version Version, uuidV4, address string) gosoap.SoapMessage {
+	ns := version.namespaces()
+	resolveMessage := gosoap.NewEmptySOAP()
+	resolveMessage.AddRootNamespaces(map[string]string{"a": ns.addressing, "d": ns.discovery})
+
+	action := etree.NewElement("a:Action")
+	action.SetText(ns.discovery + "/Resolve")
+	action.CreateAttr("mustUnderstand", "1")
+
+	msgID := etree.NewElement("a:MessageID")
+	msgID.SetText("uuid:" + uuidV4)
+
+	replyTo := etree.NewElement("a:ReplyTo")
+	replyTo.CreateElement("a:Address").SetText(ns.anonymous)
+
+	to := etree.NewElement("a:To")
+	to.SetText(ns.adHoc)
+	to.CreateAttr("mustUnderstand", "1")
+
+	resolveMessage.AddHeaderContents([]*etree.Element{action, msgID, replyTo, to})
+
+	resolve := etree.NewElement("d:Resolve")
+	resolve.CreateElement("a:EndpointReference").CreateElement("a:Address").SetText(address)
+	resolveMessage.AddBodyContent(resolve)
+
+	return resolveMessage
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -204,6 +205,57 @@ func (tp Duration) NewDateTime(years, months, days, hours, minutes, seconds stri
 	return Duration(i.ISO8601Duration())
 }
 
+/*
+Construct an instance of xsd duration type from a time.Duration, e.g. PT1M30S
+*/
+func (tp Duration) NewDuration(d time.Duration) Duration {
+	result := "PT"
+	if d < 0 {
+		result, d = "-PT", -d
+	}
+	if h := d / time.Hour; h > 0 {
+		result += strconv.FormatInt(int64(h), 10) + "H"
+		d -= h * time.Hour
+	}
+	if m := d / time.Minute; m > 0 {
+		result += strconv.FormatInt(int64(m), 10) + "M"
+		d -= m * time.Minute
+	}
+	if d > 0 || strings.HasSuffix(result, "T") {
+		result += strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S"
+	}
+	return Duration(result)
+}
+
+var durationPattern = regexp.MustCompile(`^(-)?P(?:([0-9.]+)Y)?(?:([0-9.]+)M)?(?:([0-9.]+)W)?(?:([0-9.]+)D)?(?:T(?:([0-9.]+)H)?(?:([0-9.]+)M)?(?:([0-9.]+)S)?)?$`)
+
+/*
+Duration returns the time.Duration of an xsd duration, a year and a month
+being counted as 365 and 30 days.
+*/
+func (tp Duration) Duration() (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(strings.TrimSpace(string(tp)))
+	if m == nil || strings.HasSuffix(string(tp), "P") || strings.HasSuffix(string(tp), "T") {
+		return 0, errors.New("invalid duration " + string(tp))
+	}
+	units := []time.Duration{365 * 24 * time.Hour, 30 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(m[i+2], 64)
+		if err != nil {
+			return 0, errors.New("invalid duration " + string(tp))
+		}
+		d += time.Duration(v * float64(unit))
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
 /*
 DateTime values may be viewed as objects with integer-valued year, month, day, hour
 and minute properties, a decimal-valued second property, and a boolean timezoned property.
@@ -233,7 +285,20 @@ type DateTime AnySimpleType
 Construct an instance of xsd dateTime type
 */
 func (tp DateTime) NewDateTime(time time.Time) DateTime {
-	return DateTime(time.Format("2002-10-10T12:00:00-05:00"))
+	return DateTime(time.Format("2006-01-02T15:04:05.999999999Z07:00"))
+}
+
+/*
+Time parses an xsd dateTime, a value without a timezone being taken as UTC.
+*/
+func (tp DateTime) Time() (time.Time, error) {
+	v := strings.TrimSpace(string(tp))
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("invalid dateTime " + v)
 }
 
 /*
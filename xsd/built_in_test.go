@@ -0,0 +1,48 @@
+package xsd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	for in, want := range map[Duration]time.Duration{
+		"PT10S":    10 * time.Second,
+		"PT1M30S":  90 * time.Second,
+		"PT0.5S":   500 * time.Millisecond,
+		"P1DT2H":   26 * time.Hour,
+		"-PT1H":    -time.Hour,
+		"PT2H5M1S": 2*time.Hour + 5*time.Minute + time.Second,
+	} {
+		got, err := in.Duration()
+		if err != nil || got != want {
+			t.Errorf("%s.Duration() = %v, %v, want %v", in, got, err, want)
+		}
+		if in[0] == 'P' && in != "P1DT2H" {
+			if back := Duration("").NewDuration(want); back != in {
+				t.Errorf("NewDuration(%v) = %s, want %s", want, back, in)
+			}
+		}
+	}
+	for _, in := range []Duration{"", "P", "PT", "10S", "PT1X"} {
+		if _, err := in.Duration(); err == nil {
+			t.Errorf("%q.Duration() succeeded", in)
+		}
+	}
+	if d := Duration("").NewDuration(0); d != "PT0S" {
+		t.Errorf("NewDuration(0) = %s", d)
+	}
+}
+
+func TestDateTime(t *testing.T) {
+	want := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	for _, in := range []DateTime{"2024-03-01T12:30:00Z", "2024-03-01T12:30:00", "2024-03-01T13:30:00+01:00", "2024-03-01T12:30:00.000Z"} {
+		got, err := in.Time()
+		if err != nil || !got.Equal(want) {
+			t.Errorf("%s.Time() = %v, %v", in, got, err)
+		}
+	}
+	if got, _ := DateTime("").NewDateTime(want).Time(); !got.Equal(want) {
+		t.Errorf("NewDateTime round trip gave %v", got)
+	}
+}
@@ -1,7 +1,9 @@
 package onvif
 
 import (
+	"encoding/xml"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/ritj/onvif/xsd"
@@ -495,37 +497,251 @@ type VideoAnalyticsConfiguration struct {
 }
 
 type AnalyticsEngineConfiguration struct {
-	AnalyticsModule Config                                `xml:"AnalyticsModule"`
+	AnalyticsModule []Config                              `xml:"AnalyticsModule"`
 	Extension       AnalyticsEngineConfigurationExtension `xml:"Extension"`
 }
 
+// Config is the configuration of an analytics module or of a rule, whose
+// parameters are described by the ConfigDescription of its Type.
 type Config struct {
 	Name       string    `xml:"Name,attr"`
 	Type       xsd.QName `xml:"Type,attr"`
 	Parameters ItemList  `xml:"Parameters"`
+	// Namespaces are the namespaces in scope where the Config was read, by
+	// prefix, as its Type and the content of its ElementItem may use them,
+	// e.g. axis for axis:LineDetector. The default namespace is not kept.
+	Namespaces map[string]string `xml:"-"`
+}
+
+// UnmarshalXML keeps the namespaces declared on the Config, see
+// InheritNamespaces for the ones of its ancestors.
+func (c *Config) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type config Config
+	if err := d.DecodeElement((*config)(c), &start); err != nil {
+		return err
+	}
+	for _, a := range start.Attr {
+		if a.Name.Space == "xmlns" {
+			if c.Namespaces == nil {
+				c.Namespaces = map[string]string{}
+			}
+			c.Namespaces[a.Name.Local] = a.Value
+		}
+	}
+	return nil
+}
+
+// InheritNamespaces adds the namespaces declared on the ancestors of the
+// Config, which its UnmarshalXML does not see, unless their prefix is bound
+// on the Config itself.
+func (c *Config) InheritNamespaces(namespaces map[string]string) {
+	for prefix, uri := range namespaces {
+		if _, ok := c.Namespaces[prefix]; ok || prefix == "" {
+			continue
+		}
+		if c.Namespaces == nil {
+			c.Namespaces = map[string]string{}
+		}
+		c.Namespaces[prefix] = uri
+	}
+}
+
+// MarshalXML writes the parameters in the tt namespace and declares the
+// Namespaces of the Config, so that a Config read from a device can be sent
+// back as is, e.g. from GetRules to ModifyRules, even when its Type or its
+// ElementItem are of a vendor namespace.
+func (c Config) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type elementItem struct {
+		Name    string `xml:"Name,attr"`
+		Content string `xml:",innerxml"`
+	}
+	type simpleItem struct {
+		Name  string            `xml:"Name,attr"`
+		Value xsd.AnySimpleType `xml:"Value,attr"`
+	}
+	out := struct {
+		Name       string    `xml:"Name,attr"`
+		Type       xsd.QName `xml:"Type,attr"`
+		Parameters struct {
+			SimpleItem  []simpleItem  `xml:"tt:SimpleItem"`
+			ElementItem []elementItem `xml:"tt:ElementItem"`
+		} `xml:"tt:Parameters"`
+	}{Name: c.Name, Type: c.Type}
+	prefixes := make([]string, 0, len(c.Namespaces))
+	for prefix := range c.Namespaces {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		// The encoder writes a local name as is, where an xmlns space would
+		// be taken for a namespace.
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:" + prefix}, Value: c.Namespaces[prefix]})
+	}
+	for _, item := range c.Parameters.SimpleItem {
+		out.Parameters.SimpleItem = append(out.Parameters.SimpleItem, simpleItem(item))
+	}
+	for _, item := range c.Parameters.ElementItem {
+		out.Parameters.ElementItem = append(out.Parameters.ElementItem, elementItem(item))
+	}
+	return e.EncodeElement(out, start)
 }
 
 type ItemList struct {
-	SimpleItem  SimpleItem        `xml:"SimpleItem"`
-	ElementItem ElementItem       `xml:"ElementItem"`
+	SimpleItem  []SimpleItem      `xml:"SimpleItem"`
+	ElementItem []ElementItem     `xml:"ElementItem"`
 	Extension   ItemListExtension `xml:"Extension"`
 }
 
+// Simple returns the value of the SimpleItem called name.
+func (l ItemList) Simple(name string) (xsd.AnySimpleType, bool) {
+	for _, item := range l.SimpleItem {
+		if item.Name == name {
+			return item.Value, true
+		}
+	}
+	return "", false
+}
+
+// SetSimple sets the value of the SimpleItem called name, adding it if missing.
+func (l *ItemList) SetSimple(name string, value xsd.AnySimpleType) {
+	for i := range l.SimpleItem {
+		if l.SimpleItem[i].Name == name {
+			l.SimpleItem[i].Value = value
+			return
+		}
+	}
+	l.SimpleItem = append(l.SimpleItem, SimpleItem{Name: name, Value: value})
+}
+
+// Element returns the ElementItem called name.
+func (l ItemList) Element(name string) (ElementItem, bool) {
+	for _, item := range l.ElementItem {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return ElementItem{}, false
+}
+
 type SimpleItem struct {
 	Name  string            `xml:"Name,attr"`
 	Value xsd.AnySimpleType `xml:"Value,attr"`
 }
 
+// ElementItem holds a complex parameter, e.g. a tt:Polyline or a
+// tt:CellLayout, as the raw XML of its content.
 type ElementItem struct {
-	Name string `xml:"Name,attr"`
+	Name    string `xml:"Name,attr"`
+	Content string `xml:",innerxml"`
 }
 
 type ItemListExtension xsd.AnyType
 
 type AnalyticsEngineConfigurationExtension xsd.AnyType
 
+// ConfigDescription describes the parameters of a type of analytics module or
+// rule, and the events that its instances emit.
+type ConfigDescription struct {
+	Name         xsd.QName                   `xml:"Name,attr"`
+	Fixed        *xsd.Boolean                `xml:"fixed,attr"`
+	MaxInstances *xsd.Integer                `xml:"maxInstances,attr"`
+	Parameters   ItemListDescription         `xml:"Parameters"`
+	Messages     []ConfigDescriptionMessages `xml:"Messages"`
+	Extension    *ConfigDescriptionExtension `xml:"Extension"`
+}
+
+type ConfigDescriptionMessages struct {
+	MessageDescription
+	ParentTopic string `xml:"ParentTopic"`
+}
+
+type ConfigDescriptionExtension xsd.AnyType
+
+// MessageDescription describes the items of the Source, Key and Data of the
+// notifications of a topic.
+type MessageDescription struct {
+	IsProperty *xsd.Boolean                 `xml:"IsProperty,attr"`
+	Source     *ItemListDescription         `xml:"Source"`
+	Key        *ItemListDescription         `xml:"Key"`
+	Data       *ItemListDescription         `xml:"Data"`
+	Extension  *MessageDescriptionExtension `xml:"Extension"`
+}
+
+type MessageDescriptionExtension xsd.AnyType
+
+type ItemListDescription struct {
+	SimpleItemDescription  []SimpleItemDescription       `xml:"SimpleItemDescription"`
+	ElementItemDescription []ElementItemDescription      `xml:"ElementItemDescription"`
+	Extension              *ItemListDescriptionExtension `xml:"Extension"`
+}
+
+type SimpleItemDescription struct {
+	Name string    `xml:"Name,attr"`
+	Type xsd.QName `xml:"Type,attr"`
+}
+
+type ElementItemDescription struct {
+	Name string    `xml:"Name,attr"`
+	Type xsd.QName `xml:"Type,attr"`
+}
+
+type ItemListDescriptionExtension xsd.AnyType
+
+type SupportedRules struct {
+	Limit                     *xsd.Int                 `xml:"Limit,attr"`
+	RuleContentSchemaLocation []xsd.AnyURI             `xml:"RuleContentSchemaLocation"`
+	RuleDescription           []ConfigDescription      `xml:"RuleDescription"`
+	Extension                 *SupportedRulesExtension `xml:"Extension"`
+}
+
+// Description returns the description of the rules of type ruleType, the
+// qualified name of the Type of a rule of the same device, e.g.
+// tt:LineDetector.
+func (r SupportedRules) Description(ruleType xsd.QName) (ConfigDescription, bool) {
+	return findDescription(r.RuleDescription, ruleType)
+}
+
+type SupportedRulesExtension xsd.AnyType
+
+type SupportedAnalyticsModules struct {
+	Limit                                *xsd.Int                            `xml:"Limit,attr"`
+	AnalyticsModuleContentSchemaLocation []xsd.AnyURI                        `xml:"AnalyticsModuleContentSchemaLocation"`
+	AnalyticsModuleDescription           []ConfigDescription                 `xml:"AnalyticsModuleDescription"`
+	Extension                            *SupportedAnalyticsModulesExtension `xml:"Extension"`
+}
+
+// Description returns the description of the analytics modules of type
+// moduleType, the qualified name of the Type of a module of the same device.
+func (m SupportedAnalyticsModules) Description(moduleType xsd.QName) (ConfigDescription, bool) {
+	return findDescription(m.AnalyticsModuleDescription, moduleType)
+}
+
+type SupportedAnalyticsModulesExtension xsd.AnyType
+
+// findDescription returns the description of the full qualified name, as a
+// vendor type and a standard one may share their local name, e.g.
+// axis:LineDetector and tt:LineDetector.
+func findDescription(descriptions []ConfigDescription, name xsd.QName) (ConfigDescription, bool) {
+	name = xsd.QName(strings.TrimSpace(string(name)))
+	for _, d := range descriptions {
+		if xsd.QName(strings.TrimSpace(string(d.Name))) == name {
+			return d, true
+		}
+	}
+	return ConfigDescription{}, false
+}
+
+// Frame is a frame of the scene description of a video analytics, its
+// objects are kept as the raw XML of its content.
+type Frame struct {
+	UtcTime    xsd.DateTime `xml:"UtcTime,attr"`
+	Colorspace string       `xml:"Colorspace,attr"`
+	Source     string       `xml:"Source,attr"`
+	Content    string       `xml:",innerxml"`
+}
+
 type RuleEngineConfiguration struct {
-	Rule      Config                           `xml:"Rule"`
+	Rule      []Config                         `xml:"Rule"`
 	Extension RuleEngineConfigurationExtension `xml:"Extension"`
 }
 
@@ -1034,8 +1250,9 @@ type PTZMoveStatus struct {
 	Zoom    MoveStatus
 }
 
+// MoveStatus is IDLE, MOVING or UNKNOWN, the text of its element.
 type MoveStatus struct {
-	Status string
+	Status string `xml:",chardata"`
 }
 
 type GeoLocation struct {
@@ -1859,6 +2076,151 @@ type AbsoluteFocus struct {
 	Speed    xsd.Float `xml:"Speed"`
 }
 
+type ImagingOptions20 struct {
+	BacklightCompensation *BacklightCompensationOptions20 `xml:"BacklightCompensation"`
+	Brightness            *FloatRange                     `xml:"Brightness"`
+	ColorSaturation       *FloatRange                     `xml:"ColorSaturation"`
+	Contrast              *FloatRange                     `xml:"Contrast"`
+	Exposure              *ExposureOptions20              `xml:"Exposure"`
+	Focus                 *FocusOptions20                 `xml:"Focus"`
+	IrCutFilterModes      []IrCutFilterMode               `xml:"IrCutFilterModes"`
+	Sharpness             *FloatRange                     `xml:"Sharpness"`
+	WideDynamicRange      *WideDynamicRangeOptions20      `xml:"WideDynamicRange"`
+	WhiteBalance          *WhiteBalanceOptions20          `xml:"WhiteBalance"`
+	Extension             *ImagingOptions20Extension      `xml:"Extension"`
+}
+
+type BacklightCompensationOptions20 struct {
+	Mode  []BacklightCompensationMode `xml:"Mode"`
+	Level *FloatRange                 `xml:"Level"`
+}
+
+type ExposureOptions20 struct {
+	Mode            []ExposureMode     `xml:"Mode"`
+	Priority        []ExposurePriority `xml:"Priority"`
+	MinExposureTime *FloatRange        `xml:"MinExposureTime"`
+	MaxExposureTime *FloatRange        `xml:"MaxExposureTime"`
+	MinGain         *FloatRange        `xml:"MinGain"`
+	MaxGain         *FloatRange        `xml:"MaxGain"`
+	MinIris         *FloatRange        `xml:"MinIris"`
+	MaxIris         *FloatRange        `xml:"MaxIris"`
+	ExposureTime    *FloatRange        `xml:"ExposureTime"`
+	Gain            *FloatRange        `xml:"Gain"`
+	Iris            *FloatRange        `xml:"Iris"`
+}
+
+type FocusOptions20 struct {
+	AutoFocusModes []AutoFocusMode          `xml:"AutoFocusModes"`
+	DefaultSpeed   *FloatRange              `xml:"DefaultSpeed"`
+	NearLimit      *FloatRange              `xml:"NearLimit"`
+	FarLimit       *FloatRange              `xml:"FarLimit"`
+	Extension      *FocusOptions20Extension `xml:"Extension"`
+}
+
+type FocusOptions20Extension xsd.AnyType
+
+type WideDynamicRangeOptions20 struct {
+	Mode  []WideDynamicMode `xml:"Mode"`
+	Level *FloatRange       `xml:"Level"`
+}
+
+type WhiteBalanceOptions20 struct {
+	Mode      []WhiteBalanceMode              `xml:"Mode"`
+	YrGain    *FloatRange                     `xml:"YrGain"`
+	YbGain    *FloatRange                     `xml:"YbGain"`
+	Extension *WhiteBalanceOptions20Extension `xml:"Extension"`
+}
+
+type WhiteBalanceOptions20Extension xsd.AnyType
+
+type ImagingOptions20Extension struct {
+	ImageStabilization *ImageStabilizationOptions  `xml:"ImageStabilization"`
+	Extension          *ImagingOptions20Extension2 `xml:"Extension"`
+}
+
+type ImageStabilizationOptions struct {
+	Mode      []ImageStabilizationMode            `xml:"Mode"`
+	Level     *FloatRange                         `xml:"Level"`
+	Extension *ImageStabilizationOptionsExtension `xml:"Extension"`
+}
+
+type ImageStabilizationOptionsExtension xsd.AnyType
+
+type ImagingOptions20Extension2 struct {
+	IrCutFilterAutoAdjustment *IrCutFilterAutoAdjustmentOptions `xml:"IrCutFilterAutoAdjustment"`
+	Extension                 *ImagingOptions20Extension3       `xml:"Extension"`
+}
+
+type IrCutFilterAutoAdjustmentOptions struct {
+	BoundaryType      []xsd.String                               `xml:"BoundaryType"`
+	BoundaryOffset    *xsd.Boolean                               `xml:"BoundaryOffset"`
+	ResponseTimeRange *DurationRange                             `xml:"ResponseTimeRange"`
+	Extension         *IrCutFilterAutoAdjustmentOptionsExtension `xml:"Extension"`
+}
+
+type IrCutFilterAutoAdjustmentOptionsExtension xsd.AnyType
+
+type ImagingOptions20Extension3 struct {
+	ToneCompensationOptions *ToneCompensationOptions    `xml:"ToneCompensationOptions"`
+	DefoggingOptions        *DefoggingOptions           `xml:"DefoggingOptions"`
+	NoiseReductionOptions   *NoiseReductionOptions      `xml:"NoiseReductionOptions"`
+	Extension               *ImagingOptions20Extension4 `xml:"Extension"`
+}
+
+type ToneCompensationOptions struct {
+	Mode  []xsd.String `xml:"Mode"`
+	Level xsd.Boolean  `xml:"Level"`
+}
+
+type DefoggingOptions struct {
+	Mode  []xsd.String `xml:"Mode"`
+	Level xsd.Boolean  `xml:"Level"`
+}
+
+type NoiseReductionOptions struct {
+	Level xsd.Boolean `xml:"Level"`
+}
+
+type ImagingOptions20Extension4 xsd.AnyType
+
+type MoveOptions20 struct {
+	Absolute   *AbsoluteFocusOptions   `xml:"Absolute"`
+	Relative   *RelativeFocusOptions20 `xml:"Relative"`
+	Continuous *ContinuousFocusOptions `xml:"Continuous"`
+}
+
+type AbsoluteFocusOptions struct {
+	Position FloatRange  `xml:"Position"`
+	Speed    *FloatRange `xml:"Speed"`
+}
+
+type RelativeFocusOptions20 struct {
+	Distance FloatRange  `xml:"Distance"`
+	Speed    *FloatRange `xml:"Speed"`
+}
+
+type ContinuousFocusOptions struct {
+	Speed FloatRange `xml:"Speed"`
+}
+
+type ImagingStatus20 struct {
+	FocusStatus20 *FocusStatus20            `xml:"FocusStatus20"`
+	Extension     *ImagingStatus20Extension `xml:"Extension"`
+}
+
+type ImagingStatus20Extension xsd.AnyType
+
+// FocusStatus20 reports the position of the focus lens. MoveStatus is one of
+// IDLE, MOVING or UNKNOWN.
+type FocusStatus20 struct {
+	Position   xsd.Float               `xml:"Position"`
+	MoveStatus MoveStatus              `xml:"MoveStatus"`
+	Error      xsd.String              `xml:"Error"`
+	Extension  *FocusStatus20Extension `xml:"Extension"`
+}
+
+type FocusStatus20Extension xsd.AnyType
+
 type DateTime struct {
 	Time Time `xml:"Time"`
 	Date Date `xml:"Date"`